@@ -1,21 +1,54 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/jokosaputro95/cms-go/config"
+	article_handlers "github.com/jokosaputro95/cms-go/internal/modules/article/handlers"
+	article_repositories "github.com/jokosaputro95/cms-go/internal/modules/article/repositories"
+	article_routes "github.com/jokosaputro95/cms-go/internal/modules/article/routes"
+	article_services "github.com/jokosaputro95/cms-go/internal/modules/article/services"
 	auth_hendlers "github.com/jokosaputro95/cms-go/internal/modules/auth/handlers"
 	auth_repositories "github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
 	auth_routes "github.com/jokosaputro95/cms-go/internal/modules/auth/routes"
 	auth_services "github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	media_handlers "github.com/jokosaputro95/cms-go/internal/modules/media/handlers"
+	media_repositories "github.com/jokosaputro95/cms-go/internal/modules/media/repositories"
+	media_routes "github.com/jokosaputro95/cms-go/internal/modules/media/routes"
+	media_services "github.com/jokosaputro95/cms-go/internal/modules/media/services"
 	profile_handlers "github.com/jokosaputro95/cms-go/internal/modules/profile/handlers"
 	profile_repositories "github.com/jokosaputro95/cms-go/internal/modules/profile/repositories"
 	profile_services "github.com/jokosaputro95/cms-go/internal/modules/profile/services"
+	region_data "github.com/jokosaputro95/cms-go/internal/modules/region/data"
+	region_handlers "github.com/jokosaputro95/cms-go/internal/modules/region/handlers"
+	region_repositories "github.com/jokosaputro95/cms-go/internal/modules/region/repositories"
+	region_routes "github.com/jokosaputro95/cms-go/internal/modules/region/routes"
+	region_services "github.com/jokosaputro95/cms-go/internal/modules/region/services"
+	role_handlers "github.com/jokosaputro95/cms-go/internal/modules/role/handlers"
+	role_models "github.com/jokosaputro95/cms-go/internal/modules/role/models"
+	role_repositories "github.com/jokosaputro95/cms-go/internal/modules/role/repositories"
+	role_routes "github.com/jokosaputro95/cms-go/internal/modules/role/routes"
+	role_services "github.com/jokosaputro95/cms-go/internal/modules/role/services"
 	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	taxonomy_handlers "github.com/jokosaputro95/cms-go/internal/modules/taxonomy/handlers"
+	taxonomy_repositories "github.com/jokosaputro95/cms-go/internal/modules/taxonomy/repositories"
+	taxonomy_routes "github.com/jokosaputro95/cms-go/internal/modules/taxonomy/routes"
+	taxonomy_services "github.com/jokosaputro95/cms-go/internal/modules/taxonomy/services"
+	user_handlers "github.com/jokosaputro95/cms-go/internal/modules/user/handlers"
+	user_repositories "github.com/jokosaputro95/cms-go/internal/modules/user/repositories"
+	user_routes "github.com/jokosaputro95/cms-go/internal/modules/user/routes"
+	user_services "github.com/jokosaputro95/cms-go/internal/modules/user/services"
 	"github.com/jokosaputro95/cms-go/internal/pkg/email"
+	"github.com/jokosaputro95/cms-go/internal/pkg/oidc"
+	"github.com/jokosaputro95/cms-go/internal/pkg/sms"
+	"github.com/jokosaputro95/cms-go/internal/pkg/storage"
+	"github.com/jokosaputro95/cms-go/internal/pkg/webauthn"
 )
 
 // App adalah struktur utama yang menampung server dan dependensi
@@ -26,7 +59,10 @@ type App struct {
 	AuthRoutes  *auth_routes.AuthRoutes
 	AuthMiddleware func(http.Handler) http.Handler
 	ProfileHandler *profile_handlers.ProfileHandler
-	
+	RoleService *role_services.RoleService
+	ArticleScheduler *article_services.ArticleScheduler
+	TokenPurger *auth_services.RevokedTokenPurger
+	RevocationCache *auth_services.RevocationCache
 }
 
 // StartServer adalah fungsi entry point untuk inisialisasi aplikasi
@@ -46,29 +82,158 @@ func StartServer(isProd bool, envFile string) (*App, error) {
 	}
 	
 	// 3. Inisialisasi container dengan semua dependensi
-	jwtService := auth_services.NewJWTService(cfg)
+	jwtService, err := auth_services.NewJWTService(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize JWT service: %w", err)
+	}
 	authRepo := auth_repositories.NewAuthRepository(db.DB)
 	emailSvc := email.NewEmailService(cfg)
-	authService := auth_services.NewAuthService(authRepo, jwtService, emailSvc)
+	// Cache pencabutan token agar AuthMiddleware tidak query ke database di setiap permintaan
+	revocationCache := auth_services.NewRevocationCache(
+		authRepo,
+		cfg.Database.DSN(),
+		cfg.JWT.JWTRevocationSyncInterval,
+		cfg.JWT.JWTRefreshExpiresIn,
+		cfg.Database.QueryTimeout,
+	)
+	oauthProviders, err := oidc.NewRegistry(oauthProviderConfigs(cfg), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure OAuth providers: %w", err)
+	}
+	relyingParty, err := webauthn.NewRelyingParty(cfg.WebAuthn.WebAuthnRPID, cfg.WebAuthn.WebAuthnRPName, cfg.WebAuthn.WebAuthnOrigins)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure WebAuthn: %w", err)
+	}
+	authService := auth_services.NewAuthService(authRepo, jwtService, emailSvc, cfg.Server.AppName, revocationCache, oauthProviders, cfg.OIDC, relyingParty)
 	authHandler := auth_hendlers.NewAuthHandler(authService)
+	tokenPurger := auth_services.NewRevokedTokenPurger(
+		authRepo,
+		cfg.JWT.JWTRevokedPurgeInterval,
+		cfg.Scheduler.SchedulerBatchSize,
+		cfg.Database.QueryTimeout,
+	)
+
+	// Inisialisasi data wilayah administrasi, dipakai oleh lookup publik dan validasi profil
+	regionRepo, err := loadRegionRepository(cfg.Region.RegionDataFile, cfg.Region.RegionAllowSampleData, isProd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load region dataset: %w", err)
+	}
+	regionService := region_services.NewRegionService(regionRepo)
+	regionHandler := region_handlers.NewRegionHandler(regionService)
 
 	// Inisialisasi service dan repository untuk profile
 	profileRepo := profile_repositories.NewProfileRepository(db.DB)
-	profileService := profile_services.NewProfileService(profileRepo)
+	smsSender, err := sms.NewSMSSender(cfg, isProd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize SMS sender: %w", err)
+	}
+	profileService := profile_services.NewProfileService(profileRepo, regionService, smsSender)
 	profileHandler := profile_handlers.NewProfileHandler(profileService)
 	
+	// Inisialisasi service dan repository untuk role
+	roleRepo := role_repositories.NewRoleRepository(db.DB)
+	roleService := role_services.NewRoleService(roleRepo)
+	roleHandler := role_handlers.NewRoleHandler(roleService)
+
+	// Inisialisasi service dan repository untuk manajemen pengguna oleh admin
+	userRepo := user_repositories.NewUserRepository(db.DB)
+	userService := user_services.NewUserService(userRepo, profileRepo, roleService, authService)
+	userHandler := user_handlers.NewUserHandler(userService)
+
+	// Inisialisasi service dan repository untuk artikel
+	articleRepo := article_repositories.NewArticleRepository(db.DB)
+	articleService := article_services.NewArticleService(articleRepo, article_services.SystemClock{})
+	articleHandler := article_handlers.NewArticleHandler(articleService)
+	articleScheduler := article_services.NewArticleScheduler(
+		articleRepo,
+		article_services.SystemClock{},
+		cfg.Scheduler.SchedulerInterval,
+		cfg.Scheduler.SchedulerBatchSize,
+		cfg.Database.QueryTimeout,
+	)
+
+	// Inisialisasi service dan repository untuk kategori dan tag
+	taxonomyRepo := taxonomy_repositories.NewTaxonomyRepository(db.DB)
+	taxonomyService := taxonomy_services.NewTaxonomyService(taxonomyRepo)
+	taxonomyHandler := taxonomy_handlers.NewTaxonomyHandler(taxonomyService)
+
+	// Inisialisasi storage, service dan repository untuk media
+	mediaStorage, err := storage.NewLocalStorage(cfg.Media.MediaStorageDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize media storage: %w", err)
+	}
+	mediaRepo := media_repositories.NewMediaRepository(db.DB)
+	mediaService := media_services.NewMediaService(mediaRepo, mediaStorage, cfg.Media.MediaMaxUploadSize, cfg.Media.MediaMaxImagePixels, cfg.Media.MediaPublicBaseURL)
+	mediaHandler := media_handlers.NewMediaHandler(mediaService, cfg.Media.MediaMaxUploadSize)
+
+	// Pastikan role dan permission bawaan tersedia
+	seedCtx, cancelSeed := context.WithTimeout(context.Background(), cfg.Database.QueryTimeout)
+	if err := roleService.SeedDefaults(seedCtx); err != nil {
+		log.Printf("Warning: gagal melakukan seeding role bawaan: %v", err)
+	}
+	cancelSeed()
+
 	// Inisialisasi rute dan middleware
 	authRoutes := auth_routes.NewAuthRoutes(authHandler)
 	authMiddleware := middleware.AuthMiddleware(jwtService, authService)
+	roleRoutes := role_routes.NewRoleRoutes(roleHandler)
+	userRoutes := user_routes.NewUserRoutes(userHandler)
+	articleRoutes := article_routes.NewArticleRoutes(articleHandler)
+	taxonomyRoutes := taxonomy_routes.NewTaxonomyRoutes(taxonomyHandler)
+	mediaRoutes := media_routes.NewMediaRoutes(mediaHandler)
+	regionRoutes := region_routes.NewRegionRoutes(regionHandler)
+
+	// Rute admin role membutuhkan permission roles.manage dengan klaim yang masih segar
+	roleAdminMiddleware := middleware.Chain(
+		authMiddleware,
+		middleware.RequireFreshAuthz(authService),
+		middleware.RequirePermission(role_models.PermRolesManage),
+	)
+
+	// Rute admin pengguna membutuhkan permission users.manage dengan klaim yang masih segar
+	userAdminMiddleware := middleware.Chain(
+		authMiddleware,
+		middleware.RequireFreshAuthz(authService),
+		middleware.RequirePermission(role_models.PermUsersManage),
+	)
+
+	// Pengelolaan kategori dan tag membutuhkan permission taxonomy.manage
+	taxonomyAdminMiddleware := middleware.Chain(
+		authMiddleware,
+		middleware.RequirePermission(role_models.PermTaxonomyManage),
+	)
+
+	// Pengelolaan akun (MFA, sesi, API token) hanya boleh lewat sesi login, bukan API token
+	sessionAuthMiddleware := middleware.Chain(
+		authMiddleware,
+		middleware.RequireSessionAuth(),
+	)
+
+	// Pendaftaran aplikasi yang login dengan akun CMS membutuhkan permission oauth_clients.manage
+	oauthClientAdminMiddleware := middleware.Chain(
+		authMiddleware,
+		middleware.RequireFreshAuthz(authService),
+		middleware.RequirePermission(role_models.PermOAuthClientsManage),
+	)
 
 	// 4. Daftarkan rute ke router
 	router := http.NewServeMux()
-	authRoutes.RegisterRoutes(router)
+	authRoutes.RegisterRoutes(router, sessionAuthMiddleware)
+	authRoutes.RegisterOIDCProviderRoutes(router, sessionAuthMiddleware, oauthClientAdminMiddleware)
+	roleRoutes.RegisterRoutes(router, roleAdminMiddleware)
+	userRoutes.RegisterRoutes(router, userAdminMiddleware)
+	articleRoutes.RegisterRoutes(router, authMiddleware)
+	taxonomyRoutes.RegisterRoutes(router, taxonomyAdminMiddleware)
+	mediaRoutes.RegisterRoutes(router, authMiddleware)
+	regionRoutes.RegisterRoutes(router)
 
 	// Contoh pendaftaran rute yang dilindungi
 	protectedRouter := http.NewServeMux()
-	protectedRouter.HandleFunc("/profile", profileHandler.GetProfile)
+	protectedRouter.HandleFunc("/profile", profileHandler.Profile)
+	protectedRouter.HandleFunc("/profile/phone/verification", profileHandler.RequestPhoneVerification)
+	protectedRouter.HandleFunc("/profile/phone/verification/confirm", profileHandler.ConfirmPhoneVerification)
 	router.Handle("/profile", authMiddleware(protectedRouter))
+	router.Handle("/profile/", authMiddleware(protectedRouter))
 
 	// 5. Buat instance server
 	server := &http.Server{
@@ -79,15 +244,75 @@ func StartServer(isProd bool, envFile string) (*App, error) {
         IdleTimeout:  cfg.Server.ServerIdleTimeout,
 	}
 
+	// Jalankan scheduler penerbitan artikel di background
+	articleScheduler.Start()
+	tokenPurger.Start()
+	revocationCache.Start()
+
 	return &App{
 		Config: cfg,
 		Server: server,
 		DB: db,
 		AuthRoutes: authRoutes,
 		AuthMiddleware: authMiddleware,
+		ProfileHandler: profileHandler,
+		RoleService: roleService,
+		ArticleScheduler: articleScheduler,
+		TokenPurger: tokenPurger,
+		RevocationCache: revocationCache,
 	}, nil
 }
 
+// oauthProviderConfigs mengubah konfigurasi penyedia OAuth menjadi konfigurasi oidc dengan
+// redirect URI callback masing-masing penyedia
+func oauthProviderConfigs(cfg *config.Config) []oidc.Config {
+	baseURL := strings.TrimSuffix(cfg.OAuth.OAuthRedirectBaseURL, "/")
+	configs := make([]oidc.Config, 0, len(cfg.OAuth.OAuthProviders))
+	for _, provider := range cfg.OAuth.OAuthProviders {
+		configs = append(configs, oidc.Config{
+			Name:         provider.Name,
+			Type:         provider.Type,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			Issuer:       provider.Issuer,
+			AuthURL:      provider.AuthURL,
+			TokenURL:     provider.TokenURL,
+			UserInfoURL:  provider.UserInfoURL,
+			Scopes:       provider.Scopes,
+			RedirectURL:  baseURL + "/auth/oauth/" + provider.Name + "/callback",
+		})
+	}
+	return configs
+}
+
+// loadRegionRepository memuat dataset wilayah dari path. Dataset di path wajib lengkap.
+// Jika path kosong, dataset contoh bawaan dipakai dengan peringatan di luar production;
+// di production dataset contoh hanya dipakai jika allowSample diaktifkan secara eksplisit.
+func loadRegionRepository(path string, allowSample, isProd bool) (*region_repositories.RegionRepository, error) {
+	if path == "" {
+		if isProd && !allowSample {
+			return nil, fmt.Errorf("REGION_DATA_FILE is required in production: the embedded region dataset is only a sample, set REGION_ALLOW_SAMPLE_DATA=true to use it anyway")
+		}
+		log.Println("WARNING: REGION_DATA_FILE is not set, using the embedded sample region dataset; addresses outside the sample will be rejected")
+		return region_repositories.NewRegionRepository(bytes.NewReader(region_data.RegionsCSV))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	repo, err := region_repositories.NewRegionRepository(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.CheckComplete(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return repo, nil
+}
+
 // Start memulai server HTTP
 func (a *App) Start() error {
 	log.Println("Starting server...")
@@ -107,6 +332,32 @@ func (a *App) Start() error {
 // Shutdown menutup server secara bertahap dan melepaskan sumber daya
 func (a *App) Shutdown(ctx context.Context) error {
 	log.Println("Shutting down server...")
+	// Hentikan server lebih dulu agar permintaan yang sedang berjalan selesai sebelum worker
+	// dan koneksi database ditutup
+	serverErr := a.Server.Shutdown(ctx)
+	if serverErr != nil {
+		log.Printf("Error shutting down HTTP server: %v", serverErr)
+	} else {
+		log.Println("✅ HTTP server stopped")
+	}
+
+	// Hentikan scheduler sebelum koneksi database ditutup
+	if err := a.ArticleScheduler.Stop(ctx); err != nil {
+		log.Printf("Error stopping article scheduler: %v", err)
+	} else {
+		log.Println("✅ Article scheduler stopped")
+	}
+	if err := a.TokenPurger.Stop(ctx); err != nil {
+		log.Printf("Error stopping revoked token purger: %v", err)
+	} else {
+		log.Println("✅ Revoked token purger stopped")
+	}
+	if err := a.RevocationCache.Stop(ctx); err != nil {
+		log.Printf("Error stopping revocation cache: %v", err)
+	} else {
+		log.Println("✅ Revocation cache stopped")
+	}
+
 	// Tutup koneksi database
 	if err := a.DB.Close(); err != nil {
 		log.Printf("Error closing database: %v", err)
@@ -114,5 +365,5 @@ func (a *App) Shutdown(ctx context.Context) error {
 		log.Println("✅ Database connection closed")
 	}
 
-	return a.Server.Shutdown(ctx)
+	return serverErr
 }
\ No newline at end of file
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/jokosaputro95/cms-go/config"
+	auth_dto "github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	auth_repositories "github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
+	auth_services "github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	role_models "github.com/jokosaputro95/cms-go/internal/modules/role/models"
+	role_repositories "github.com/jokosaputro95/cms-go/internal/modules/role/repositories"
+	role_services "github.com/jokosaputro95/cms-go/internal/modules/role/services"
+	"github.com/jokosaputro95/cms-go/internal/pkg/email"
+	"github.com/jokosaputro95/cms-go/internal/pkg/migrate"
+	"github.com/jokosaputro95/cms-go/migrations"
+)
+
+// openDatabase memuat konfigurasi dan membuka koneksi database untuk perintah CLI
+func openDatabase(isProd bool, envFile string) (*config.Config, *config.Database, error) {
+	cfg, err := config.LoadConfig(isProd, envFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := config.SetUpDatabase(cfg.Database)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return cfg, db, nil
+}
+
+// RunMigrate menjalankan perintah migrate up|down [N]|status|to N|force N
+func RunMigrate(ctx context.Context, isProd bool, envFile string, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up|down [N]|status|to N|force N")
+	}
+
+	_, db, err := openDatabase(isProd, envFile)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db.DB, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("jumlah langkah tidak valid: %s", args[1])
+			}
+		}
+		err = migrator.Down(ctx, steps)
+	case "to", "force":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: migrate %s N", args[0])
+		}
+		version, parseErr := strconv.ParseUint(args[1], 10, 64)
+		if parseErr != nil {
+			return fmt.Errorf("versi tidak valid: %s", args[1])
+		}
+		if args[0] == "to" {
+			err = migrator.To(ctx, uint(version))
+		} else {
+			err = migrator.Force(ctx, uint(version))
+		}
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	default:
+		return fmt.Errorf("perintah migrate tidak dikenal: %s", args[0])
+	}
+
+	if errors.Is(err, migrate.ErrNoChange) {
+		log.Println("Tidak ada migrasi yang perlu dijalankan")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return printMigrationStatus(ctx, migrator)
+}
+
+func printMigrationStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range status.Migrations {
+		state := "pending"
+		if migration.Applied {
+			state = "applied"
+		}
+		if status.Dirty && migration.Version == status.Version {
+			state = "DIRTY"
+		}
+		fmt.Printf("%06d  %-8s %s\n", migration.Version, state, migration.Name)
+	}
+
+	dirty := ""
+	if status.Dirty {
+		dirty = " (dirty)"
+	}
+	fmt.Printf("Versi database saat ini: %d%s\n", status.Version, dirty)
+	return nil
+}
+
+// RunSeed membuat role dan permission bawaan
+func RunSeed(ctx context.Context, isProd bool, envFile string) error {
+	_, db, err := openDatabase(isProd, envFile)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	roleService := role_services.NewRoleService(role_repositories.NewRoleRepository(db.DB))
+	if err := roleService.SeedDefaults(ctx); err != nil {
+		return fmt.Errorf("gagal melakukan seeding role bawaan: %w", err)
+	}
+	log.Println("✅ Role dan permission bawaan tersedia")
+	return nil
+}
+
+// RunCreateAdmin membuat pengguna aktif dengan role admin. Password dapat diberikan lewat
+// flag -password atau variabel lingkungan ADMIN_PASSWORD agar tidak tersimpan di riwayat shell.
+func RunCreateAdmin(ctx context.Context, isProd bool, envFile string, args []string) error {
+	fs := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	username := fs.String("username", "", "username admin")
+	emailAddr := fs.String("email", "", "email admin")
+	password := fs.String("password", os.Getenv("ADMIN_PASSWORD"), "password admin (default dari ADMIN_PASSWORD)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, db, err := openDatabase(isProd, envFile)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	roleService := role_services.NewRoleService(role_repositories.NewRoleRepository(db.DB))
+	if err := roleService.SeedDefaults(ctx); err != nil {
+		return fmt.Errorf("gagal melakukan seeding role bawaan: %w", err)
+	}
+
+	jwtService, err := auth_services.NewJWTService(cfg)
+	if err != nil {
+		return err
+	}
+	authService := auth_services.NewAuthService(
+		auth_repositories.NewAuthRepository(db.DB),
+		jwtService,
+		email.NewEmailService(cfg),
+		cfg.Server.AppName,
+		nil,
+		nil,
+		cfg.OIDC,
+		nil,
+	)
+	user, err := authService.CreateActiveUser(ctx, &auth_dto.RegisterRequestDTO{
+		Username: *username,
+		Email:    *emailAddr,
+		Password: *password,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := roleService.AssignRoleByName(ctx, user.ID, role_models.RoleAdmin); err != nil {
+		return fmt.Errorf("pengguna %s dibuat tetapi gagal diberi role admin: %w", user.ID, err)
+	}
+
+	log.Printf("✅ Admin %s (%s) dibuat dengan ID %s", user.Username, user.Email, user.ID)
+	return nil
+}
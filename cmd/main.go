@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -11,8 +13,65 @@ import (
 	"github.com/jokosaputro95/cms-go/cmd/app"
 )
 
+const usage = `Usage: %s [flags] <command> [args]
+
+Commands:
+  serve                       menjalankan HTTP server (default)
+  migrate up                  menjalankan semua migrasi yang belum terpasang
+  migrate down [N]            membatalkan N migrasi terakhir (default 1)
+  migrate to N                memindahkan database ke versi N
+  migrate force N             menandai database pada versi N tanpa menjalankan SQL
+  migrate status              menampilkan status migrasi
+  seed                        membuat role dan permission bawaan
+  create-admin -username U -email E [-password P]
+                              membuat pengguna admin aktif
+
+Flags:
+`
+
 func main() {
-	container, err := app.StartServer(false, ".env")
+	envFile := flag.String("env", ".env", "path file konfigurasi .env")
+	isProd := flag.Bool("prod", false, "gunakan konfigurasi database produksi")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), usage, os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	command := "serve"
+	args := flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	// Perintah CLI dapat dibatalkan dengan Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch command {
+	case "serve":
+		stop()
+		serve(*isProd, *envFile)
+		return
+	case "migrate":
+		err = app.RunMigrate(ctx, *isProd, *envFile, args)
+	case "seed":
+		err = app.RunSeed(ctx, *isProd, *envFile)
+	case "create-admin":
+		err = app.RunCreateAdmin(ctx, *isProd, *envFile, args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("Error running %s: %v", command, err)
+	}
+}
+
+func serve(isProd bool, envFile string) {
+	container, err := app.StartServer(isProd, envFile)
 	if err != nil {
 		log.Fatalf("Error starting server: %v", err)
 	}
@@ -22,24 +81,22 @@ func main() {
 
 	// Start server in a goroutine
 	go func() {
-        if err := container.Start(); err != nil {
-            log.Printf("Server error: %v", err)
-        }
-    }()
+		if err := container.Start(); err != nil {
+			log.Printf("Server error: %v", err)
+		}
+	}()
 
 	// Wait for interrupt signal
 	<-quit
 	log.Println("Received interrupt signal, shutting down...")
-	
 
 	// Create a context with timeout for graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-
 	if err := container.Shutdown(ctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
 	log.Println("Server exited gracefully")
-}
\ No newline at end of file
+}
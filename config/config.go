@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -48,6 +49,21 @@ type JWTConfig struct {
 	JWTExpiresIn time.Duration
 	JWTRefreshExpiresIn time.Duration
 	JWTIssuer string
+	// Interval pembersihan baris revoked_tokens yang tokennya sudah kedaluwarsa
+	JWTRevokedPurgeInterval time.Duration
+	// Interval sinkronisasi ulang cache pencabutan token dari database
+	JWTRevocationSyncInterval time.Duration
+	// File PEM private key RSA/Ed25519 untuk menandatangani access token. Kosong berarti
+	// access token tetap ditandatangani HS256 dengan JWTSecret.
+	JWTSigningKeyFile string
+	// kid kunci penandatanganan; kosong berarti thumbprint RFC 7638
+	JWTSigningKeyID string
+	// Kunci tambahan yang diterima dan diterbitkan di JWKS selama rotasi (kunci baru sebelum
+	// dipakai, kunci lama hingga access token terakhirnya kedaluwarsa). Dipisah koma dengan
+	// format path atau kid=path.
+	JWTVerificationKeyFiles string
+	// Tetap menerima access token HS256 setelah beralih ke kunci asimetris (masa transisi)
+	JWTAcceptHS256 bool
 }
 
 type EmailConfig struct {
@@ -57,11 +73,85 @@ type EmailConfig struct {
 	EmailSMTPPassword string
 }
 
+type SchedulerConfig struct {
+	SchedulerInterval time.Duration
+	SchedulerBatchSize int
+}
+
+type MediaConfig struct {
+	MediaStorageDir string
+	MediaMaxUploadSize int64
+	// MediaMaxImagePixels membatasi lebar x tinggi gambar yang di-decode untuk rendition
+	MediaMaxImagePixels int64
+	MediaPublicBaseURL string
+}
+
+type RegionConfig struct {
+	RegionDataFile string
+	RegionAllowSampleData bool
+}
+
+type SMSConfig struct {
+	SMSDriver string
+	SMSFilePath string
+}
+
+// OAuthProviderConfig adalah konfigurasi satu penyedia login OAuth/OIDC, dibaca dari
+// variabel OAUTH_<NAMA>_* untuk setiap nama di OAUTH_PROVIDERS
+type OAuthProviderConfig struct {
+	Name string
+	// oidc atau github; kosong berarti github untuk penyedia bernama github, selain itu oidc
+	Type string
+	ClientID string
+	ClientSecret string
+	// Issuer OIDC; kosong untuk google berarti https://accounts.google.com
+	Issuer string
+	// Override endpoint, terutama untuk GitHub Enterprise atau penyedia tanpa discovery
+	AuthURL string
+	TokenURL string
+	UserInfoURL string
+	Scopes []string
+}
+
+type OAuthConfig struct {
+	// URL publik aplikasi; redirect URI penyedia adalah <base>/auth/oauth/<nama>/callback
+	OAuthRedirectBaseURL string
+	OAuthProviders []OAuthProviderConfig
+}
+
+// OIDCProviderConfig mengatur CMS sebagai penyedia OpenID Connect untuk aplikasi lain
+type OIDCProviderConfig struct {
+	// URL publik CMS tanpa path, dipakai sebagai klaim iss dan dasar URL endpoint di dokumen
+	// discovery. Kosong berarti fitur penyedia OIDC dinonaktifkan.
+	OIDCIssuer string
+	// Halaman consent di frontend yang dibuka browser dengan parameter otorisasi lalu
+	// memanggil /oauth2/authorize; kosong berarti <issuer>/oauth2/authorize
+	OIDCConsentURL string
+}
+
+// WebAuthnConfig mengatur relying party untuk login dengan passkey atau security key
+type WebAuthnConfig struct {
+	// Domain yang menjadi cakupan kredensial, misalnya example.com untuk frontend di
+	// app.example.com. Kredensial tidak dapat dipakai lagi jika RP ID diganti.
+	WebAuthnRPID string
+	// Nama layanan yang ditampilkan oleh authenticator
+	WebAuthnRPName string
+	// Origin frontend yang menjalankan ceremony WebAuthn
+	WebAuthnOrigins []string
+}
+
 type Config struct {
 	Server ServerConfig
 	Database DatabaseConfig
 	JWT JWTConfig
 	Email EmailConfig
+	Scheduler SchedulerConfig
+	Media MediaConfig
+	Region RegionConfig
+	SMS SMSConfig
+	OAuth OAuthConfig
+	OIDC OIDCProviderConfig
+	WebAuthn WebAuthnConfig
 }
 
 var (
@@ -127,6 +217,12 @@ func LoadConfig(isProd bool, envPath string) (*Config, error) {
 				JWTExpiresIn: GetEnvAsDuration("JWT_ACCESS_TOKEN_TTL", "15m"),
 				JWTRefreshExpiresIn: GetEnvAsDuration("JWT_REFRESH_TOKEN_TTL", "168h"),
 				JWTIssuer: GetEnv("JWT_ISSUER", "cms-go"),
+				JWTRevokedPurgeInterval: GetEnvAsDuration("JWT_REVOKED_PURGE_INTERVAL", "1h"),
+				JWTRevocationSyncInterval: GetEnvAsDuration("JWT_REVOCATION_SYNC_INTERVAL", "30s"),
+				JWTSigningKeyFile: GetEnv("JWT_SIGNING_KEY_FILE", ""),
+				JWTSigningKeyID: GetEnv("JWT_SIGNING_KEY_ID", ""),
+				JWTVerificationKeyFiles: GetEnv("JWT_VERIFICATION_KEY_FILES", ""),
+				JWTAcceptHS256: GetEnvAsBool("JWT_ACCEPT_HS256", false),
 			},
 			Email: EmailConfig{
 				EmailSMTPHost: GetEnv("EMAIL_SMTP_HOST", "smtp.gmail.com"),
@@ -134,12 +230,74 @@ func LoadConfig(isProd bool, envPath string) (*Config, error) {
 				EmailSMTPUsername: GetEnv("EMAIL_SMTP_USERNAME", ""),
 				EmailSMTPPassword: GetEnv("EMAIL_SMTP_PASSWORD", ""),
 			},
+			Scheduler: SchedulerConfig{
+				SchedulerInterval: GetEnvAsDuration("SCHEDULER_INTERVAL", "30s"),
+				SchedulerBatchSize: GetEnvAsInt("SCHEDULER_BATCH_SIZE", 50),
+			},
+			Media: MediaConfig{
+				MediaStorageDir: GetEnv("MEDIA_STORAGE_DIR", "./storage/media"),
+				MediaMaxUploadSize: int64(GetEnvAsInt("MEDIA_MAX_UPLOAD_MB", 10)) << 20,
+				MediaMaxImagePixels: int64(GetEnvAsInt("MEDIA_MAX_IMAGE_MEGAPIXELS", 40)) * 1_000_000,
+				MediaPublicBaseURL: GetEnv("MEDIA_PUBLIC_BASE_URL", "/media/files"),
+			},
+			Region: RegionConfig{
+				// Path ke dataset wilayah Kemendagri lengkap; wajib di production, di luar production
+				// dataset contoh bawaan dipakai jika kosong
+				RegionDataFile: GetEnv("REGION_DATA_FILE", ""),
+				// Mengizinkan dataset contoh bawaan dipakai di production saat REGION_DATA_FILE kosong
+				RegionAllowSampleData: GetEnvAsBool("REGION_ALLOW_SAMPLE_DATA", false),
+			},
+			SMS: SMSConfig{
+				// log, file atau disabled; log dan file hanya untuk pengembangan dan ditolak di
+				// production. Penyedia SMS lain cukup mengimplementasikan sms.SMSSender
+				SMSDriver: GetEnv("SMS_DRIVER", ""),
+				SMSFilePath: GetEnv("SMS_FILE_PATH", "./storage/sms.log"),
+			},
+			OAuth: OAuthConfig{
+				OAuthRedirectBaseURL: GetEnv("OAUTH_REDIRECT_BASE_URL", "http://localhost:8080"),
+				// Daftar nama penyedia dipisah koma, misalnya google,github,keycloak
+				OAuthProviders: loadOAuthProviders(os.Getenv("OAUTH_PROVIDERS")),
+			},
+			OIDC: OIDCProviderConfig{
+				OIDCIssuer: GetEnv("OIDC_ISSUER", ""),
+				OIDCConsentURL: GetEnv("OIDC_CONSENT_URL", ""),
+			},
+			WebAuthn: WebAuthnConfig{
+				WebAuthnRPID: GetEnv("WEBAUTHN_RP_ID", "localhost"),
+				WebAuthnRPName: GetEnv("WEBAUTHN_RP_NAME", GetEnv("APP_NAME", "CMS GO")),
+				// Daftar origin dipisah koma, misalnya https://app.example.com,https://admin.example.com
+				WebAuthnOrigins: strings.Split(GetEnv("WEBAUTHN_ORIGINS", "http://localhost:3000,http://localhost:8080"), ","),
+			},
 		}
 	})
 	
 	return cfg, nil
 }
 
+// loadOAuthProviders membaca konfigurasi penyedia OAuth untuk setiap nama di daftar
+func loadOAuthProviders(names string) []OAuthProviderConfig {
+	var providers []OAuthProviderConfig
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OAUTH_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		providers = append(providers, OAuthProviderConfig{
+			Name: name,
+			Type: os.Getenv(prefix + "TYPE"),
+			ClientID: GetEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: GetEnv(prefix+"CLIENT_SECRET", ""),
+			Issuer: os.Getenv(prefix + "ISSUER"),
+			AuthURL: os.Getenv(prefix + "AUTH_URL"),
+			TokenURL: os.Getenv(prefix + "TOKEN_URL"),
+			UserInfoURL: os.Getenv(prefix + "USERINFO_URL"),
+			Scopes: strings.Fields(strings.ReplaceAll(os.Getenv(prefix+"SCOPES"), ",", " ")),
+		})
+	}
+	return providers
+}
+
 // Herlper functions
 func GetEnv(key string, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -162,6 +320,17 @@ func GetEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func GetEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		} else {
+			log.Printf("Warning: invalid bool for %s: %v, using default %t", key, err, defaultValue)
+		}
+	}
+	return defaultValue
+}
+
 func GetEnvAsDuration(key string, defaultValue string) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if durationValue, err := time.ParseDuration(value); err == nil {
@@ -13,9 +13,10 @@ type Database struct {
 	DB *sql.DB
 }
 
-func SetUpDatabase(cfg DatabaseConfig) (*Database, error) {
-	// Build connection string
-	dsn := fmt.Sprintf(
+// DSN membangun connection string PostgreSQL dari konfigurasi database. Dipakai juga oleh
+// koneksi LISTEN/NOTIFY yang tidak melewati pool database/sql.
+func (cfg DatabaseConfig) DSN() string {
+	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.DBHost,
 		cfg.DBPort,
@@ -24,9 +25,11 @@ func SetUpDatabase(cfg DatabaseConfig) (*Database, error) {
 		cfg.DBName,
 		cfg.DBSSLMode,
 	)
+}
 
+func SetUpDatabase(cfg DatabaseConfig) (*Database, error) {
 	// Open Connection
-	db, err := sql.Open("postgres", dsn)
+	db, err := sql.Open("postgres", cfg.DSN())
 	if err != nil {
 		return nil, fmt.Errorf("error opening database connection: %w", err)
 	}
@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+// CreateArticleRequestDTO digunakan untuk membuat draft artikel baru
+type CreateArticleRequestDTO struct {
+	Title         string  `json:"title" validate:"required,min=3,max=255"`
+	Slug          string  `json:"slug" validate:"omitempty,max=255"`
+	Excerpt       *string `json:"excerpt" validate:"omitempty,max=1000"`
+	Body          string  `json:"body" validate:"max=200000"`
+	CoverImageURL *string `json:"cover_image_url" validate:"omitempty,url,max=500"`
+	ChangeNote    *string `json:"change_note" validate:"omitempty,max=500"`
+}
+
+// UpdateArticleRequestDTO digunakan untuk mengubah sebagian isi artikel.
+// Field yang bernilai nil tidak diubah. UpdatedAt wajib berisi updated_at artikel yang
+// terakhir dibaca agar perubahan editor lain tidak tertimpa.
+type UpdateArticleRequestDTO struct {
+	Title         *string    `json:"title" validate:"omitempty,min=3,max=255"`
+	Slug          *string    `json:"slug" validate:"omitempty,max=255"`
+	Excerpt       *string    `json:"excerpt" validate:"omitempty,max=1000"`
+	Body          *string    `json:"body" validate:"omitempty,max=200000"`
+	CoverImageURL *string    `json:"cover_image_url" validate:"omitempty,url,max=500"`
+	ChangeNote    *string    `json:"change_note" validate:"omitempty,max=500"`
+	UpdatedAt     *time.Time `json:"updated_at" validate:"required"`
+}
+
+// RestoreRevisionRequestDTO digunakan untuk mengembalikan artikel ke revisi sebelumnya
+type RestoreRevisionRequestDTO struct {
+	ChangeNote *string `json:"change_note" validate:"omitempty,max=500"`
+}
+
+// TransitionRequestDTO digunakan untuk memindahkan artikel ke status berikutnya
+type TransitionRequestDTO struct {
+	Action    string     `json:"action" validate:"required,oneof=submit reject schedule unschedule publish archive restore"`
+	PublishAt *time.Time `json:"publish_at"`
+	// UnpublishAt adalah batas embargo opsional untuk aksi schedule dan publish.
+	// Setelah waktu ini tercapai artikel diarsipkan otomatis.
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	Reason      *string    `json:"reason" validate:"omitempty,max=500"`
+}
+
+// SetArticleTaxonomyRequestDTO digunakan untuk mengatur kategori dan tag artikel.
+// Tag yang belum ada akan dibuat otomatis berdasarkan namanya.
+type SetArticleTaxonomyRequestDTO struct {
+	CategoryID *string  `json:"category_id"`
+	Tags       []string `json:"tags" validate:"max=20,dive,required,max=100"`
+}
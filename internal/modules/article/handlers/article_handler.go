@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/article/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/article/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/article/services"
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ArticleHandler menangani permintaan HTTP untuk artikel
+type ArticleHandler struct {
+	articleService services.ArticleServiceInterface
+}
+
+// NewArticleHandler membuat instance baru dari ArticleHandler
+func NewArticleHandler(articleService services.ArticleServiceInterface) *ArticleHandler {
+	return &ArticleHandler{articleService: articleService}
+}
+
+// Articles menangani daftar artikel (GET) dan pembuatan artikel baru (POST)
+func (h *ArticleHandler) Articles(w http.ResponseWriter, r *http.Request) {
+	actor := actorFromRequest(r)
+
+	switch r.Method {
+	case http.MethodGet:
+		page, perPage := api.ParsePagination(r)
+		filter := models.ArticleFilter{
+			Status:       r.URL.Query().Get("status"),
+			AuthorID:     r.URL.Query().Get("author_id"),
+			Search:       r.URL.Query().Get("q"),
+			CategoryPath: r.URL.Query().Get("category"),
+			TagSlug:      r.URL.Query().Get("tag"),
+			Page:         page,
+			PerPage:      perPage,
+		}
+
+		articles, total, err := h.articleService.ListArticles(r.Context(), actor, filter)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to list articles")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Articles fetched successfully", articles, api.NewPaginationMeta(page, perPage, total))
+
+	case http.MethodPost:
+		var req dto.CreateArticleRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		article, err := h.articleService.CreateArticle(r.Context(), actor, &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to create article")
+			return
+		}
+		api.SendSuccess(w, http.StatusCreated, "Article created successfully", article, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// Article menangani detail (GET), perubahan (PATCH) dan penghapusan (DELETE) artikel
+func (h *ArticleHandler) Article(w http.ResponseWriter, r *http.Request) {
+	actor := actorFromRequest(r)
+	articleID := r.PathValue("articleID")
+
+	switch r.Method {
+	case http.MethodGet:
+		article, err := h.articleService.GetArticle(r.Context(), actor, articleID)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to get article")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Article fetched successfully", article, nil)
+
+	case http.MethodPatch:
+		var req dto.UpdateArticleRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		article, err := h.articleService.UpdateArticle(r.Context(), actor, articleID, &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to update article")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Article updated successfully", article, nil)
+
+	case http.MethodDelete:
+		if err := h.articleService.DeleteArticle(r.Context(), actor, articleID); err != nil {
+			h.sendServiceError(w, err, "Failed to delete article")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Article deleted successfully", nil, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// Transition menangani riwayat status artikel (GET) dan perpindahan status dalam alur editorial (POST)
+func (h *ArticleHandler) Transition(w http.ResponseWriter, r *http.Request) {
+	actor := actorFromRequest(r)
+	articleID := r.PathValue("articleID")
+
+	switch r.Method {
+	case http.MethodGet:
+		entries, err := h.articleService.ListTransitions(r.Context(), actor, articleID)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to list article transitions")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Article transitions fetched successfully", entries, nil)
+
+	case http.MethodPost:
+		var req dto.TransitionRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		article, err := h.articleService.TransitionArticle(r.Context(), actor, articleID, &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to change article status")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Article status changed successfully", article, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// PublishedArticle menangani pembacaan artikel yang sudah terbit oleh publik
+func (h *ArticleHandler) PublishedArticle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	article, err := h.articleService.GetPublishedArticle(r.Context(), r.PathValue("slug"))
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to get article")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Article fetched successfully", article, nil)
+}
+
+// PublishedArticles menangani daftar artikel terbit untuk publik. Query parameter category
+// berisi path kategori (contoh news/national) dan tag berisi slug tag.
+func (h *ArticleHandler) PublishedArticles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	page, perPage := api.ParsePagination(r)
+	filter := models.ArticleFilter{
+		Search:       r.URL.Query().Get("q"),
+		CategoryPath: r.URL.Query().Get("category"),
+		TagSlug:      r.URL.Query().Get("tag"),
+		Page:         page,
+		PerPage:      perPage,
+	}
+
+	articles, total, err := h.articleService.ListPublishedArticles(r.Context(), filter)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to list articles")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Articles fetched successfully", articles, api.NewPaginationMeta(page, perPage, total))
+}
+
+// Taxonomy menangani pengaturan kategori dan tag artikel
+func (h *ArticleHandler) Taxonomy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.SetArticleTaxonomyRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	article, err := h.articleService.SetArticleTaxonomy(r.Context(), actorFromRequest(r), r.PathValue("articleID"), &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to update article taxonomy")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Article taxonomy updated successfully", article, nil)
+}
+
+// Revisions menangani daftar revisi artikel
+func (h *ArticleHandler) Revisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	revisions, err := h.articleService.ListRevisions(r.Context(), actorFromRequest(r), r.PathValue("articleID"))
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to list article revisions")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Article revisions fetched successfully", revisions, nil)
+}
+
+// Revision menangani detail satu revisi artikel
+func (h *ArticleHandler) Revision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	revisionNumber, err := strconv.Atoi(r.PathValue("revision"))
+	if err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid revision number")
+		return
+	}
+
+	revision, err := h.articleService.GetRevision(r.Context(), actorFromRequest(r), r.PathValue("articleID"), revisionNumber)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to get article revision")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Article revision fetched successfully", revision, nil)
+}
+
+// DiffRevisions menangani perbandingan dua revisi artikel melalui query parameter from dan to
+func (h *ArticleHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	fromRevision, errFrom := strconv.Atoi(r.URL.Query().Get("from"))
+	toRevision, errTo := strconv.Atoi(r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil {
+		api.SendError(w, http.StatusBadRequest, "Query parameters from and to must be revision numbers")
+		return
+	}
+
+	diff, err := h.articleService.DiffRevisions(r.Context(), actorFromRequest(r), r.PathValue("articleID"), fromRevision, toRevision)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to diff article revisions")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Article revisions compared successfully", diff, nil)
+}
+
+// RestoreRevision menangani pengembalian artikel ke revisi sebelumnya
+func (h *ArticleHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	revisionNumber, err := strconv.Atoi(r.PathValue("revision"))
+	if err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid revision number")
+		return
+	}
+
+	var req dto.RestoreRevisionRequestDTO
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	article, err := h.articleService.RestoreRevision(r.Context(), actorFromRequest(r), r.PathValue("articleID"), revisionNumber, &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to restore article revision")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Article revision restored successfully", article, nil)
+}
+
+// actorFromRequest membangun Actor dari data yang disimpan AuthMiddleware di context
+func actorFromRequest(r *http.Request) services.Actor {
+	userID, _ := r.Context().Value(middleware.UserIDContextKey).(string)
+	return services.Actor{
+		UserID:      userID,
+		Permissions: middleware.PermissionsFromContext(r.Context()),
+	}
+}
+
+// sendServiceError memetakan error dari ArticleService ke respons HTTP
+func (h *ArticleHandler) sendServiceError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.Is(err, services.ErrArticleNotFound), errors.Is(err, services.ErrRevisionNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	case errors.Is(err, services.ErrForbidden):
+		api.SendDetailedError(w, http.StatusForbidden, err.Error(), "forbidden", nil)
+	case errors.Is(err, services.ErrInvalidTransition), errors.Is(err, services.ErrConcurrentUpdate):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "invalid_transition", nil)
+	case errors.Is(err, services.ErrSlugAlreadyUsed):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "slug_exists", nil)
+	case errors.Is(err, services.ErrPublishAtRequired), errors.Is(err, services.ErrInvalidArticleSlug),
+		errors.Is(err, services.ErrInvalidUnpublishAt), errors.Is(err, services.ErrCategoryNotFound),
+		errors.Is(err, services.ErrInvalidTag):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "validation_error", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// Status artikel dalam alur editorial
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusScheduled = "scheduled"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// Article merepresentasikan tabel 'articles' di database
+type Article struct {
+	ID            string       `json:"id"`
+	Title         string       `json:"title"`
+	Slug          string       `json:"slug"`
+	Excerpt       *string      `json:"excerpt"`
+	Body          string       `json:"body"`
+	CoverImageURL *string      `json:"cover_image_url"`
+	AuthorID      string       `json:"author_id"`
+	CategoryID    *string      `json:"category_id"`
+	Tags          []ArticleTag `json:"tags"`
+	Status        string       `json:"status"`
+	PublishAt     *time.Time   `json:"publish_at"`
+	UnpublishAt   *time.Time   `json:"unpublish_at"`
+	SubmittedAt   *time.Time   `json:"submitted_at"`
+	SubmittedBy   *string      `json:"submitted_by"`
+	ReviewedAt    *time.Time   `json:"reviewed_at"`
+	ReviewedBy    *string      `json:"reviewed_by"`
+	PublishedAt   *time.Time   `json:"published_at"`
+	PublishedBy   *string      `json:"published_by"`
+	ArchivedAt    *time.Time   `json:"archived_at"`
+	ArchivedBy    *string      `json:"archived_by"`
+	LastEditedBy  *string      `json:"last_edited_by"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// ArticleTag adalah tag yang ditempelkan pada artikel
+type ArticleTag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// ArticleFilter berisi kriteria pencarian daftar artikel
+type ArticleFilter struct {
+	Status   string
+	AuthorID string
+	Search   string
+	// CategoryPath memfilter artikel di kategori tersebut beserta seluruh turunannya
+	CategoryPath string
+	TagSlug      string
+	// VisibleAt membatasi hasil pada artikel yang terlihat oleh pembaca umum pada waktu tersebut:
+	// sudah terbit atau terjadwal dengan publish_at yang sudah tercapai, dan embargonya belum berakhir
+	VisibleAt *time.Time
+	Page      int
+	PerPage   int
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/pkg/textdiff"
+)
+
+// ArticleRevision merepresentasikan tabel 'article_revisions' di database
+type ArticleRevision struct {
+	ID             string           `json:"id"`
+	ArticleID      string           `json:"article_id"`
+	RevisionNumber int              `json:"revision_number"`
+	AuthorID       string           `json:"author_id"`
+	Snapshot       RevisionSnapshot `json:"snapshot"`
+	ChangeNote     *string          `json:"change_note"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+// RevisionSnapshot adalah salinan konten artikel yang disimpan di kolom snapshot (JSONB)
+type RevisionSnapshot struct {
+	Title         string  `json:"title"`
+	Slug          string  `json:"slug"`
+	Excerpt       *string `json:"excerpt"`
+	Body          string  `json:"body"`
+	CoverImageURL *string `json:"cover_image_url"`
+}
+
+// SnapshotOf membuat RevisionSnapshot dari konten artikel saat ini
+func SnapshotOf(article *Article) RevisionSnapshot {
+	return RevisionSnapshot{
+		Title:         article.Title,
+		Slug:          article.Slug,
+		Excerpt:       article.Excerpt,
+		Body:          article.Body,
+		CoverImageURL: article.CoverImageURL,
+	}
+}
+
+// FieldChange menjelaskan perubahan satu field di antara dua revisi
+type FieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+// RevisionDiff adalah hasil perbandingan dua revisi artikel
+type RevisionDiff struct {
+	ArticleID    string          `json:"article_id"`
+	FromRevision int             `json:"from_revision"`
+	ToRevision   int             `json:"to_revision"`
+	Fields       []FieldChange   `json:"fields"`
+	BodyDiff     []textdiff.Line `json:"body_diff"`
+}
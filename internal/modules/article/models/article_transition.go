@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// Aksi transisi yang dijalankan otomatis oleh scheduler
+const (
+	ActionScheduledPublish = "scheduled_publish"
+	ActionEmbargoExpired   = "embargo_expired"
+)
+
+// ArticleTransition merepresentasikan tabel 'article_transitions' di database.
+// Setiap perpindahan status artikel, manual maupun otomatis, dicatat di sini.
+type ArticleTransition struct {
+	ID         string    `json:"id"`
+	ArticleID  string    `json:"article_id"`
+	Action     string    `json:"action"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    *string   `json:"actor_id"` // nil jika dijalankan oleh scheduler
+	Automatic  bool      `json:"automatic"`
+	Reason     *string   `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
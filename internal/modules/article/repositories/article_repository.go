@@ -0,0 +1,737 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/article/models"
+	"github.com/jokosaputro95/cms-go/internal/pkg/sqlutil"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrDuplicateSlug dikembalikan ketika slug artikel sudah dipakai
+	ErrDuplicateSlug = errors.New("slug artikel sudah digunakan")
+	// ErrUnknownCategory dikembalikan ketika kategori yang dipilih tidak ada
+	ErrUnknownCategory = errors.New("kategori tidak ditemukan")
+)
+
+// articleColumns adalah daftar kolom yang dibaca oleh scanArticle
+const articleColumns = `
+	id, title, slug, excerpt, body, cover_image_url, author_id, category_id, status, publish_at, unpublish_at,
+	submitted_at, submitted_by, reviewed_at, reviewed_by, published_at, published_by,
+	archived_at, archived_by, last_edited_by, created_at, updated_at
+`
+
+// ArticleRepositoryInterface mendefinisikan kontrak untuk interaksi database artikel
+type ArticleRepositoryInterface interface {
+	CreateArticle(ctx context.Context, article *models.Article, revision *models.ArticleRevision) error
+	FindArticleByID(ctx context.Context, articleID string) (*models.Article, error)
+	FindArticleBySlug(ctx context.Context, slug string) (*models.Article, error)
+	ListArticles(ctx context.Context, filter models.ArticleFilter) ([]models.Article, int, error)
+	UpdateArticle(ctx context.Context, article *models.Article, revision *models.ArticleRevision, expectedUpdatedAt time.Time) (bool, error)
+	UpdateArticleStatus(ctx context.Context, article *models.Article, fromStatus string, entry *models.ArticleTransition) (bool, error)
+	ListTransitions(ctx context.Context, articleID string) ([]models.ArticleTransition, error)
+	PublishDueArticles(ctx context.Context, now time.Time, limit int) ([]models.ArticleTransition, error)
+	ArchiveExpiredArticles(ctx context.Context, now time.Time, limit int) ([]models.ArticleTransition, error)
+	DeleteArticle(ctx context.Context, articleID string) error
+	SlugExists(ctx context.Context, slug, excludeID string) (bool, error)
+	SetArticleTaxonomy(ctx context.Context, articleID string, categoryID *string, tags []models.ArticleTag) error
+	ListRevisions(ctx context.Context, articleID string) ([]models.ArticleRevision, error)
+	FindRevision(ctx context.Context, articleID string, revisionNumber int) (*models.ArticleRevision, error)
+}
+
+// ArticleRepository adalah implementasi dari ArticleRepositoryInterface
+type ArticleRepository struct {
+	db *sql.DB
+}
+
+// NewArticleRepository membuat instance baru dari ArticleRepository
+func NewArticleRepository(db *sql.DB) *ArticleRepository {
+	return &ArticleRepository{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanArticle(row rowScanner) (*models.Article, error) {
+	article := &models.Article{}
+	err := row.Scan(
+		&article.ID,
+		&article.Title,
+		&article.Slug,
+		&article.Excerpt,
+		&article.Body,
+		&article.CoverImageURL,
+		&article.AuthorID,
+		&article.CategoryID,
+		&article.Status,
+		&article.PublishAt,
+		&article.UnpublishAt,
+		&article.SubmittedAt,
+		&article.SubmittedBy,
+		&article.ReviewedAt,
+		&article.ReviewedBy,
+		&article.PublishedAt,
+		&article.PublishedBy,
+		&article.ArchivedAt,
+		&article.ArchivedBy,
+		&article.LastEditedBy,
+		&article.CreatedAt,
+		&article.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// CreateArticle menyimpan artikel baru beserta revisi pertamanya dalam satu transaksi
+func (r *ArticleRepository) CreateArticle(ctx context.Context, article *models.Article, revision *models.ArticleRevision) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	article.ID = uuid.New().String()
+	article.CreatedAt = time.Now().UTC()
+	article.UpdatedAt = article.CreatedAt
+
+	query := `
+		INSERT INTO articles (
+			id, title, slug, excerpt, body, cover_image_url, author_id, status, last_edited_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		article.ID,
+		article.Title,
+		article.Slug,
+		article.Excerpt,
+		article.Body,
+		article.CoverImageURL,
+		article.AuthorID,
+		article.Status,
+		article.LastEditedBy,
+		article.CreatedAt,
+		article.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err, "articles_slug_key") {
+			return ErrDuplicateSlug
+		}
+		return fmt.Errorf("gagal menyimpan artikel: %w", err)
+	}
+
+	revision.ArticleID = article.ID
+	if err := appendRevision(ctx, tx, revision); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return nil
+}
+
+// FindArticleByID mencari artikel berdasarkan ID
+func (r *ArticleRepository) FindArticleByID(ctx context.Context, articleID string) (*models.Article, error) {
+	query := `SELECT ` + articleColumns + ` FROM articles WHERE id = $1`
+	article, err := scanArticle(r.db.QueryRowContext(ctx, query, articleID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari artikel: %w", err)
+	}
+	if err := r.attachTags(ctx, article); err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// FindArticleBySlug mencari artikel berdasarkan slug
+func (r *ArticleRepository) FindArticleBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	query := `SELECT ` + articleColumns + ` FROM articles WHERE slug = $1`
+	article, err := scanArticle(r.db.QueryRowContext(ctx, query, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari artikel berdasarkan slug: %w", err)
+	}
+	if err := r.attachTags(ctx, article); err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// ListArticles mengambil daftar artikel sesuai filter beserta jumlah totalnya
+func (r *ArticleRepository) ListArticles(ctx context.Context, filter models.ArticleFilter) ([]models.Article, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.AuthorID != "" {
+		args = append(args, filter.AuthorID)
+		conditions = append(conditions, fmt.Sprintf("author_id = $%d", len(args)))
+	}
+	if filter.Search != "" {
+		args = append(args, sqlutil.ContainsPattern(filter.Search))
+		conditions = append(conditions, fmt.Sprintf(`title ILIKE $%d ESCAPE '\'`, len(args)))
+	}
+	if filter.CategoryPath != "" {
+		args = append(args, filter.CategoryPath)
+		conditions = append(conditions, fmt.Sprintf(
+			"category_id IN (SELECT id FROM categories WHERE path = $%[1]d OR path LIKE $%[1]d || '/%%')", len(args)))
+	}
+	if filter.TagSlug != "" {
+		args = append(args, filter.TagSlug)
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM article_tags at JOIN tags t ON t.id = at.tag_id WHERE at.article_id = articles.id AND t.slug = $%d)", len(args)))
+	}
+	if filter.VisibleAt != nil {
+		args = append(args, *filter.VisibleAt, models.StatusPublished, models.StatusScheduled)
+		conditions = append(conditions, fmt.Sprintf(
+			"(status = $%[2]d OR (status = $%[3]d AND publish_at <= $%[1]d)) AND (unpublish_at IS NULL OR unpublish_at > $%[1]d)",
+			len(args)-2, len(args)-1, len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM articles ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal menghitung artikel: %w", err)
+	}
+
+	// Artikel terbit diurutkan berdasarkan waktu terbit, selain itu berdasarkan perubahan terakhir
+	orderBy := "updated_at DESC"
+	if filter.Status == models.StatusPublished {
+		orderBy = "published_at DESC NULLS LAST, updated_at DESC"
+	}
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	query := fmt.Sprintf(`SELECT %s FROM articles %s ORDER BY %s LIMIT $%d OFFSET $%d`,
+		articleColumns, where, orderBy, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mengambil daftar artikel: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []models.Article{}
+	for rows.Next() {
+		article, err := scanArticle(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("gagal membaca data artikel: %w", err)
+		}
+		articles = append(articles, *article)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("gagal membaca daftar artikel: %w", err)
+	}
+
+	pointers := make([]*models.Article, len(articles))
+	for i := range articles {
+		pointers[i] = &articles[i]
+	}
+	if err := r.attachTags(ctx, pointers...); err != nil {
+		return nil, 0, err
+	}
+	return articles, total, nil
+}
+
+// UpdateArticle menyimpan perubahan konten artikel dan menambahkan revisi baru dalam satu transaksi.
+// Perubahan hanya diterapkan jika status di database masih sama dengan article.Status dan updated_at
+// masih sama dengan expectedUpdatedAt, sehingga konten tidak tersimpan ke artikel yang baru saja
+// dipublikasikan atau diarsipkan dan dua editor tidak saling menimpa tanpa sadar.
+// Hasil false berarti artikel telah diubah oleh proses lain.
+func (r *ArticleRepository) UpdateArticle(ctx context.Context, article *models.Article, revision *models.ArticleRevision, expectedUpdatedAt time.Time) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	// UPDATE mengunci baris artikel sehingga nomor revisi tidak bentrok saat disimpan bersamaan
+	query := `
+		UPDATE articles
+		SET title = $1, slug = $2, excerpt = $3, body = $4, cover_image_url = $5, last_edited_by = $6
+		WHERE id = $7 AND status = $8 AND updated_at = $9
+		RETURNING updated_at
+	`
+	err = tx.QueryRowContext(ctx, query,
+		article.Title,
+		article.Slug,
+		article.Excerpt,
+		article.Body,
+		article.CoverImageURL,
+		article.LastEditedBy,
+		article.ID,
+		article.Status,
+		expectedUpdatedAt,
+	).Scan(&article.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		if isUniqueViolation(err, "articles_slug_key") {
+			return false, ErrDuplicateSlug
+		}
+		return false, fmt.Errorf("gagal memperbarui artikel: %w", err)
+	}
+
+	revision.ArticleID = article.ID
+	if err := appendRevision(ctx, tx, revision); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return true, nil
+}
+
+// appendRevision menambahkan revisi dengan nomor berikutnya untuk artikel
+func appendRevision(ctx context.Context, tx *sql.Tx, revision *models.ArticleRevision) error {
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return fmt.Errorf("gagal membuat snapshot revisi: %w", err)
+	}
+
+	revision.ID = uuid.New().String()
+	query := `
+		INSERT INTO article_revisions (id, article_id, revision_number, author_id, snapshot, change_note)
+		SELECT $1, $2, COALESCE(MAX(revision_number), 0) + 1, $3, $4, $5
+		FROM article_revisions WHERE article_id = $2
+		RETURNING revision_number, created_at
+	`
+	err = tx.QueryRowContext(ctx, query,
+		revision.ID,
+		revision.ArticleID,
+		revision.AuthorID,
+		snapshot,
+		revision.ChangeNote,
+	).Scan(&revision.RevisionNumber, &revision.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan revisi artikel: %w", err)
+	}
+	return nil
+}
+
+// ListRevisions mengambil semua revisi artikel, dari yang terbaru
+func (r *ArticleRepository) ListRevisions(ctx context.Context, articleID string) ([]models.ArticleRevision, error) {
+	query := `
+		SELECT id, article_id, revision_number, author_id, snapshot, change_note, created_at
+		FROM article_revisions
+		WHERE article_id = $1
+		ORDER BY revision_number DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar revisi: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []models.ArticleRevision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar revisi: %w", err)
+	}
+	return revisions, nil
+}
+
+// FindRevision mencari revisi artikel berdasarkan nomor revisi
+func (r *ArticleRepository) FindRevision(ctx context.Context, articleID string, revisionNumber int) (*models.ArticleRevision, error) {
+	query := `
+		SELECT id, article_id, revision_number, author_id, snapshot, change_note, created_at
+		FROM article_revisions
+		WHERE article_id = $1 AND revision_number = $2
+	`
+	revision, err := scanRevision(r.db.QueryRowContext(ctx, query, articleID, revisionNumber))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return revision, nil
+}
+
+func scanRevision(row rowScanner) (*models.ArticleRevision, error) {
+	revision := &models.ArticleRevision{}
+	var snapshot []byte
+	err := row.Scan(
+		&revision.ID,
+		&revision.ArticleID,
+		&revision.RevisionNumber,
+		&revision.AuthorID,
+		&snapshot,
+		&revision.ChangeNote,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("gagal membaca data revisi: %w", err)
+	}
+	if err := json.Unmarshal(snapshot, &revision.Snapshot); err != nil {
+		return nil, fmt.Errorf("gagal membaca snapshot revisi: %w", err)
+	}
+	return revision, nil
+}
+
+// UpdateArticleStatus menyimpan status dan jejak editorial artikel beserta catatan transisinya.
+// Perubahan hanya diterapkan jika status di database masih sama dengan fromStatus, sehingga
+// dua transisi yang berjalan bersamaan tidak saling menimpa.
+func (r *ArticleRepository) UpdateArticleStatus(ctx context.Context, article *models.Article, fromStatus string, entry *models.ArticleTransition) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE articles
+		SET status = $1, publish_at = $2, unpublish_at = $3,
+			submitted_at = $4, submitted_by = $5,
+			reviewed_at = $6, reviewed_by = $7,
+			published_at = $8, published_by = $9,
+			archived_at = $10, archived_by = $11
+		WHERE id = $12 AND status = $13
+		RETURNING updated_at
+	`
+	err = tx.QueryRowContext(ctx, query,
+		article.Status,
+		article.PublishAt,
+		article.UnpublishAt,
+		article.SubmittedAt,
+		article.SubmittedBy,
+		article.ReviewedAt,
+		article.ReviewedBy,
+		article.PublishedAt,
+		article.PublishedBy,
+		article.ArchivedAt,
+		article.ArchivedBy,
+		article.ID,
+		fromStatus,
+	).Scan(&article.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("gagal memperbarui status artikel: %w", err)
+	}
+
+	entry.ArticleID = article.ID
+	if err := insertTransition(ctx, tx, entry); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return true, nil
+}
+
+// ListTransitions mengambil riwayat perpindahan status artikel, dari yang terlama
+func (r *ArticleRepository) ListTransitions(ctx context.Context, articleID string) ([]models.ArticleTransition, error) {
+	query := `
+		SELECT id, article_id, action, from_status, to_status, actor_id, automatic, reason, created_at
+		FROM article_transitions
+		WHERE article_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil riwayat transisi: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.ArticleTransition{}
+	for rows.Next() {
+		var entry models.ArticleTransition
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ArticleID,
+			&entry.Action,
+			&entry.FromStatus,
+			&entry.ToStatus,
+			&entry.ActorID,
+			&entry.Automatic,
+			&entry.Reason,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca data transisi: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca riwayat transisi: %w", err)
+	}
+	return entries, nil
+}
+
+// PublishDueArticles menerbitkan artikel terjadwal yang publish_at-nya sudah tercapai.
+// Baris dikunci dengan FOR UPDATE SKIP LOCKED sehingga beberapa instance aplikasi
+// yang berjalan bersamaan tidak memproses artikel yang sama dua kali.
+func (r *ArticleRepository) PublishDueArticles(ctx context.Context, now time.Time, limit int) ([]models.ArticleTransition, error) {
+	selectQuery := `
+		SELECT id FROM articles
+		WHERE status = 'scheduled' AND publish_at <= $1
+		ORDER BY publish_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+	// published_at diisi dengan waktu jadwal agar urutan terbit tidak bergantung pada interval polling
+	updateQuery := `
+		UPDATE articles
+		SET status = 'published', published_at = publish_at, published_by = NULL, publish_at = NULL
+		WHERE id = $1
+	`
+	return r.applyDueTransitions(ctx, now, limit, selectQuery, updateQuery, models.ArticleTransition{
+		Action:     models.ActionScheduledPublish,
+		FromStatus: models.StatusScheduled,
+		ToStatus:   models.StatusPublished,
+	})
+}
+
+// ArchiveExpiredArticles mengarsipkan artikel terbit yang masa embargonya (unpublish_at) sudah berakhir
+func (r *ArticleRepository) ArchiveExpiredArticles(ctx context.Context, now time.Time, limit int) ([]models.ArticleTransition, error) {
+	selectQuery := `
+		SELECT id FROM articles
+		WHERE status = 'published' AND unpublish_at <= $1
+		ORDER BY unpublish_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+	updateQuery := `
+		UPDATE articles
+		SET status = 'archived', archived_at = unpublish_at, archived_by = NULL, unpublish_at = NULL
+		WHERE id = $1
+	`
+	return r.applyDueTransitions(ctx, now, limit, selectQuery, updateQuery, models.ArticleTransition{
+		Action:     models.ActionEmbargoExpired,
+		FromStatus: models.StatusPublished,
+		ToStatus:   models.StatusArchived,
+	})
+}
+
+// applyDueTransitions mengunci artikel yang jatuh tempo, menerapkan updateQuery pada
+// setiap artikel dan mencatat transisinya dalam satu transaksi
+func (r *ArticleRepository) applyDueTransitions(ctx context.Context, now time.Time, limit int, selectQuery, updateQuery string, template models.ArticleTransition) ([]models.ArticleTransition, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, selectQuery, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil artikel yang jatuh tempo: %w", err)
+	}
+	var articleIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("gagal membaca artikel yang jatuh tempo: %w", err)
+		}
+		articleIDs = append(articleIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca artikel yang jatuh tempo: %w", err)
+	}
+
+	entries := make([]models.ArticleTransition, 0, len(articleIDs))
+	for _, id := range articleIDs {
+		if _, err := tx.ExecContext(ctx, updateQuery, id); err != nil {
+			return nil, fmt.Errorf("gagal memperbarui status artikel %s: %w", id, err)
+		}
+
+		entry := template
+		entry.ArticleID = id
+		entry.Automatic = true
+		entry.CreatedAt = now
+		if err := insertTransition(ctx, tx, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return entries, nil
+}
+
+// insertTransition mencatat satu perpindahan status artikel
+func insertTransition(ctx context.Context, tx *sql.Tx, entry *models.ArticleTransition) error {
+	entry.ID = uuid.New().String()
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+
+	query := `
+		INSERT INTO article_transitions (id, article_id, action, from_status, to_status, actor_id, automatic, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := tx.ExecContext(ctx, query,
+		entry.ID,
+		entry.ArticleID,
+		entry.Action,
+		entry.FromStatus,
+		entry.ToStatus,
+		entry.ActorID,
+		entry.Automatic,
+		entry.Reason,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal mencatat transisi artikel: %w", err)
+	}
+	return nil
+}
+
+// DeleteArticle menghapus artikel
+func (r *ArticleRepository) DeleteArticle(ctx context.Context, articleID string) error {
+	query := `DELETE FROM articles WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, articleID); err != nil {
+		return fmt.Errorf("gagal menghapus artikel: %w", err)
+	}
+	return nil
+}
+
+// SlugExists memeriksa apakah slug sudah dipakai oleh artikel lain
+func (r *ArticleRepository) SlugExists(ctx context.Context, slug, excludeID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM articles WHERE slug = $1 AND id <> $2)`
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, slug, excludeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("gagal memeriksa slug artikel: %w", err)
+	}
+	return exists, nil
+}
+
+// SetArticleTaxonomy mengganti kategori dan seluruh tag artikel dalam satu transaksi.
+// Tag yang slug-nya belum ada dibuat terlebih dahulu.
+func (r *ArticleRepository) SetArticleTaxonomy(ctx context.Context, articleID string, categoryID *string, tags []models.ArticleTag) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE articles SET category_id = $1 WHERE id = $2`, categoryID, articleID); err != nil {
+		if isForeignKeyViolation(err, "fk_articles_category") {
+			return ErrUnknownCategory
+		}
+		return fmt.Errorf("gagal menyimpan kategori artikel: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM article_tags WHERE article_id = $1`, articleID); err != nil {
+		return fmt.Errorf("gagal menghapus tag artikel: %w", err)
+	}
+
+	for i := range tags {
+		// DO UPDATE dengan nilai yang sama agar RETURNING tetap mengembalikan tag yang sudah ada
+		query := `
+			INSERT INTO tags (id, name, slug) VALUES ($1, $2, $3)
+			ON CONFLICT (slug) DO UPDATE SET slug = EXCLUDED.slug
+			RETURNING id, name
+		`
+		err := tx.QueryRowContext(ctx, query, uuid.New().String(), tags[i].Name, tags[i].Slug).Scan(&tags[i].ID, &tags[i].Name)
+		if err != nil {
+			return fmt.Errorf("gagal menyimpan tag: %w", err)
+		}
+
+		query = `INSERT INTO article_tags (article_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+		if _, err := tx.ExecContext(ctx, query, articleID, tags[i].ID); err != nil {
+			return fmt.Errorf("gagal menempelkan tag ke artikel: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return nil
+}
+
+// attachTags mengisi daftar tag untuk setiap artikel dengan satu query
+func (r *ArticleRepository) attachTags(ctx context.Context, articles ...*models.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(articles))
+	byID := make(map[string]*models.Article, len(articles))
+	for i, article := range articles {
+		article.Tags = []models.ArticleTag{}
+		ids[i] = article.ID
+		byID[article.ID] = article
+	}
+
+	query := `
+		SELECT at.article_id, t.id, t.name, t.slug
+		FROM article_tags at
+		JOIN tags t ON t.id = at.tag_id
+		WHERE at.article_id = ANY($1)
+		ORDER BY t.name
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("gagal mengambil tag artikel: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var articleID string
+		var tag models.ArticleTag
+		if err := rows.Scan(&articleID, &tag.ID, &tag.Name, &tag.Slug); err != nil {
+			return fmt.Errorf("gagal membaca tag artikel: %w", err)
+		}
+		if article, ok := byID[articleID]; ok {
+			article.Tags = append(article.Tags, tag)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("gagal membaca tag artikel: %w", err)
+	}
+	return nil
+}
+
+// isForeignKeyViolation memeriksa apakah error adalah pelanggaran foreign key tertentu
+func isForeignKeyViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == constraint
+}
+
+// isUniqueViolation memeriksa apakah error adalah pelanggaran constraint unik tertentu
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
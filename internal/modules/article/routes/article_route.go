@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/article/handlers"
+)
+
+// ArticleRoutes mengelola pendaftaran rute untuk modul artikel
+type ArticleRoutes struct {
+	articleHandler *handlers.ArticleHandler
+}
+
+// NewArticleRoutes membuat instance baru dari ArticleRoutes
+func NewArticleRoutes(articleHandler *handlers.ArticleHandler) *ArticleRoutes {
+	return &ArticleRoutes{articleHandler: articleHandler}
+}
+
+// RegisterRoutes mendaftarkan rute artikel ke router. Rute editorial dibungkus dengan
+// authMiddleware, sedangkan pembacaan artikel terbit terbuka untuk publik.
+func (r *ArticleRoutes) RegisterRoutes(router *http.ServeMux, authMiddleware func(http.Handler) http.Handler) {
+	router.Handle("/articles", authMiddleware(http.HandlerFunc(r.articleHandler.Articles)))
+	router.Handle("/articles/{articleID}", authMiddleware(http.HandlerFunc(r.articleHandler.Article)))
+	router.Handle("/articles/{articleID}/transitions", authMiddleware(http.HandlerFunc(r.articleHandler.Transition)))
+	router.Handle("/articles/{articleID}/taxonomy", authMiddleware(http.HandlerFunc(r.articleHandler.Taxonomy)))
+	router.Handle("/articles/{articleID}/revisions", authMiddleware(http.HandlerFunc(r.articleHandler.Revisions)))
+	router.Handle("/articles/{articleID}/revisions/diff", authMiddleware(http.HandlerFunc(r.articleHandler.DiffRevisions)))
+	router.Handle("/articles/{articleID}/revisions/{revision}", authMiddleware(http.HandlerFunc(r.articleHandler.Revision)))
+	router.Handle("/articles/{articleID}/revisions/{revision}/restore", authMiddleware(http.HandlerFunc(r.articleHandler.RestoreRevision)))
+
+	router.HandleFunc("/public/articles", r.articleHandler.PublishedArticles)
+	router.HandleFunc("/public/articles/{slug}", r.articleHandler.PublishedArticle)
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/article/repositories"
+)
+
+// defaultSchedulerBatchSize adalah jumlah maksimum artikel yang diproses per polling
+const defaultSchedulerBatchSize = 50
+
+// Clock menyediakan waktu saat ini. Scheduler menerima Clock agar waktu dapat
+// dikendalikan saat pengujian.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock adalah Clock yang memakai waktu sistem dalam UTC
+type SystemClock struct{}
+
+// Now mengembalikan waktu sistem saat ini
+func (SystemClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// ArticleScheduler menjalankan transisi artikel terjadwal di background: menerbitkan
+// artikel yang publish_at-nya tercapai dan mengarsipkan artikel yang embargonya berakhir.
+type ArticleScheduler struct {
+	articleRepo  repositories.ArticleRepositoryInterface
+	clock        Clock
+	interval     time.Duration
+	batchSize    int
+	queryTimeout time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewArticleScheduler membuat instance baru dari ArticleScheduler
+func NewArticleScheduler(articleRepo repositories.ArticleRepositoryInterface, clock Clock, interval time.Duration, batchSize int, queryTimeout time.Duration) *ArticleScheduler {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	if batchSize <= 0 {
+		batchSize = defaultSchedulerBatchSize
+	}
+	return &ArticleScheduler{
+		articleRepo:  articleRepo,
+		clock:        clock,
+		interval:     interval,
+		batchSize:    batchSize,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Start menjalankan polling di goroutine terpisah. Pemanggilan berulang diabaikan.
+func (s *ArticleScheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.loop(ctx, s.done)
+	log.Printf("Article scheduler berjalan setiap %s", s.interval)
+}
+
+// Stop menghentikan polling dan menunggu putaran yang sedang berjalan selesai
+// atau hingga ctx berakhir.
+func (s *ArticleScheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *ArticleScheduler) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Article scheduler gagal memproses artikel terjadwal: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce memproses semua artikel yang jatuh tempo menurut clock dan mengembalikan
+// jumlah transisi yang dijalankan. Batch diulang hingga tidak ada artikel tersisa.
+func (s *ArticleScheduler) RunOnce(ctx context.Context) (int, error) {
+	total := 0
+	now := s.clock.Now()
+
+	for _, apply := range []func(context.Context, time.Time, int) (int, error){
+		s.publishDue,
+		s.archiveExpired,
+	} {
+		for {
+			count, err := apply(ctx, now, s.batchSize)
+			total += count
+			if err != nil {
+				return total, err
+			}
+			if count < s.batchSize {
+				break
+			}
+		}
+	}
+	return total, nil
+}
+
+func (s *ArticleScheduler) publishDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	queryCtx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	entries, err := s.articleRepo.PublishDueArticles(queryCtx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		log.Printf("Artikel %s diterbitkan otomatis oleh scheduler", entry.ArticleID)
+	}
+	return len(entries), nil
+}
+
+func (s *ArticleScheduler) archiveExpired(ctx context.Context, now time.Time, limit int) (int, error) {
+	queryCtx, cancel := s.queryContext(ctx)
+	defer cancel()
+
+	entries, err := s.articleRepo.ArchiveExpiredArticles(queryCtx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		log.Printf("Artikel %s diarsipkan otomatis karena embargo berakhir", entry.ArticleID)
+	}
+	return len(entries), nil
+}
+
+func (s *ArticleScheduler) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.queryTimeout)
+}
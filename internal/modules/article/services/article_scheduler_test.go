@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/article/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/article/repositories"
+)
+
+// schedulerRepository mengembalikan jumlah artikel per batch sesuai antrean dan mencatat
+// urutan pemanggilan; method lain tidak dipanggil oleh scheduler
+type schedulerRepository struct {
+	repositories.ArticleRepositoryInterface
+	publishBatches []int
+	archiveBatches []int
+	publishErr     error
+	archiveErr     error
+	calls          []string
+	seenNow        []time.Time
+}
+
+func (r *schedulerRepository) PublishDueArticles(ctx context.Context, now time.Time, limit int) ([]models.ArticleTransition, error) {
+	return r.next("publish", now, limit, &r.publishBatches, r.publishErr)
+}
+
+func (r *schedulerRepository) ArchiveExpiredArticles(ctx context.Context, now time.Time, limit int) ([]models.ArticleTransition, error) {
+	return r.next("archive", now, limit, &r.archiveBatches, r.archiveErr)
+}
+
+func (r *schedulerRepository) next(action string, now time.Time, limit int, batches *[]int, err error) ([]models.ArticleTransition, error) {
+	r.calls = append(r.calls, action)
+	r.seenNow = append(r.seenNow, now)
+	if len(*batches) == 0 {
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	count := (*batches)[0]
+	*batches = (*batches)[1:]
+	if count > limit {
+		panic(fmt.Sprintf("batch %d melebihi limit %d", count, limit))
+	}
+
+	entries := make([]models.ArticleTransition, count)
+	for i := range entries {
+		entries[i] = models.ArticleTransition{ArticleID: fmt.Sprintf("%s-%d", action, i)}
+	}
+	return entries, nil
+}
+
+func TestArticleSchedulerRunOnce(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		publishBatches []int
+		archiveBatches []int
+		wantTotal      int
+		wantCalls      []string
+	}{
+		{
+			name:      "tidak ada artikel jatuh tempo",
+			wantCalls: []string{"publish", "archive"},
+		},
+		{
+			name:           "batch tidak penuh tidak diulang",
+			publishBatches: []int{2},
+			archiveBatches: []int{1},
+			wantTotal:      3,
+			wantCalls:      []string{"publish", "archive"},
+		},
+		{
+			name:           "batch penuh diulang sampai habis",
+			publishBatches: []int{3, 3, 1},
+			archiveBatches: []int{3, 0},
+			wantTotal:      10,
+			wantCalls:      []string{"publish", "publish", "publish", "archive", "archive"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &schedulerRepository{publishBatches: tt.publishBatches, archiveBatches: tt.archiveBatches}
+			scheduler := NewArticleScheduler(repo, &fakeClock{now: now}, time.Minute, 3, 0)
+
+			total, err := scheduler.RunOnce(context.Background())
+			if err != nil {
+				t.Fatalf("RunOnce error: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("total = %d, seharusnya %d", total, tt.wantTotal)
+			}
+			if !reflect.DeepEqual(repo.calls, tt.wantCalls) {
+				t.Errorf("urutan pemanggilan = %v, seharusnya %v", repo.calls, tt.wantCalls)
+			}
+			for _, seen := range repo.seenNow {
+				if !seen.Equal(now) {
+					t.Errorf("repository menerima waktu %v, seharusnya waktu clock %v", seen, now)
+				}
+			}
+		})
+	}
+}
+
+func TestArticleSchedulerRunOnceReturnsErrors(t *testing.T) {
+	errDatabase := errors.New("database tidak tersedia")
+
+	t.Run("publish gagal menghentikan putaran sebelum arsip", func(t *testing.T) {
+		repo := &schedulerRepository{publishBatches: []int{3}, publishErr: errDatabase}
+		scheduler := NewArticleScheduler(repo, &fakeClock{}, time.Minute, 3, 0)
+
+		total, err := scheduler.RunOnce(context.Background())
+		if !errors.Is(err, errDatabase) {
+			t.Fatalf("err = %v, seharusnya %v", err, errDatabase)
+		}
+		if total != 3 {
+			t.Errorf("total = %d, seharusnya 3 transisi yang sudah berhasil", total)
+		}
+		if want := []string{"publish", "publish"}; !reflect.DeepEqual(repo.calls, want) {
+			t.Errorf("urutan pemanggilan = %v, seharusnya %v", repo.calls, want)
+		}
+	})
+
+	t.Run("arsip gagal setelah publish berhasil", func(t *testing.T) {
+		repo := &schedulerRepository{publishBatches: []int{1}, archiveErr: errDatabase}
+		scheduler := NewArticleScheduler(repo, &fakeClock{}, time.Minute, 3, 0)
+
+		total, err := scheduler.RunOnce(context.Background())
+		if !errors.Is(err, errDatabase) {
+			t.Fatalf("err = %v, seharusnya %v", err, errDatabase)
+		}
+		if total != 1 {
+			t.Errorf("total = %d, seharusnya 1", total)
+		}
+	})
+}
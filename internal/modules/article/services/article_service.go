@@ -0,0 +1,640 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/article/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/article/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/article/repositories"
+	roles "github.com/jokosaputro95/cms-go/internal/modules/role/models"
+	"github.com/jokosaputro95/cms-go/internal/pkg/slug"
+	"github.com/jokosaputro95/cms-go/internal/pkg/textdiff"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ArticleServiceError adalah tipe error kustom untuk service artikel
+type ArticleServiceError string
+
+func (e ArticleServiceError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrArticleNotFound    = ArticleServiceError("artikel tidak ditemukan")
+	ErrForbidden          = ArticleServiceError("anda tidak memiliki izin untuk melakukan aksi ini")
+	ErrInvalidTransition  = ArticleServiceError("transisi status artikel tidak diizinkan")
+	ErrPublishAtRequired  = ArticleServiceError("publish_at wajib diisi dan harus di masa depan")
+	ErrSlugAlreadyUsed    = ArticleServiceError("slug artikel sudah digunakan")
+	ErrConcurrentUpdate   = ArticleServiceError("artikel telah diubah oleh pengguna lain, muat ulang artikel")
+	ErrInvalidArticleSlug = ArticleServiceError("slug artikel tidak valid")
+	ErrRevisionNotFound   = ArticleServiceError("revisi artikel tidak ditemukan")
+	ErrInvalidUnpublishAt = ArticleServiceError("unpublish_at harus setelah waktu terbit artikel")
+	ErrCategoryNotFound   = ArticleServiceError("kategori tidak ditemukan")
+	ErrInvalidTag         = ArticleServiceError("nama tag tidak valid")
+)
+
+// Actor adalah pengguna yang melakukan aksi terhadap artikel
+type Actor struct {
+	UserID      string
+	Permissions []string
+}
+
+// Can memeriksa apakah actor memiliki permission tertentu
+func (a Actor) Can(permission string) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// transition mendefinisikan satu langkah dalam alur editorial
+type transition struct {
+	from       []string
+	to         string
+	permission string
+	// allowOwner mengizinkan penulis artikel melakukan transisi tanpa permission di atas
+	allowOwner bool
+}
+
+// transitions adalah daftar aksi editorial yang diizinkan. Penulis hanya dapat mengirim
+// artikelnya untuk direview; menjadwalkan dan menerbitkan membutuhkan editor.
+var transitions = map[string]transition{
+	"submit":     {from: []string{models.StatusDraft}, to: models.StatusInReview, permission: roles.PermArticlesEditAny, allowOwner: true},
+	"reject":     {from: []string{models.StatusInReview}, to: models.StatusDraft, permission: roles.PermArticlesReview},
+	"schedule":   {from: []string{models.StatusInReview}, to: models.StatusScheduled, permission: roles.PermArticlesPublish},
+	"unschedule": {from: []string{models.StatusScheduled}, to: models.StatusInReview, permission: roles.PermArticlesPublish},
+	"publish":    {from: []string{models.StatusInReview, models.StatusScheduled}, to: models.StatusPublished, permission: roles.PermArticlesPublish},
+	"archive":    {from: []string{models.StatusPublished}, to: models.StatusArchived, permission: roles.PermArticlesPublish},
+	"restore":    {from: []string{models.StatusArchived}, to: models.StatusDraft, permission: roles.PermArticlesPublish},
+}
+
+// ArticleServiceInterface mendefinisikan kontrak untuk service artikel
+type ArticleServiceInterface interface {
+	CreateArticle(ctx context.Context, actor Actor, req *dto.CreateArticleRequestDTO) (*models.Article, error)
+	GetArticle(ctx context.Context, actor Actor, articleID string) (*models.Article, error)
+	GetPublishedArticle(ctx context.Context, slug string) (*models.Article, error)
+	ListArticles(ctx context.Context, actor Actor, filter models.ArticleFilter) ([]models.Article, int, error)
+	ListPublishedArticles(ctx context.Context, filter models.ArticleFilter) ([]models.Article, int, error)
+	SetArticleTaxonomy(ctx context.Context, actor Actor, articleID string, req *dto.SetArticleTaxonomyRequestDTO) (*models.Article, error)
+	UpdateArticle(ctx context.Context, actor Actor, articleID string, req *dto.UpdateArticleRequestDTO) (*models.Article, error)
+	DeleteArticle(ctx context.Context, actor Actor, articleID string) error
+	TransitionArticle(ctx context.Context, actor Actor, articleID string, req *dto.TransitionRequestDTO) (*models.Article, error)
+	ListTransitions(ctx context.Context, actor Actor, articleID string) ([]models.ArticleTransition, error)
+	ListRevisions(ctx context.Context, actor Actor, articleID string) ([]models.ArticleRevision, error)
+	GetRevision(ctx context.Context, actor Actor, articleID string, revisionNumber int) (*models.ArticleRevision, error)
+	DiffRevisions(ctx context.Context, actor Actor, articleID string, fromRevision, toRevision int) (*models.RevisionDiff, error)
+	RestoreRevision(ctx context.Context, actor Actor, articleID string, revisionNumber int, req *dto.RestoreRevisionRequestDTO) (*models.Article, error)
+}
+
+// ArticleService adalah implementasi dari ArticleServiceInterface
+type ArticleService struct {
+	articleRepo repositories.ArticleRepositoryInterface
+	clock       Clock
+	validate    *validator.Validate
+}
+
+// NewArticleService membuat instance baru dari ArticleService. Clock nil berarti memakai waktu sistem.
+func NewArticleService(articleRepo repositories.ArticleRepositoryInterface, clock Clock) *ArticleService {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return &ArticleService{
+		articleRepo: articleRepo,
+		clock:       clock,
+		validate:    validator.New(),
+	}
+}
+
+// CreateArticle membuat draft artikel baru dengan actor sebagai penulis
+func (s *ArticleService) CreateArticle(ctx context.Context, actor Actor, req *dto.CreateArticleRequestDTO) (*models.Article, error) {
+	if !actor.Can(roles.PermArticlesCreate) {
+		return nil, ErrForbidden
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	articleSlug, err := s.resolveSlug(ctx, req.Slug, req.Title, "")
+	if err != nil {
+		return nil, err
+	}
+
+	article := &models.Article{
+		Title:         strings.TrimSpace(req.Title),
+		Slug:          articleSlug,
+		Excerpt:       req.Excerpt,
+		Body:          req.Body,
+		CoverImageURL: req.CoverImageURL,
+		AuthorID:      actor.UserID,
+		Status:        models.StatusDraft,
+		LastEditedBy:  &actor.UserID,
+	}
+	revision := &models.ArticleRevision{
+		AuthorID:   actor.UserID,
+		Snapshot:   models.SnapshotOf(article),
+		ChangeNote: req.ChangeNote,
+	}
+	if err := s.articleRepo.CreateArticle(ctx, article, revision); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateSlug) {
+			return nil, ErrSlugAlreadyUsed
+		}
+		return nil, err
+	}
+
+	log.Printf("Artikel %s dibuat oleh %s", article.ID, actor.UserID)
+	return article, nil
+}
+
+// GetArticle mengambil artikel yang boleh dilihat oleh actor
+func (s *ArticleService) GetArticle(ctx context.Context, actor Actor, articleID string) (*models.Article, error) {
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(actor, article) {
+		return nil, ErrArticleNotFound
+	}
+	return article, nil
+}
+
+// GetPublishedArticle mengambil artikel yang sudah terbit berdasarkan slug untuk pembaca umum
+func (s *ArticleService) GetPublishedArticle(ctx context.Context, articleSlug string) (*models.Article, error) {
+	article, err := s.articleRepo.FindArticleBySlug(ctx, articleSlug)
+	if err != nil {
+		return nil, err
+	}
+	if article == nil || !isPubliclyVisible(article, s.clock.Now()) {
+		return nil, ErrArticleNotFound
+	}
+	return article, nil
+}
+
+// isPubliclyVisible memeriksa apakah artikel terlihat oleh pembaca umum pada waktu now.
+// Artikel terjadwal yang publish_at-nya tercapai sudah ditampilkan dan embargo yang berakhir
+// sudah disembunyikan walaupun scheduler belum sempat memprosesnya.
+func isPubliclyVisible(article *models.Article, now time.Time) bool {
+	switch article.Status {
+	case models.StatusPublished:
+	case models.StatusScheduled:
+		if article.PublishAt == nil || now.Before(*article.PublishAt) {
+			return false
+		}
+	default:
+		return false
+	}
+	return article.UnpublishAt == nil || now.Before(*article.UnpublishAt)
+}
+
+// ListArticles mengambil daftar artikel. Actor tanpa akses editorial hanya melihat artikelnya sendiri.
+func (s *ArticleService) ListArticles(ctx context.Context, actor Actor, filter models.ArticleFilter) ([]models.Article, int, error) {
+	if !actor.Can(roles.PermArticlesEditAny) && !actor.Can(roles.PermArticlesReview) {
+		if !actor.Can(roles.PermArticlesCreate) {
+			return nil, 0, ErrForbidden
+		}
+		filter.AuthorID = actor.UserID
+	}
+	return s.articleRepo.ListArticles(ctx, filter)
+}
+
+// ListPublishedArticles mengambil daftar artikel terbit untuk pembaca umum, dapat difilter
+// berdasarkan path kategori (termasuk turunannya) dan slug tag
+func (s *ArticleService) ListPublishedArticles(ctx context.Context, filter models.ArticleFilter) ([]models.Article, int, error) {
+	now := s.clock.Now()
+	filter.Status = ""
+	filter.AuthorID = ""
+	filter.VisibleAt = &now
+	filter.CategoryPath = strings.Trim(filter.CategoryPath, "/")
+	return s.articleRepo.ListArticles(ctx, filter)
+}
+
+// SetArticleTaxonomy mengatur kategori dan tag artikel. Aturan izinnya sama dengan mengubah konten.
+func (s *ArticleService) SetArticleTaxonomy(ctx context.Context, actor Actor, articleID string, req *dto.SetArticleTaxonomyRequestDTO) (*models.Article, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit(actor, article) {
+		return nil, ErrForbidden
+	}
+
+	var categoryID *string
+	if req.CategoryID != nil && strings.TrimSpace(*req.CategoryID) != "" {
+		categoryID = req.CategoryID
+	}
+
+	tags := []models.ArticleTag{}
+	seen := make(map[string]bool, len(req.Tags))
+	for _, name := range req.Tags {
+		tagSlug := slug.Make(name)
+		if tagSlug == "" {
+			return nil, ErrInvalidTag
+		}
+		if seen[tagSlug] {
+			continue
+		}
+		seen[tagSlug] = true
+		tags = append(tags, models.ArticleTag{Name: strings.TrimSpace(name), Slug: tagSlug})
+	}
+
+	if err := s.articleRepo.SetArticleTaxonomy(ctx, article.ID, categoryID, tags); err != nil {
+		if errors.Is(err, repositories.ErrUnknownCategory) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	article.CategoryID = categoryID
+	article.Tags = tags
+	return article, nil
+}
+
+// UpdateArticle mengubah konten artikel sesuai aturan kepemilikan dan status
+func (s *ArticleService) UpdateArticle(ctx context.Context, actor Actor, articleID string, req *dto.UpdateArticleRequestDTO) (*models.Article, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit(actor, article) {
+		return nil, ErrForbidden
+	}
+
+	// Tolak lebih awal jika editor bekerja dengan salinan artikel yang sudah usang.
+	// Repository tetap memeriksa ulang di query UPDATE untuk menangani balapan.
+	expectedUpdatedAt := req.UpdatedAt.Truncate(time.Microsecond)
+	if !article.UpdatedAt.Equal(expectedUpdatedAt) {
+		return nil, ErrConcurrentUpdate
+	}
+
+	if req.Title != nil {
+		article.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Slug != nil {
+		articleSlug, err := s.resolveSlug(ctx, *req.Slug, article.Title, article.ID)
+		if err != nil {
+			return nil, err
+		}
+		article.Slug = articleSlug
+	}
+	if req.Excerpt != nil {
+		article.Excerpt = req.Excerpt
+	}
+	if req.Body != nil {
+		article.Body = *req.Body
+	}
+	if req.CoverImageURL != nil {
+		article.CoverImageURL = req.CoverImageURL
+	}
+
+	if err := s.saveRevision(ctx, actor, article, req.ChangeNote); err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// saveRevision menyimpan konten artikel dan menambahkan revisi baru. Semua perubahan
+// konten, baik dari handler REST maupun proses lain, harus melalui fungsi ini. Artikel
+// harus masih memiliki updated_at yang sama dengan saat dibaca.
+func (s *ArticleService) saveRevision(ctx context.Context, actor Actor, article *models.Article, changeNote *string) error {
+	expectedUpdatedAt := article.UpdatedAt
+	article.LastEditedBy = &actor.UserID
+	revision := &models.ArticleRevision{
+		AuthorID:   actor.UserID,
+		Snapshot:   models.SnapshotOf(article),
+		ChangeNote: changeNote,
+	}
+
+	updated, err := s.articleRepo.UpdateArticle(ctx, article, revision, expectedUpdatedAt)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicateSlug) {
+			return ErrSlugAlreadyUsed
+		}
+		return err
+	}
+	if !updated {
+		return ErrConcurrentUpdate
+	}
+	return nil
+}
+
+// ListRevisions mengambil riwayat revisi artikel
+func (s *ArticleService) ListRevisions(ctx context.Context, actor Actor, articleID string) ([]models.ArticleRevision, error) {
+	if _, err := s.GetArticle(ctx, actor, articleID); err != nil {
+		return nil, err
+	}
+	return s.articleRepo.ListRevisions(ctx, articleID)
+}
+
+// GetRevision mengambil satu revisi artikel beserta snapshot lengkapnya
+func (s *ArticleService) GetRevision(ctx context.Context, actor Actor, articleID string, revisionNumber int) (*models.ArticleRevision, error) {
+	if _, err := s.GetArticle(ctx, actor, articleID); err != nil {
+		return nil, err
+	}
+	return s.findRevision(ctx, articleID, revisionNumber)
+}
+
+// DiffRevisions membandingkan dua revisi artikel per field dan per baris isi artikel
+func (s *ArticleService) DiffRevisions(ctx context.Context, actor Actor, articleID string, fromRevision, toRevision int) (*models.RevisionDiff, error) {
+	if _, err := s.GetArticle(ctx, actor, articleID); err != nil {
+		return nil, err
+	}
+
+	from, err := s.findRevision(ctx, articleID, fromRevision)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findRevision(ctx, articleID, toRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RevisionDiff{
+		ArticleID:    articleID,
+		FromRevision: from.RevisionNumber,
+		ToRevision:   to.RevisionNumber,
+		Fields:       diffSnapshots(from.Snapshot, to.Snapshot),
+		BodyDiff:     textdiff.Lines(from.Snapshot.Body, to.Snapshot.Body),
+	}, nil
+}
+
+// RestoreRevision mengembalikan konten artikel ke revisi lama dengan menyimpannya sebagai revisi baru
+func (s *ArticleService) RestoreRevision(ctx context.Context, actor Actor, articleID string, revisionNumber int, req *dto.RestoreRevisionRequestDTO) (*models.Article, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit(actor, article) {
+		return nil, ErrForbidden
+	}
+
+	revision, err := s.findRevision(ctx, articleID, revisionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	article.Title = revision.Snapshot.Title
+	article.Slug = revision.Snapshot.Slug
+	article.Excerpt = revision.Snapshot.Excerpt
+	article.Body = revision.Snapshot.Body
+	article.CoverImageURL = revision.Snapshot.CoverImageURL
+
+	changeNote := req.ChangeNote
+	if changeNote == nil {
+		note := fmt.Sprintf("Restored from revision %d", revision.RevisionNumber)
+		changeNote = &note
+	}
+
+	if err := s.saveRevision(ctx, actor, article, changeNote); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Artikel %s dikembalikan ke revisi %d oleh %s", article.ID, revision.RevisionNumber, actor.UserID)
+	return article, nil
+}
+
+func (s *ArticleService) findRevision(ctx context.Context, articleID string, revisionNumber int) (*models.ArticleRevision, error) {
+	revision, err := s.articleRepo.FindRevision(ctx, articleID, revisionNumber)
+	if err != nil {
+		return nil, err
+	}
+	if revision == nil {
+		return nil, ErrRevisionNotFound
+	}
+	return revision, nil
+}
+
+// diffSnapshots membandingkan field-field snapshot dan mengembalikan field yang berubah
+func diffSnapshots(from, to models.RevisionSnapshot) []models.FieldChange {
+	changes := []models.FieldChange{}
+	addChange := func(field string, oldValue, newValue interface{}, changed bool) {
+		if changed {
+			changes = append(changes, models.FieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	addChange("title", from.Title, to.Title, from.Title != to.Title)
+	addChange("slug", from.Slug, to.Slug, from.Slug != to.Slug)
+	addChange("excerpt", from.Excerpt, to.Excerpt, !equalStringPtr(from.Excerpt, to.Excerpt))
+	addChange("cover_image_url", from.CoverImageURL, to.CoverImageURL, !equalStringPtr(from.CoverImageURL, to.CoverImageURL))
+	// Isi artikel bisa sangat panjang, detail perubahannya ada di body_diff
+	addChange("body", nil, nil, from.Body != to.Body)
+
+	return changes
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// DeleteArticle menghapus artikel. Penulis hanya dapat menghapus draft miliknya sendiri.
+func (s *ArticleService) DeleteArticle(ctx context.Context, actor Actor, articleID string) error {
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		return err
+	}
+
+	isOwnDraft := article.AuthorID == actor.UserID && article.Status == models.StatusDraft && actor.Can(roles.PermArticlesEditOwn)
+	if !actor.Can(roles.PermArticlesDelete) && !isOwnDraft {
+		return ErrForbidden
+	}
+
+	if err := s.articleRepo.DeleteArticle(ctx, article.ID); err != nil {
+		return err
+	}
+	log.Printf("Artikel %s dihapus oleh %s", article.ID, actor.UserID)
+	return nil
+}
+
+// TransitionArticle memindahkan artikel ke status berikutnya dalam alur editorial
+func (s *ArticleService) TransitionArticle(ctx context.Context, actor Actor, articleID string, req *dto.TransitionRequestDTO) (*models.Article, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	step := transitions[req.Action]
+	if !containsStatus(step.from, article.Status) {
+		return nil, ErrInvalidTransition
+	}
+	isOwner := article.AuthorID == actor.UserID && actor.Can(roles.PermArticlesEditOwn)
+	if !actor.Can(step.permission) && !(step.allowOwner && isOwner) {
+		return nil, ErrForbidden
+	}
+
+	now := s.clock.Now()
+	fromStatus := article.Status
+	article.Status = step.to
+
+	switch req.Action {
+	case "submit":
+		article.SubmittedAt = &now
+		article.SubmittedBy = &actor.UserID
+	case "reject":
+		article.ReviewedAt = &now
+		article.ReviewedBy = &actor.UserID
+	case "schedule":
+		if req.PublishAt == nil || !req.PublishAt.After(now) {
+			return nil, ErrPublishAtRequired
+		}
+		publishAt := req.PublishAt.UTC()
+		article.PublishAt = &publishAt
+		if err := applyUnpublishAt(article, req.UnpublishAt, publishAt); err != nil {
+			return nil, err
+		}
+		article.ReviewedAt = &now
+		article.ReviewedBy = &actor.UserID
+	case "unschedule":
+		article.PublishAt = nil
+	case "publish":
+		article.PublishAt = nil
+		if err := applyUnpublishAt(article, req.UnpublishAt, now); err != nil {
+			return nil, err
+		}
+		article.ReviewedAt = &now
+		article.ReviewedBy = &actor.UserID
+		article.PublishedAt = &now
+		article.PublishedBy = &actor.UserID
+	case "archive":
+		article.UnpublishAt = nil
+		article.ArchivedAt = &now
+		article.ArchivedBy = &actor.UserID
+	case "restore":
+		article.ArchivedAt = nil
+		article.ArchivedBy = nil
+	}
+
+	entry := &models.ArticleTransition{
+		Action:     req.Action,
+		FromStatus: fromStatus,
+		ToStatus:   article.Status,
+		ActorID:    &actor.UserID,
+		Reason:     req.Reason,
+		CreatedAt:  now,
+	}
+	updated, err := s.articleRepo.UpdateArticleStatus(ctx, article, fromStatus, entry)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrConcurrentUpdate
+	}
+
+	log.Printf("Artikel %s berpindah dari %s ke %s oleh %s", article.ID, fromStatus, article.Status, actor.UserID)
+	return article, nil
+}
+
+// ListTransitions mengambil riwayat perpindahan status artikel, termasuk transisi otomatis dari scheduler
+func (s *ArticleService) ListTransitions(ctx context.Context, actor Actor, articleID string) ([]models.ArticleTransition, error) {
+	if _, err := s.GetArticle(ctx, actor, articleID); err != nil {
+		return nil, err
+	}
+	return s.articleRepo.ListTransitions(ctx, articleID)
+}
+
+// applyUnpublishAt memasang batas embargo opsional yang harus jatuh setelah waktu terbit
+func applyUnpublishAt(article *models.Article, unpublishAt *time.Time, publishAt time.Time) error {
+	if unpublishAt == nil {
+		return nil
+	}
+	if !unpublishAt.After(publishAt) {
+		return ErrInvalidUnpublishAt
+	}
+	value := unpublishAt.UTC()
+	article.UnpublishAt = &value
+	return nil
+}
+
+func (s *ArticleService) findArticle(ctx context.Context, articleID string) (*models.Article, error) {
+	article, err := s.articleRepo.FindArticleByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+	return article, nil
+}
+
+// resolveSlug membuat slug dari input atau judul, lalu menambahkan akhiran angka jika sudah dipakai
+func (s *ArticleService) resolveSlug(ctx context.Context, requested, title, excludeID string) (string, error) {
+	base := slug.Make(requested)
+	if requested == "" {
+		base = slug.Make(title)
+	}
+	if base == "" {
+		return "", ErrInvalidArticleSlug
+	}
+
+	candidate := base
+	for i := 2; ; i++ {
+		exists, err := s.articleRepo.SlugExists(ctx, candidate, excludeID)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		// Slug yang diminta secara eksplisit tidak diubah diam-diam
+		if requested != "" {
+			return "", ErrSlugAlreadyUsed
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// canView menentukan apakah actor boleh melihat artikel di area editorial
+func canView(actor Actor, article *models.Article) bool {
+	if article.AuthorID == actor.UserID {
+		return true
+	}
+	return actor.Can(roles.PermArticlesEditAny) || actor.Can(roles.PermArticlesReview)
+}
+
+// canEdit menentukan apakah actor boleh mengubah konten artikel. Penulis hanya dapat
+// mengubah draft miliknya; editor dapat mengubah artikel apa pun yang belum diarsipkan.
+func canEdit(actor Actor, article *models.Article) bool {
+	if article.Status == models.StatusArchived {
+		return false
+	}
+	if actor.Can(roles.PermArticlesEditAny) {
+		return true
+	}
+	return article.AuthorID == actor.UserID &&
+		article.Status == models.StatusDraft &&
+		actor.Can(roles.PermArticlesEditOwn)
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
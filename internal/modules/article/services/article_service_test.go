@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/article/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/article/repositories"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// slugRepository hanya mengimplementasikan FindArticleBySlug; method lain tidak dipanggil
+type slugRepository struct {
+	repositories.ArticleRepositoryInterface
+	articles map[string]*models.Article
+}
+
+func (r *slugRepository) FindArticleBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	article, ok := r.articles[slug]
+	if !ok {
+		return nil, nil
+	}
+	copied := *article
+	return &copied, nil
+}
+
+func TestGetPublishedArticleFollowsClock(t *testing.T) {
+	publishAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	unpublishAt := publishAt.Add(48 * time.Hour)
+	repo := &slugRepository{articles: map[string]*models.Article{
+		"terjadwal": {
+			ID:          "article-1",
+			Slug:        "terjadwal",
+			Status:      models.StatusScheduled,
+			PublishAt:   &publishAt,
+			UnpublishAt: &unpublishAt,
+		},
+	}}
+	clock := &fakeClock{}
+	service := NewArticleService(repo, clock)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		visible bool
+	}{
+		{"sebelum publish_at", publishAt.Add(-time.Second), false},
+		{"tepat pada publish_at", publishAt, true},
+		{"setelah publish_at", publishAt.Add(time.Hour), true},
+		{"embargo berakhir", unpublishAt, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.now = tt.now
+			article, err := service.GetPublishedArticle(context.Background(), "terjadwal")
+			if tt.visible {
+				if err != nil || article == nil {
+					t.Fatalf("artikel seharusnya terlihat, err = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrArticleNotFound) {
+				t.Fatalf("err = %v, seharusnya ErrArticleNotFound", err)
+			}
+		})
+	}
+}
+
+func TestGetPublishedArticleHidesUnpublishedStatuses(t *testing.T) {
+	past := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &slugRepository{articles: map[string]*models.Article{
+		"draft":     {Slug: "draft", Status: models.StatusDraft, PublishAt: &past},
+		"in-review": {Slug: "in-review", Status: models.StatusInReview},
+		"terbit":    {Slug: "terbit", Status: models.StatusPublished},
+	}}
+	service := NewArticleService(repo, &fakeClock{now: past.Add(time.Hour)})
+
+	for _, slug := range []string{"draft", "in-review", "tidak-ada"} {
+		if _, err := service.GetPublishedArticle(context.Background(), slug); !errors.Is(err, ErrArticleNotFound) {
+			t.Errorf("slug %q: err = %v, seharusnya ErrArticleNotFound", slug, err)
+		}
+	}
+	if _, err := service.GetPublishedArticle(context.Background(), "terbit"); err != nil {
+		t.Errorf("artikel terbit seharusnya terlihat, err = %v", err)
+	}
+}
@@ -24,6 +24,11 @@ type AuthResponseDTO struct {
 	ExpiresIn    int64  `json:"expires_in"` // Waktu kadaluarsa
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	// MFA diisi ketika login membutuhkan langkah kedua; token belum diterbitkan
+	MFA *MFAChallengeDTO `json:"-"`
+	// RecoveryCodes hanya diisi sekali ketika MFA diaktifkan lewat alur login
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
 }
 
 type RefreshTokenRequestDTO struct {
@@ -32,4 +37,394 @@ type RefreshTokenRequestDTO struct {
 
 type VerifyEmailRequestDTO struct {
 	Token string `json:"token"`
-}
\ No newline at end of file
+}
+// ForgotPasswordRequestDTO digunakan untuk meminta tautan reset password
+type ForgotPasswordRequestDTO struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequestDTO digunakan untuk mengganti password menggunakan token reset
+type ResetPasswordRequestDTO struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8"`
+}
+
+// ResendVerificationRequestDTO digunakan untuk meminta email verifikasi baru
+type ResendVerificationRequestDTO struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// MagicLinkRequestDTO digunakan untuk meminta tautan login tanpa password
+type MagicLinkRequestDTO struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// MagicLinkResponseDTO berisi nonce yang mengikat magic link ke browser yang memintanya.
+// Nonce juga dikirim sebagai cookie; klien non-browser menyimpannya sendiri.
+type MagicLinkResponseDTO struct {
+	Nonce string `json:"nonce"`
+}
+
+// MagicLinkLoginRequestDTO digunakan untuk login dengan token dari magic link.
+// Nonce kosong berarti tautan dibuka di browser lain.
+type MagicLinkLoginRequestDTO struct {
+	Token string `json:"token" validate:"required"`
+	Nonce string `json:"nonce"`
+}
+
+// MFAChallengeDTO dikembalikan oleh login ketika pengguna harus memasukkan kode MFA.
+// EnrollmentRequired berarti role pengguna mewajibkan MFA tetapi pengguna belum mendaftar.
+type MFAChallengeDTO struct {
+	MFAToken           string   `json:"mfa_token"`
+	EnrollmentRequired bool     `json:"enrollment_required"`
+	Methods            []string `json:"methods"`
+	ExpiresIn          int64    `json:"expires_in"`
+}
+
+// VerifyMFARequestDTO digunakan untuk menyelesaikan login dengan kode TOTP, kode pemulihan
+// atau assertion WebAuthn dari challenge /auth/mfa/webauthn
+type VerifyMFARequestDTO struct {
+	MFAToken     string                          `json:"mfa_token" validate:"required"`
+	Code         string                          `json:"code" validate:"required_without_all=RecoveryCode WebAuthn,omitempty,len=6,numeric"`
+	RecoveryCode string                          `json:"recovery_code" validate:"required_without_all=Code WebAuthn,omitempty,max=32"`
+	WebAuthn     *WebAuthnAssertionCredentialDTO `json:"webauthn"`
+}
+
+// EnrollMFARequestDTO digunakan untuk mendaftarkan TOTP saat login ketika role mewajibkan MFA
+type EnrollMFARequestDTO struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
+// MFACodeRequestDTO digunakan oleh operasi MFA yang membutuhkan konfirmasi kode TOTP
+// atau kode pemulihan dari pengguna yang sudah login
+type MFACodeRequestDTO struct {
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" validate:"required_without=Code,omitempty,max=32"`
+}
+
+// TOTPSetupResponseDTO berisi secret TOTP dan otpauth URI untuk ditampilkan sebagai QR code
+type TOTPSetupResponseDTO struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// RecoveryCodesResponseDTO berisi kode pemulihan yang hanya ditampilkan sekali
+type RecoveryCodesResponseDTO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAStatusResponseDTO berisi status MFA pengguna
+type MFAStatusResponseDTO struct {
+	Enabled                bool       `json:"enabled"`
+	Required               bool       `json:"required"`
+	ConfirmedAt            *time.Time `json:"confirmed_at,omitempty"`
+	RemainingRecoveryCodes int        `json:"remaining_recovery_codes"`
+	WebAuthnCredentials    int        `json:"webauthn_credentials"`
+}
+
+// SessionResponseDTO berisi informasi satu sesi login (perangkat) milik pengguna
+type SessionResponseDTO struct {
+	ID         string    `json:"id"`
+	UserAgent  *string   `json:"user_agent"`
+	IPAddress  *string   `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// CreateAPITokenRequestDTO digunakan untuk membuat personal access token. Scopes berisi nama
+// permission yang boleh dipakai token; ExpiresAt kosong berarti token tidak kedaluwarsa.
+type CreateAPITokenRequestDTO struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APITokenResponseDTO berisi informasi personal access token tanpa nilai tokennya
+type APITokenResponseDTO struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	LastUsedIP *string    `json:"last_used_ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPITokenResponseDTO berisi token mentah yang hanya ditampilkan sekali saat dibuat
+type CreatedAPITokenResponseDTO struct {
+	APITokenResponseDTO
+	Token string `json:"token"`
+}
+
+// OAuthLoginResponseDTO berisi URL otorisasi penyedia untuk memulai login OAuth.
+// State juga dikirim sebagai cookie agar callback terikat ke browser yang memulai login.
+type OAuthLoginResponseDTO struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"-"`
+}
+
+// OAuthCallbackRequestDTO berisi parameter callback dari penyedia OAuth
+type OAuthCallbackRequestDTO struct {
+	Code  string `validate:"required"`
+	State string `validate:"required"`
+}
+
+// CreateOAuthClientRequestDTO digunakan untuk mendaftarkan aplikasi yang login dengan akun CMS.
+// Public client (SPA/mobile) tidak mendapat secret dan wajib memakai PKCE.
+type CreateOAuthClientRequestDTO struct {
+	Name         string   `json:"name" validate:"required,max=100"`
+	RedirectURIs []string `json:"redirect_uris" validate:"dive,required,max=2000"`
+	GrantTypes   []string `json:"grant_types" validate:"dive,oneof=authorization_code refresh_token client_credentials"`
+	Scopes       []string `json:"scopes" validate:"dive,required,max=100"`
+	Public       bool     `json:"public"`
+}
+
+// OAuthClientResponseDTO berisi informasi client OAuth tanpa secret
+type OAuthClientResponseDTO struct {
+	ClientID     string    `json:"client_id"`
+	Name         string    `json:"name"`
+	Public       bool      `json:"public"`
+	RedirectURIs []string  `json:"redirect_uris"`
+	GrantTypes   []string  `json:"grant_types"`
+	Scopes       []string  `json:"scopes"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// OAuthClientSecretResponseDTO berisi secret client yang hanya ditampilkan sekali
+type OAuthClientSecretResponseDTO struct {
+	OAuthClientResponseDTO
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// OAuth2AuthorizeRequestDTO berisi parameter permintaan otorisasi dari client, diteruskan
+// apa adanya oleh halaman consent
+type OAuth2AuthorizeRequestDTO struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+}
+
+// OAuth2ConsentRequestDTO berisi keputusan pengguna pada layar consent
+type OAuth2ConsentRequestDTO struct {
+	OAuth2AuthorizeRequestDTO
+	Approve bool `json:"approve"`
+}
+
+// OAuth2AuthorizationDTO berisi data untuk layar consent. ConsentGranted berarti semua scope
+// sudah pernah disetujui sehingga halaman consent boleh langsung menyetujui.
+type OAuth2AuthorizationDTO struct {
+	ClientID       string   `json:"client_id"`
+	ClientName     string   `json:"client_name"`
+	Scopes         []string `json:"scopes"`
+	ConsentGranted bool     `json:"consent_granted"`
+}
+
+// OAuth2RedirectDTO berisi URL redirect kembali ke client beserta code atau error
+type OAuth2RedirectDTO struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// OAuth2ClientAuthDTO berisi kredensial client dari header Basic atau body form
+type OAuth2ClientAuthDTO struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// OAuth2TokenRequestDTO berisi parameter form endpoint token (RFC 6749 bagian 4)
+type OAuth2TokenRequestDTO struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+// OAuth2TokenResponseDTO adalah respons sukses endpoint token (RFC 6749 bagian 5.1)
+type OAuth2TokenResponseDTO struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// OAuth2ErrorResponseDTO adalah respons error endpoint OAuth (RFC 6749 bagian 5.2)
+type OAuth2ErrorResponseDTO struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// OAuth2IntrospectionDTO adalah respons endpoint introspeksi token (RFC 7662)
+type OAuth2IntrospectionDTO struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	Sub       string `json:"sub,omitempty"`
+	Iss       string `json:"iss,omitempty"`
+}
+
+// OAuthConsentResponseDTO berisi aplikasi yang sudah diberi akses oleh pengguna
+type OAuthConsentResponseDTO struct {
+	ClientID   string    `json:"client_id"`
+	ClientName string    `json:"client_name"`
+	Scopes     []string  `json:"scopes"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// OpenIDConfigurationDTO adalah dokumen /.well-known/openid-configuration
+type OpenIDConfigurationDTO struct {
+	Issuer                                     string   `json:"issuer"`
+	AuthorizationEndpoint                      string   `json:"authorization_endpoint"`
+	TokenEndpoint                              string   `json:"token_endpoint"`
+	UserInfoEndpoint                           string   `json:"userinfo_endpoint"`
+	JWKSURI                                    string   `json:"jwks_uri"`
+	IntrospectionEndpoint                      string   `json:"introspection_endpoint"`
+	RevocationEndpoint                         string   `json:"revocation_endpoint"`
+	ScopesSupported                            []string `json:"scopes_supported"`
+	ResponseTypesSupported                     []string `json:"response_types_supported"`
+	GrantTypesSupported                        []string `json:"grant_types_supported"`
+	SubjectTypesSupported                      []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported           []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported          []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported              []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                            []string `json:"claims_supported"`
+	AuthorizationResponseIssParameterSupported bool     `json:"authorization_response_iss_parameter_supported"`
+}
+
+// WebAuthnRelyingPartyDTO adalah PublicKeyCredentialRpEntity
+type WebAuthnRelyingPartyDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WebAuthnUserDTO adalah PublicKeyCredentialUserEntity; ID adalah user handle dalam base64url
+type WebAuthnUserDTO struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// WebAuthnCredentialParameterDTO adalah algoritma kunci yang diterima
+type WebAuthnCredentialParameterDTO struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// WebAuthnCredentialDescriptorDTO menunjuk kredensial yang sudah terdaftar
+type WebAuthnCredentialDescriptorDTO struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// WebAuthnAuthenticatorSelectionDTO berisi syarat authenticator saat registrasi
+type WebAuthnAuthenticatorSelectionDTO struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnCreationOptionsDTO adalah PublicKeyCredentialCreationOptionsJSON yang dapat langsung
+// diberikan ke PublicKeyCredential.parseCreationOptionsFromJSON di browser
+type WebAuthnCreationOptionsDTO struct {
+	Challenge              string                            `json:"challenge"`
+	RP                     WebAuthnRelyingPartyDTO           `json:"rp"`
+	User                   WebAuthnUserDTO                   `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParameterDTO  `json:"pubKeyCredParams"`
+	Timeout                int64                             `json:"timeout"`
+	Attestation            string                            `json:"attestation"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptorDTO `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelectionDTO `json:"authenticatorSelection"`
+}
+
+// WebAuthnRequestOptionsDTO adalah PublicKeyCredentialRequestOptionsJSON untuk login.
+// AllowCredentials kosong berarti authenticator memilih passkey yang tersimpan untuk RP ID.
+type WebAuthnRequestOptionsDTO struct {
+	Challenge        string                            `json:"challenge"`
+	Timeout          int64                             `json:"timeout"`
+	RPID             string                            `json:"rpId"`
+	AllowCredentials []WebAuthnCredentialDescriptorDTO `json:"allowCredentials"`
+	UserVerification string                            `json:"userVerification"`
+}
+
+// WebAuthnAttestationResponseDTO adalah AuthenticatorAttestationResponseJSON (base64url)
+type WebAuthnAttestationResponseDTO struct {
+	ClientDataJSON    string   `json:"clientDataJSON" validate:"required"`
+	AttestationObject string   `json:"attestationObject" validate:"required"`
+	Transports        []string `json:"transports" validate:"max=10,dive,max=32"`
+}
+
+// WebAuthnRegistrationCredentialDTO adalah RegistrationResponseJSON dari navigator.credentials.create
+type WebAuthnRegistrationCredentialDTO struct {
+	ID       string                         `json:"id" validate:"required,max=1400"`
+	Type     string                         `json:"type" validate:"required,eq=public-key"`
+	Response WebAuthnAttestationResponseDTO `json:"response"`
+}
+
+// WebAuthnRegisterRequestDTO digunakan untuk menyimpan kredensial setelah registrasi di browser
+type WebAuthnRegisterRequestDTO struct {
+	Name       string                            `json:"name" validate:"required,min=1,max=100"`
+	Credential WebAuthnRegistrationCredentialDTO `json:"credential"`
+}
+
+// WebAuthnAssertionResponseDTO adalah AuthenticatorAssertionResponseJSON (base64url)
+type WebAuthnAssertionResponseDTO struct {
+	ClientDataJSON    string `json:"clientDataJSON" validate:"required"`
+	AuthenticatorData string `json:"authenticatorData" validate:"required"`
+	Signature         string `json:"signature" validate:"required"`
+	UserHandle        string `json:"userHandle"`
+}
+
+// WebAuthnAssertionCredentialDTO adalah AuthenticationResponseJSON dari navigator.credentials.get
+type WebAuthnAssertionCredentialDTO struct {
+	ID       string                       `json:"id" validate:"required,max=1400"`
+	Type     string                       `json:"type" validate:"required,eq=public-key"`
+	Response WebAuthnAssertionResponseDTO `json:"response"`
+}
+
+// WebAuthnLoginOptionsRequestDTO digunakan untuk memulai login dengan passkey. Identifier
+// boleh kosong agar browser menawarkan passkey yang tersimpan (login tanpa username).
+type WebAuthnLoginOptionsRequestDTO struct {
+	Identifier string `json:"identifier" validate:"omitempty,max=255"`
+}
+
+// WebAuthnLoginRequestDTO digunakan untuk menyelesaikan login dengan passkey
+type WebAuthnLoginRequestDTO struct {
+	Credential WebAuthnAssertionCredentialDTO `json:"credential"`
+}
+
+// WebAuthnMFAOptionsRequestDTO digunakan untuk meminta challenge WebAuthn sebagai langkah MFA
+type WebAuthnMFAOptionsRequestDTO struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
+// WebAuthnRenameRequestDTO digunakan untuk mengganti nama kredensial
+type WebAuthnRenameRequestDTO struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+// WebAuthnCredentialResponseDTO berisi informasi kredensial WebAuthn tanpa kunci publik
+type WebAuthnCredentialResponseDTO struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	AAGUID         string     `json:"aaguid"`
+	Transports     []string   `json:"transports"`
+	BackupEligible bool       `json:"backup_eligible"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// APITokens menangani daftar (GET) dan pembuatan (POST) personal access token milik pengguna
+func (h *AuthHandler) APITokens(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		tokens, err := h.authService.ListAPITokens(r.Context(), userID)
+		if err != nil {
+			h.sendAPITokenError(w, err, "Failed to list API tokens")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "API tokens fetched successfully", tokens, nil)
+
+	case http.MethodPost:
+		var req dto.CreateAPITokenRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		token, err := h.authService.CreateAPIToken(r.Context(), userID, &req)
+		if err != nil {
+			h.sendAPITokenError(w, err, "Failed to create API token")
+			return
+		}
+		api.SendSuccess(w, http.StatusCreated, "API token created. Copy it now, it will not be shown again", token, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// APIToken menangani pencabutan (DELETE) satu personal access token milik pengguna
+func (h *AuthHandler) APIToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	if err := h.authService.RevokeAPIToken(r.Context(), userID, r.PathValue("tokenID")); err != nil {
+		h.sendAPITokenError(w, err, "Failed to revoke API token")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "API token revoked successfully", nil, nil)
+}
+
+// sendAPITokenError memetakan error pengelolaan API token dari AuthService ke respons HTTP
+func (h *AuthHandler) sendAPITokenError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	switch {
+	case isValidationError(err):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.Is(err, services.ErrAPITokenExpiryInPast):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "validation_error", nil)
+	case errors.Is(err, services.ErrAPITokenScopeNotAllowed):
+		api.SendDetailedError(w, http.StatusForbidden, err.Error(), "scope_not_allowed", nil)
+	case errors.Is(err, services.ErrAPITokenLimitReached):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "token_limit_reached", nil)
+	case errors.Is(err, services.ErrAPITokenNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
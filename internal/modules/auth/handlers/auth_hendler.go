@@ -2,15 +2,20 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
 	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
 	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+
+	"github.com/go-playground/validator/v10"
 )
 
 // AuthHandler menangani permintaan HTTP untuk otentikasi
@@ -63,9 +68,9 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ip := r.RemoteAddr
+	ip := clientIP(r)
 
-	tokenPair, err := h.authService.LoginUser(r.Context(), &req, ip)
+	tokenPair, err := h.authService.LoginUser(r.Context(), &req, ip, r.UserAgent())
     if err != nil {
         log.Printf("Gagal login pengguna: %v", err)
         
@@ -103,6 +108,12 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
     }
     
+    // Password benar tetapi login masih membutuhkan kode MFA
+    if tokenPair.MFA != nil {
+        api.SendSuccess(w, http.StatusOK, "MFA verification required", tokenPair.MFA, nil)
+        return
+    }
+
     api.SendSuccess(w, http.StatusOK, "Login successful", tokenPair, nil)
 }
 
@@ -138,9 +149,13 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenPair, err := h.authService.RefreshToken(r.Context(), req.RefreshToken)
+	tokenPair, err := h.authService.RefreshToken(r.Context(), req.RefreshToken, clientIP(r), r.UserAgent())
 	if err != nil {
 		log.Printf("Gagal refresh token: %v", err)
+		if errors.Is(err, services.ErrRefreshTokenReused) {
+			api.SendDetailedError(w, http.StatusUnauthorized, "Refresh token has already been used, the session has been revoked", "refresh_token_reused", nil)
+			return
+		}
 		api.SendError(w, http.StatusUnauthorized, "Invalid refresh token")
 		return
 	}
@@ -170,4 +185,129 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	api.SendSuccess(w, http.StatusOK, "Logout successful", nil, nil)
+}
+
+// LogoutAll menangani logout dari semua perangkat: semua token yang sudah terbit, termasuk
+// token yang sedang dipakai, langsung ditolak
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	if err := h.authService.LogoutAllSessions(r.Context(), userID); err != nil {
+		log.Printf("Gagal logout dari semua perangkat: %v", err)
+		api.SendError(w, http.StatusInternalServerError, "Failed to logout from all devices")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Logged out from all devices", nil, nil)
+}
+
+// ForgotPassword menangani permintaan tautan reset password
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.ForgotPasswordRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err := h.authService.ForgotPassword(r.Context(), &req)
+	if err != nil {
+		log.Printf("Gagal memproses permintaan reset password: %v", err)
+		if isValidationError(err) {
+			api.SendError(w, http.StatusBadRequest, "Invalid email address")
+			return
+		}
+		api.SendError(w, http.StatusInternalServerError, "Failed to process password reset request")
+		return
+	}
+
+	// Respons selalu sama agar tidak membocorkan apakah email terdaftar
+	api.SendSuccess(w, http.StatusOK, "If the email is registered, a password reset link has been sent.", nil, nil)
+}
+
+// ResetPassword menangani penggantian password menggunakan token reset
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.ResetPasswordRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err := h.authService.ResetPassword(r.Context(), &req)
+	if err != nil {
+		log.Printf("Gagal reset password: %v", err)
+		switch {
+		case isValidationError(err):
+			api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+		case errors.Is(err, services.ErrInvalidToken), errors.Is(err, services.ErrTokenAlreadyUsed):
+			api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "invalid_token", nil)
+		default:
+			api.SendError(w, http.StatusInternalServerError, "Failed to reset password")
+		}
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Password has been reset successfully, you can now login", nil, nil)
+}
+
+// ResendVerification menangani permintaan pengiriman ulang email verifikasi
+func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.ResendVerificationRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err := h.authService.ResendVerification(r.Context(), &req)
+	if err != nil {
+		log.Printf("Gagal mengirim ulang email verifikasi: %v", err)
+
+		switch {
+		case isValidationError(err):
+			api.SendError(w, http.StatusBadRequest, "Invalid email address")
+		default:
+			api.SendError(w, http.StatusInternalServerError, "Failed to resend verification email")
+		}
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "If the account is awaiting verification, a new verification email has been sent.", nil, nil)
+}
+
+// clientIP mengambil alamat IP klien dari RemoteAddr tanpa nomor port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// isValidationError memeriksa apakah error berasal dari validasi input DTO
+func isValidationError(err error) bool {
+	var validationErrs validator.ValidationErrors
+	return errors.As(err, &validationErrs)
 }
\ No newline at end of file
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// JWKS menyajikan kunci publik access token dalam format JSON Web Key Set (RFC 7517).
+// Respons tidak dibungkus format api.Response karena dibaca langsung oleh library JWT.
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Verifier boleh menyimpan JWKS sebentar. Saat rotasi, kunci baru didaftarkan dulu di
+	// JWT_VERIFICATION_KEY_FILES agar sudah ada di cache verifier sebelum dipakai menandatangani.
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	api.SendJSON(w, http.StatusOK, h.authService.PublicKeys())
+}
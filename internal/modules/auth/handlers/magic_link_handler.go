@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// magicLinkNonceCookie menyimpan nonce magic link di browser yang memintanya sehingga
+// tautan yang dibuka di browser lain ditolak
+const magicLinkNonceCookie = "magic_link_nonce"
+
+// MagicLink menangani permintaan tautan login tanpa password
+func (h *AuthHandler) MagicLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.MagicLinkRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	magicLink, err := h.authService.RequestMagicLink(r.Context(), &req)
+	if err != nil {
+		log.Printf("Gagal memproses permintaan magic link: %v", err)
+		if isValidationError(err) {
+			api.SendError(w, http.StatusBadRequest, "Invalid email address")
+			return
+		}
+		api.SendError(w, http.StatusInternalServerError, "Failed to process login link request")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     magicLinkNonceCookie,
+		Value:    magicLink.Nonce,
+		Path:     "/auth/magic-link",
+		MaxAge:   int(services.MagicLinkTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		// Lax agar cookie ikut terkirim saat tautan dibuka dari aplikasi email
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// Respons selalu sama agar tidak membocorkan apakah email terdaftar
+	api.SendSuccess(w, http.StatusOK, "If the email is registered, a login link has been sent.", magicLink, nil)
+}
+
+// MagicLinkVerify menangani login dengan magic link. GET dipakai saat tautan di email dibuka
+// langsung (?token=...), POST dipakai oleh frontend dengan body JSON. Jika nonce tidak dikirim,
+// nonce diambil dari cookie browser yang meminta tautan.
+func (h *AuthHandler) MagicLinkVerify(w http.ResponseWriter, r *http.Request) {
+	var req dto.MagicLinkLoginRequestDTO
+	switch r.Method {
+	case http.MethodGet:
+		req.Token = r.URL.Query().Get("token")
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if req.Nonce == "" {
+		if cookie, err := r.Cookie(magicLinkNonceCookie); err == nil {
+			req.Nonce = cookie.Value
+		}
+	}
+
+	tokenPair, err := h.authService.LoginWithMagicLink(r.Context(), &req, clientIP(r), r.UserAgent())
+	if err != nil {
+		h.sendMagicLinkError(w, err)
+		return
+	}
+
+	// Nonce hanya dipakai sekali
+	http.SetCookie(w, &http.Cookie{Name: magicLinkNonceCookie, Path: "/auth/magic-link", MaxAge: -1, HttpOnly: true})
+
+	if tokenPair.MFA != nil {
+		api.SendSuccess(w, http.StatusOK, "MFA verification required", tokenPair.MFA, nil)
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Login successful", tokenPair, nil)
+}
+
+// sendMagicLinkError memetakan error login magic link dari AuthService ke respons HTTP
+func (h *AuthHandler) sendMagicLinkError(w http.ResponseWriter, err error) {
+	log.Printf("Gagal login dengan magic link: %v", err)
+
+	var lockErr *services.LockoutError
+	switch {
+	case errors.As(err, &lockErr):
+		retryAfter := int(time.Until(lockErr.UnlockAt).Seconds())
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+		api.SendDetailedError(w, http.StatusTooManyRequests, lockErr.Error(), "account_locked", map[string]interface{}{
+			"unlock_at":   lockErr.UnlockAt,
+			"retry_after": retryAfter,
+		})
+	case isValidationError(err):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.Is(err, services.ErrInvalidToken), errors.Is(err, services.ErrTokenAlreadyUsed):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "invalid_token", nil)
+	case errors.Is(err, services.ErrMagicLinkBrowserMismatch):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "browser_mismatch", nil)
+	case errors.Is(err, services.ErrAccountInactive):
+		api.SendDetailedError(w, http.StatusForbidden, services.ErrAccountInactive.Error(), "account_inactive", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, "Login failed")
+	}
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// VerifyMFA menangani langkah kedua login menggunakan token tantangan MFA
+func (h *AuthHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.VerifyMFARequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tokenPair, err := h.authService.VerifyMFA(r.Context(), &req, clientIP(r), r.UserAgent())
+	if err != nil {
+		h.sendMFAError(w, err, "Failed to verify MFA")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Login successful", tokenPair, nil)
+}
+
+// EnrollMFA menangani pendaftaran TOTP saat login untuk role yang mewajibkan MFA
+func (h *AuthHandler) EnrollMFA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.EnrollMFARequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	setup, err := h.authService.EnrollMFA(r.Context(), &req)
+	if err != nil {
+		h.sendMFAError(w, err, "Failed to enroll MFA")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Scan the QR code, then verify with a code from your authenticator app", setup, nil)
+}
+
+// MFAStatus menangani pembacaan status MFA pengguna yang sedang login
+func (h *AuthHandler) MFAStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	status, err := h.authService.GetMFAStatus(r.Context(), userID)
+	if err != nil {
+		h.sendMFAError(w, err, "Failed to get MFA status")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "MFA status fetched successfully", status, nil)
+}
+
+// SetupTOTP menangani pembuatan secret TOTP untuk pengguna yang sedang login
+func (h *AuthHandler) SetupTOTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	setup, err := h.authService.SetupTOTP(r.Context(), userID)
+	if err != nil {
+		h.sendMFAError(w, err, "Failed to set up TOTP")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Scan the QR code, then confirm with a code from your authenticator app", setup, nil)
+}
+
+// ConfirmTOTP menangani konfirmasi pendaftaran TOTP dan mengembalikan kode pemulihan
+func (h *AuthHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	var req dto.MFACodeRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	codes, err := h.authService.ConfirmTOTP(r.Context(), userID, &req)
+	if err != nil {
+		h.sendMFAError(w, err, "Failed to confirm TOTP")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "MFA enabled successfully. Store the recovery codes in a safe place.", codes, nil)
+}
+
+// DisableMFA menangani penonaktifan MFA
+func (h *AuthHandler) DisableMFA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	var req dto.MFACodeRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.authService.DisableMFA(r.Context(), userID, &req); err != nil {
+		h.sendMFAError(w, err, "Failed to disable MFA")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "MFA disabled successfully", nil, nil)
+}
+
+// RegenerateRecoveryCodes menangani penggantian seluruh kode pemulihan
+func (h *AuthHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	var req dto.MFACodeRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	codes, err := h.authService.RegenerateRecoveryCodes(r.Context(), userID, &req)
+	if err != nil {
+		h.sendMFAError(w, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Recovery codes regenerated successfully", codes, nil)
+}
+
+// sendMFAError memetakan error MFA dari AuthService ke respons HTTP
+func (h *AuthHandler) sendMFAError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var lockoutErr *services.LockoutError
+	switch {
+	case isValidationError(err):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.As(err, &lockoutErr):
+		retryAfter := int(time.Until(lockoutErr.UnlockAt).Seconds())
+		details := map[string]interface{}{
+			"unlock_at":   lockoutErr.UnlockAt,
+			"retry_after": retryAfter,
+		}
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+		api.SendDetailedError(w, http.StatusTooManyRequests, lockoutErr.Error(), "account_locked", details)
+	case errors.Is(err, services.ErrInvalidMFAToken):
+		api.SendDetailedError(w, http.StatusUnauthorized, err.Error(), "invalid_mfa_token", nil)
+	case errors.Is(err, services.ErrInvalidMFACode):
+		api.SendDetailedError(w, http.StatusUnauthorized, err.Error(), "invalid_mfa_code", nil)
+	case errors.Is(err, services.ErrInvalidWebAuthnResponse), errors.Is(err, services.ErrInvalidWebAuthnChallenge):
+		api.SendDetailedError(w, http.StatusUnauthorized, err.Error(), "invalid_webauthn_response", nil)
+	case errors.Is(err, services.ErrWebAuthnUnavailable):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "webauthn_unavailable", nil)
+	case errors.Is(err, services.ErrTOTPCodeRequired):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "validation_error", nil)
+	case errors.Is(err, services.ErrMFAAlreadyEnabled), errors.Is(err, services.ErrMFANotEnabled),
+		errors.Is(err, services.ErrMFASetupMissing), errors.Is(err, services.ErrMFANotRequired),
+		errors.Is(err, services.ErrWebAuthnNotRegistered):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "mfa_state_conflict", nil)
+	case errors.Is(err, services.ErrMFARequiredByRole):
+		api.SendDetailedError(w, http.StatusForbidden, err.Error(), "mfa_required", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
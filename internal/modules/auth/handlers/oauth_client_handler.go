@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// OAuthClients menangani daftar (GET) dan pendaftaran (POST) client OAuth
+func (h *AuthHandler) OAuthClients(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		clients, err := h.authService.ListOAuthClients(r.Context())
+		if err != nil {
+			h.sendOAuthClientError(w, err, "Failed to list OAuth clients")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "OAuth clients fetched successfully", clients, nil)
+
+	case http.MethodPost:
+		userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+		if !ok {
+			api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+			return
+		}
+
+		var req dto.CreateOAuthClientRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		client, err := h.authService.CreateOAuthClient(r.Context(), userID, &req)
+		if err != nil {
+			h.sendOAuthClientError(w, err, "Failed to create OAuth client")
+			return
+		}
+		api.SendSuccess(w, http.StatusCreated, "OAuth client created. Copy the client secret now, it will not be shown again", client, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// OAuthClient menangani detail (GET) dan pencabutan (DELETE) satu client OAuth
+func (h *AuthHandler) OAuthClient(w http.ResponseWriter, r *http.Request) {
+	clientID := r.PathValue("clientID")
+
+	switch r.Method {
+	case http.MethodGet:
+		client, err := h.authService.GetOAuthClient(r.Context(), clientID)
+		if err != nil {
+			h.sendOAuthClientError(w, err, "Failed to fetch OAuth client")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "OAuth client fetched successfully", client, nil)
+
+	case http.MethodDelete:
+		if err := h.authService.RevokeOAuthClient(r.Context(), clientID); err != nil {
+			h.sendOAuthClientError(w, err, "Failed to revoke OAuth client")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "OAuth client revoked successfully", nil, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// OAuthClientSecret menangani penggantian secret client OAuth (POST)
+func (h *AuthHandler) OAuthClientSecret(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	client, err := h.authService.RotateOAuthClientSecret(r.Context(), r.PathValue("clientID"))
+	if err != nil {
+		h.sendOAuthClientError(w, err, "Failed to rotate OAuth client secret")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "OAuth client secret rotated. Copy it now, it will not be shown again", client, nil)
+}
+
+// OAuthConsents menangani daftar aplikasi yang sudah diberi akses oleh pengguna (GET)
+func (h *AuthHandler) OAuthConsents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	consents, err := h.authService.ListOAuthConsents(r.Context(), userID)
+	if err != nil {
+		h.sendOAuthClientError(w, err, "Failed to list authorized applications")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Authorized applications fetched successfully", consents, nil)
+}
+
+// OAuthConsent menangani pencabutan akses satu aplikasi oleh pengguna (DELETE)
+func (h *AuthHandler) OAuthConsent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	if err := h.authService.RevokeOAuthConsent(r.Context(), userID, r.PathValue("clientID")); err != nil {
+		h.sendOAuthClientError(w, err, "Failed to revoke application access")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Application access revoked successfully", nil, nil)
+}
+
+// sendOAuthClientError memetakan error pengelolaan client dan consent OAuth ke respons HTTP
+func (h *AuthHandler) sendOAuthClientError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	switch {
+	case isValidationError(err):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.Is(err, services.ErrInvalidOAuthClient), errors.Is(err, services.ErrInvalidRedirectURI):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "validation_error", nil)
+	case errors.Is(err, services.ErrOAuthClientNotFound), errors.Is(err, services.ErrOAuthConsentNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
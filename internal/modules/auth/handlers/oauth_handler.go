@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// oauthStateCookie menyimpan state login OAuth di browser yang memulai login sehingga
+// callback dari browser lain (login CSRF) ditolak
+const oauthStateCookie = "oauth_state"
+
+// OAuthProviders menangani daftar penyedia login OAuth yang tersedia
+func (h *AuthHandler) OAuthProviders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "OAuth providers fetched successfully", map[string][]string{
+		"providers": h.authService.OAuthProviders(),
+	}, nil)
+}
+
+// OAuthLogin memulai login OAuth dengan mengarahkan browser ke penyedia. Dengan
+// ?redirect=false URL otorisasi dikembalikan sebagai JSON, misalnya untuk SPA.
+func (h *AuthHandler) OAuthLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	login, err := h.authService.StartOAuthLogin(r.Context(), r.PathValue("provider"))
+	if err != nil {
+		h.sendOAuthError(w, err, "Failed to start OAuth login")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    login.State,
+		Path:     "/auth/oauth/",
+		MaxAge:   int(services.OAuthStateTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		// Lax agar cookie ikut terkirim pada redirect top-level dari penyedia
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	if r.URL.Query().Get("redirect") == "false" {
+		api.SendSuccess(w, http.StatusOK, "Redirect the user to the authorization URL", login, nil)
+		return
+	}
+	http.Redirect(w, r, login.AuthorizationURL, http.StatusFound)
+}
+
+// OAuthCallback menangani redirect kembali dari penyedia lalu menerbitkan token pair
+func (h *AuthHandler) OAuthCallback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		api.SendDetailedError(w, http.StatusBadRequest, "OAuth login was cancelled or denied", "oauth_denied",
+			map[string]interface{}{"error": providerErr})
+		return
+	}
+
+	// Cookie state hanya dipakai sekali
+	cookie, err := r.Cookie(oauthStateCookie)
+	http.SetCookie(w, &http.Cookie{Name: oauthStateCookie, Path: "/auth/oauth/", MaxAge: -1, HttpOnly: true})
+	if err != nil || cookie.Value == "" || cookie.Value != query.Get("state") {
+		api.SendDetailedError(w, http.StatusBadRequest, services.ErrInvalidOAuthState.Error(), "invalid_oauth_state", nil)
+		return
+	}
+
+	req := dto.OAuthCallbackRequestDTO{Code: query.Get("code"), State: query.Get("state")}
+	tokenPair, err := h.authService.CompleteOAuthLogin(r.Context(), r.PathValue("provider"), &req, clientIP(r), r.UserAgent())
+	if err != nil {
+		h.sendOAuthError(w, err, "OAuth login failed")
+		return
+	}
+
+	if tokenPair.MFA != nil {
+		api.SendSuccess(w, http.StatusOK, "MFA verification required", tokenPair.MFA, nil)
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Login successful", tokenPair, nil)
+}
+
+// sendOAuthError memetakan error login OAuth dari AuthService ke respons HTTP
+func (h *AuthHandler) sendOAuthError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	switch {
+	case errors.Is(err, services.ErrOAuthProviderNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, services.ErrOAuthProviderNotFound.Error(), "not_found", nil)
+	case errors.Is(err, services.ErrInvalidOAuthState):
+		api.SendDetailedError(w, http.StatusBadRequest, services.ErrInvalidOAuthState.Error(), "invalid_oauth_state", nil)
+	case errors.Is(err, services.ErrOAuthEmailNotVerified):
+		api.SendDetailedError(w, http.StatusForbidden, services.ErrOAuthEmailNotVerified.Error(), "email_not_verified", nil)
+	case errors.Is(err, services.ErrAccountInactive):
+		api.SendDetailedError(w, http.StatusForbidden, services.ErrAccountInactive.Error(), "account_inactive", nil)
+	case errors.Is(err, services.ErrOAuthLoginFailed):
+		// Detail error penyedia hanya dicatat di log
+		api.SendDetailedError(w, http.StatusBadGateway, services.ErrOAuthLoginFailed.Error(), "oauth_provider_error", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// OpenIDConfiguration menangani dokumen discovery OpenID Connect
+func (h *AuthHandler) OpenIDConfiguration(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	configuration, err := h.authService.OpenIDConfiguration()
+	if err != nil {
+		api.SendError(w, http.StatusNotFound, "OpenID Connect provider is not enabled")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	api.SendJSON(w, http.StatusOK, configuration)
+}
+
+// OAuth2Authorize adalah API untuk layar consent. GET memvalidasi parameter otorisasi dari
+// client dan mengembalikan data yang perlu ditampilkan; POST mencatat keputusan pengguna dan
+// mengembalikan URL redirect ke client yang harus dibuka oleh halaman consent.
+func (h *AuthHandler) OAuth2Authorize(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req := dto.OAuth2AuthorizeRequestDTO{
+			ResponseType:        query.Get("response_type"),
+			ClientID:            query.Get("client_id"),
+			RedirectURI:         query.Get("redirect_uri"),
+			Scope:               query.Get("scope"),
+			State:               query.Get("state"),
+			Nonce:               query.Get("nonce"),
+			CodeChallenge:       query.Get("code_challenge"),
+			CodeChallengeMethod: query.Get("code_challenge_method"),
+		}
+		authorization, err := h.authService.GetOAuth2Authorization(r.Context(), userID, &req)
+		if err != nil {
+			h.sendOAuth2AuthorizeError(w, err, "Failed to validate authorization request")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Authorization request is valid", authorization, nil)
+
+	case http.MethodPost:
+		var req dto.OAuth2ConsentRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		sessionID, _ := r.Context().Value(middleware.SessionIDContextKey).(string)
+		redirect, err := h.authService.DecideOAuth2Authorization(r.Context(), userID, sessionID, &req)
+		if err != nil {
+			h.sendOAuth2AuthorizeError(w, err, "Failed to complete authorization request")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Redirect the user back to the application", redirect, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// OAuth2Token menangani endpoint token. Parameter dikirim sebagai form dan kredensial client
+// lewat header Basic atau field client_id/client_secret.
+func (h *AuthHandler) OAuth2Token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	auth, err := oauth2ClientAuth(r)
+	if err != nil {
+		h.sendOAuth2Error(w, err, "Failed to issue OAuth token")
+		return
+	}
+	req := dto.OAuth2TokenRequestDTO{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+	}
+
+	tokens, err := h.authService.ExchangeOAuth2Token(r.Context(), auth, &req)
+	if err != nil {
+		h.sendOAuth2Error(w, err, "Failed to issue OAuth token")
+		return
+	}
+	sendOAuth2JSON(w, http.StatusOK, tokens)
+}
+
+// OAuth2UserInfo menangani endpoint userinfo dengan access token milik client
+func (h *AuthHandler) OAuth2UserInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if accessToken == r.Header.Get("Authorization") {
+		accessToken = ""
+	}
+
+	claims, err := h.authService.OAuth2UserInfo(r.Context(), accessToken)
+	if err != nil {
+		h.sendOAuth2Error(w, err, "Failed to fetch user info")
+		return
+	}
+	sendOAuth2JSON(w, http.StatusOK, claims)
+}
+
+// OAuth2Introspect menangani introspeksi token untuk resource server (RFC 7662)
+func (h *AuthHandler) OAuth2Introspect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	auth, err := oauth2ClientAuth(r)
+	if err != nil {
+		h.sendOAuth2Error(w, err, "Failed to introspect OAuth token")
+		return
+	}
+
+	result, err := h.authService.IntrospectOAuth2Token(r.Context(), auth, r.PostForm.Get("token"))
+	if err != nil {
+		h.sendOAuth2Error(w, err, "Failed to introspect OAuth token")
+		return
+	}
+	sendOAuth2JSON(w, http.StatusOK, result)
+}
+
+// OAuth2Revoke menangani pencabutan token oleh client (RFC 7009)
+func (h *AuthHandler) OAuth2Revoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	auth, err := oauth2ClientAuth(r)
+	if err != nil {
+		h.sendOAuth2Error(w, err, "Failed to revoke OAuth token")
+		return
+	}
+
+	if err := h.authService.RevokeOAuth2Token(r.Context(), auth, r.PostForm.Get("token")); err != nil {
+		h.sendOAuth2Error(w, err, "Failed to revoke OAuth token")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// oauth2ClientAuth membaca form dan kredensial client. Sesuai RFC 6749 bagian 2.3.1,
+// client_id dan secret pada header Basic di-encode sebagai form sehingga perlu di-decode,
+// dan client tidak boleh memakai dua metode autentikasi sekaligus.
+func oauth2ClientAuth(r *http.Request) (*dto.OAuth2ClientAuthDTO, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, &services.OAuth2Error{Code: services.OAuth2InvalidRequest, Description: "body form tidak valid"}
+	}
+
+	username, password, hasBasic := r.BasicAuth()
+	if !hasBasic {
+		return &dto.OAuth2ClientAuthDTO{
+			ClientID:     r.PostForm.Get("client_id"),
+			ClientSecret: r.PostForm.Get("client_secret"),
+		}, nil
+	}
+
+	if r.PostForm.Get("client_secret") != "" {
+		return nil, &services.OAuth2Error{Code: services.OAuth2InvalidRequest, Description: "gunakan satu metode autentikasi client"}
+	}
+	clientID, errID := url.QueryUnescape(username)
+	clientSecret, errSecret := url.QueryUnescape(password)
+	if errID != nil || errSecret != nil {
+		return nil, &services.OAuth2Error{Code: services.OAuth2InvalidClient, Description: "autentikasi client gagal"}
+	}
+	return &dto.OAuth2ClientAuthDTO{ClientID: clientID, ClientSecret: clientSecret}, nil
+}
+
+// sendOAuth2JSON mengirim respons endpoint protokol OAuth tanpa dibungkus format API CMS.
+// Respons berisi token sehingga tidak boleh di-cache.
+func sendOAuth2JSON(w http.ResponseWriter, statusCode int, response interface{}) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	api.SendJSON(w, statusCode, response)
+}
+
+// sendOAuth2Error memetakan error endpoint protokol OAuth ke format error RFC 6749 bagian 5.2
+func (h *AuthHandler) sendOAuth2Error(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var oauthErr *services.OAuth2Error
+	switch {
+	case errors.As(err, &oauthErr):
+		status := http.StatusBadRequest
+		switch oauthErr.Code {
+		case services.OAuth2InvalidClient:
+			status = http.StatusUnauthorized
+			w.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+		case services.OAuth2InvalidToken:
+			status = http.StatusUnauthorized
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		case services.OAuth2InsufficientScope:
+			status = http.StatusForbidden
+			w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="openid"`)
+		}
+		sendOAuth2JSON(w, status, dto.OAuth2ErrorResponseDTO{Error: oauthErr.Code, ErrorDescription: oauthErr.Description})
+	case errors.Is(err, services.ErrOIDCProviderDisabled):
+		api.SendError(w, http.StatusNotFound, "OpenID Connect provider is not enabled")
+	default:
+		sendOAuth2JSON(w, http.StatusInternalServerError, dto.OAuth2ErrorResponseDTO{Error: "server_error"})
+	}
+}
+
+// sendOAuth2AuthorizeError memetakan error API consent. Jika error harus dikembalikan ke
+// client, redirect_to berisi URL redirect yang harus dibuka oleh halaman consent.
+func (h *AuthHandler) sendOAuth2AuthorizeError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var oauthErr *services.OAuth2Error
+	switch {
+	case errors.As(err, &oauthErr):
+		var details map[string]interface{}
+		if oauthErr.RedirectTo != "" {
+			details = map[string]interface{}{"redirect_to": oauthErr.RedirectTo}
+		}
+		api.SendDetailedError(w, http.StatusBadRequest, oauthErr.Description, oauthErr.Code, details)
+	case errors.Is(err, services.ErrOIDCProviderDisabled):
+		api.SendError(w, http.StatusNotFound, "OpenID Connect provider is not enabled")
+	case errors.Is(err, services.ErrSessionNotFound):
+		api.SendDetailedError(w, http.StatusUnauthorized, "Your login session has ended, please log in again", "session_required", nil)
+	case errors.Is(err, services.ErrAccountInactive):
+		api.SendDetailedError(w, http.StatusForbidden, services.ErrAccountInactive.Error(), "account_inactive", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// Sessions menangani daftar sesi login (perangkat) milik pengguna yang sedang login
+func (h *AuthHandler) Sessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+	currentSessionID, _ := r.Context().Value(middleware.SessionIDContextKey).(string)
+
+	sessions, err := h.authService.ListSessions(r.Context(), userID, currentSessionID)
+	if err != nil {
+		h.sendSessionError(w, err, "Failed to list sessions")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Sessions fetched successfully", sessions, nil)
+}
+
+// Session menangani pencabutan (DELETE) satu sesi milik pengguna
+func (h *AuthHandler) Session(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	if err := h.authService.RevokeSession(r.Context(), userID, r.PathValue("sessionID")); err != nil {
+		h.sendSessionError(w, err, "Failed to revoke session")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Session revoked successfully", nil, nil)
+}
+
+// RevokeOtherSessions menangani pencabutan semua sesi selain sesi yang sedang dipakai
+func (h *AuthHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+	currentSessionID, _ := r.Context().Value(middleware.SessionIDContextKey).(string)
+
+	count, err := h.authService.RevokeOtherSessions(r.Context(), userID, currentSessionID)
+	if err != nil {
+		h.sendSessionError(w, err, "Failed to revoke other sessions")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Other sessions revoked successfully", map[string]int64{"revoked": count}, nil)
+}
+
+// sendSessionError memetakan error pengelolaan sesi dari AuthService ke respons HTTP
+func (h *AuthHandler) sendSessionError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	switch {
+	case errors.Is(err, services.ErrSessionNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	case errors.Is(err, services.ErrInvalidToken):
+		api.SendDetailedError(w, http.StatusBadRequest, "Current token is not bound to a session, please log in again", "session_required", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// WebAuthnRegisterOptions menangani pembuatan opsi navigator.credentials.create untuk
+// mendaftarkan passkey atau security key baru
+func (h *AuthHandler) WebAuthnRegisterOptions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	options, err := h.authService.BeginWebAuthnRegistration(r.Context(), userID)
+	if err != nil {
+		h.sendWebAuthnError(w, err, "Failed to start passkey registration")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Passkey registration options created", options, nil)
+}
+
+// WebAuthnRegister menangani penyimpanan kredensial hasil navigator.credentials.create
+func (h *AuthHandler) WebAuthnRegister(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	var req dto.WebAuthnRegisterRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	credential, err := h.authService.FinishWebAuthnRegistration(r.Context(), userID, &req)
+	if err != nil {
+		h.sendWebAuthnError(w, err, "Failed to register passkey")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusCreated, "Passkey registered successfully", credential, nil)
+}
+
+// WebAuthnCredentials menangani daftar passkey dan security key milik pengguna
+func (h *AuthHandler) WebAuthnCredentials(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	credentials, err := h.authService.ListWebAuthnCredentials(r.Context(), userID)
+	if err != nil {
+		h.sendWebAuthnError(w, err, "Failed to list passkeys")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Passkeys fetched successfully", credentials, nil)
+}
+
+// WebAuthnCredential menangani penggantian nama (PATCH) dan penghapusan (DELETE) satu
+// kredensial milik pengguna
+func (h *AuthHandler) WebAuthnCredential(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+	credentialID := r.PathValue("credentialID")
+
+	switch r.Method {
+	case http.MethodPatch:
+		var req dto.WebAuthnRenameRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := h.authService.RenameWebAuthnCredential(r.Context(), userID, credentialID, &req); err != nil {
+			h.sendWebAuthnError(w, err, "Failed to rename passkey")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Passkey renamed successfully", nil, nil)
+
+	case http.MethodDelete:
+		if err := h.authService.DeleteWebAuthnCredential(r.Context(), userID, credentialID); err != nil {
+			h.sendWebAuthnError(w, err, "Failed to delete passkey")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Passkey deleted successfully", nil, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// WebAuthnLoginOptions menangani pembuatan opsi navigator.credentials.get untuk login
+// tanpa password
+func (h *AuthHandler) WebAuthnLoginOptions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.WebAuthnLoginOptionsRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	options, err := h.authService.BeginWebAuthnLogin(r.Context(), &req)
+	if err != nil {
+		h.sendWebAuthnError(w, err, "Failed to start passkey login")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Passkey login options created", options, nil)
+}
+
+// WebAuthnLogin menangani login tanpa password dengan assertion passkey
+func (h *AuthHandler) WebAuthnLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.WebAuthnLoginRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tokenPair, err := h.authService.FinishWebAuthnLogin(r.Context(), &req, clientIP(r), r.UserAgent())
+	if err != nil {
+		h.sendWebAuthnError(w, err, "Login failed")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Login successful", tokenPair, nil)
+}
+
+// WebAuthnMFAOptions menangani pembuatan challenge WebAuthn untuk langkah kedua login
+// menggunakan token tantangan MFA. Assertion-nya dikirim ke /auth/mfa/verify.
+func (h *AuthHandler) WebAuthnMFAOptions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.WebAuthnMFAOptionsRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	options, err := h.authService.BeginWebAuthnMFA(r.Context(), &req)
+	if err != nil {
+		h.sendMFAError(w, err, "Failed to start passkey verification")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Passkey verification options created", options, nil)
+}
+
+// sendWebAuthnError memetakan error passkey dari AuthService ke respons HTTP
+func (h *AuthHandler) sendWebAuthnError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var lockErr *services.LockoutError
+	switch {
+	case errors.As(err, &lockErr):
+		retryAfter := int(time.Until(lockErr.UnlockAt).Seconds())
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+		api.SendDetailedError(w, http.StatusTooManyRequests, lockErr.Error(), "account_locked", map[string]interface{}{
+			"unlock_at":   lockErr.UnlockAt,
+			"retry_after": retryAfter,
+		})
+	case isValidationError(err):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.Is(err, services.ErrWebAuthnUnavailable):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "webauthn_unavailable", nil)
+	case errors.Is(err, services.ErrInvalidWebAuthnResponse), errors.Is(err, services.ErrInvalidWebAuthnChallenge):
+		// Detail kegagalan verifikasi hanya dicatat di log
+		api.SendDetailedError(w, http.StatusUnauthorized, services.ErrInvalidWebAuthnResponse.Error(), "invalid_webauthn_response", nil)
+	case errors.Is(err, services.ErrAccountInactive):
+		api.SendDetailedError(w, http.StatusForbidden, err.Error(), "account_inactive", nil)
+	case errors.Is(err, services.ErrMFARequiredByRole):
+		api.SendDetailedError(w, http.StatusForbidden, err.Error(), "mfa_required", nil)
+	case errors.Is(err, services.ErrWebAuthnCredentialNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	case errors.Is(err, services.ErrWebAuthnCredentialExists), errors.Is(err, services.ErrWebAuthnNameTaken):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "conflict", nil)
+	case errors.Is(err, services.ErrWebAuthnLimitReached):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "credential_limit_reached", nil)
+	case errors.Is(err, services.ErrInvalidToken):
+		api.SendError(w, http.StatusUnauthorized, "Unauthorized")
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
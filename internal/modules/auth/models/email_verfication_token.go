@@ -4,6 +4,22 @@ import (
 	"time"
 )
 
+// Jenis token yang disimpan di tabel 'email_verification_tokens'
+const (
+	TokenTypeEmailVerification = "email_verification"
+	TokenTypePasswordReset     = "password_reset"
+	TokenTypeMagicLink         = "magic_link"
+)
+
+// TokenIssueStats merangkum token dengan jenis tertentu yang diterbitkan untuk sebuah email
+// dalam suatu rentang waktu
+type TokenIssueStats struct {
+	Count int
+	// FirstIssuedAt dan LastIssuedAt bernilai nil jika belum ada token yang diterbitkan
+	FirstIssuedAt *time.Time
+	LastIssuedAt  *time.Time
+}
+
 // EmailVerificationToken merepresentasikan tabel 'email_verification_tokens' di database
 type EmailVerificationToken struct {
 	ID        string     `json:"id"`
@@ -13,6 +29,8 @@ type EmailVerificationToken struct {
 	TokenType string     `json:"token_type"`
 	ExpiresAt time.Time  `json:"expires_at"`
 	UsedAt    *time.Time `json:"used_at"`
+	// NonceHash mengikat magic link ke browser yang memintanya
+	NonceHash *string    `json:"-"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 }
\ No newline at end of file
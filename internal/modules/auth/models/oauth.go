@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Nilai kolom users.registration_method
+const (
+	RegistrationMethodManual = "manual"
+	RegistrationMethodOAuth  = "oauth"
+	RegistrationMethodAdmin  = "admin"
+)
+
+// UserIdentity merepresentasikan tabel user_identities, yaitu akun penyedia OAuth/OIDC
+// yang terhubung ke pengguna
+type UserIdentity struct {
+	ID          string    `json:"id" db:"id"`
+	UserID      string    `json:"user_id" db:"user_id"`
+	Provider    string    `json:"provider" db:"provider"`
+	Subject     string    `json:"subject" db:"subject"`
+	Email       *string   `json:"email" db:"email"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at" db:"last_login_at"`
+}
+
+// OAuthLoginState merepresentasikan tabel oauth_login_states, yaitu satu percobaan login
+// OAuth yang menunggu callback dari penyedia
+type OAuthLoginState struct {
+	State        string    `db:"state"`
+	Provider     string    `db:"provider"`
+	Nonce        string    `db:"nonce"`
+	CodeVerifier string    `db:"code_verifier"`
+	CreatedAt    time.Time `db:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
@@ -0,0 +1,128 @@
+package models
+
+import "time"
+
+// Grant type OAuth 2.0 yang didukung ketika CMS bertindak sebagai penyedia OIDC
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+)
+
+// Scope OpenID Connect yang memberi akses ke data pengguna
+const (
+	ScopeOpenID        = "openid"
+	ScopeProfile       = "profile"
+	ScopeEmail         = "email"
+	ScopeOfflineAccess = "offline_access"
+)
+
+// Nilai kolom oauth_tokens.token_type
+const (
+	OAuthTokenTypeAccess  = "access"
+	OAuthTokenTypeRefresh = "refresh"
+)
+
+// OAuthClient merepresentasikan tabel oauth_clients, yaitu aplikasi lain yang login
+// dengan akun CMS. SecretHash nil berarti public client.
+type OAuthClient struct {
+	ID           string     `json:"id" db:"id"`
+	Name         string     `json:"name" db:"name"`
+	SecretHash   *string    `json:"-" db:"secret_hash"`
+	RedirectURIs []string   `json:"redirect_uris" db:"redirect_uris"`
+	GrantTypes   []string   `json:"grant_types" db:"grant_types"`
+	Scopes       []string   `json:"scopes" db:"scopes"`
+	CreatedBy    *string    `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// Public melaporkan apakah client tidak memiliki secret, misalnya SPA atau aplikasi mobile
+func (c *OAuthClient) Public() bool {
+	return c.SecretHash == nil
+}
+
+// AllowsGrant memeriksa apakah client boleh memakai grant type tertentu
+func (c *OAuthClient) AllowsGrant(grantType string) bool {
+	return containsValue(c.GrantTypes, grantType)
+}
+
+// AllowsRedirectURI memeriksa redirect URI dengan pencocokan persis
+func (c *OAuthClient) AllowsRedirectURI(redirectURI string) bool {
+	return containsValue(c.RedirectURIs, redirectURI)
+}
+
+// AllowsScope memeriksa apakah scope terdaftar untuk client
+func (c *OAuthClient) AllowsScope(scope string) bool {
+	return containsValue(c.Scopes, scope)
+}
+
+// OAuthAuthorizationCode merepresentasikan tabel oauth_authorization_codes
+type OAuthAuthorizationCode struct {
+	CodeHash      string    `db:"code_hash"`
+	ClientID      string    `db:"client_id"`
+	UserID        string    `db:"user_id"`
+	RedirectURI   string    `db:"redirect_uri"`
+	Scopes        []string  `db:"scopes"`
+	Nonce         *string   `db:"nonce"`
+	CodeChallenge string    `db:"code_challenge"`
+	AuthTime      time.Time `db:"auth_time"`
+	CreatedAt     time.Time `db:"created_at"`
+	ExpiresAt     time.Time `db:"expires_at"`
+}
+
+// OAuthConsent merepresentasikan tabel oauth_consents. ClientName diisi dari oauth_clients.
+type OAuthConsent struct {
+	UserID     string    `json:"user_id" db:"user_id"`
+	ClientID   string    `json:"client_id" db:"client_id"`
+	ClientName string    `json:"client_name" db:"client_name"`
+	Scopes     []string  `json:"scopes" db:"scopes"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Covers memeriksa apakah semua scope sudah pernah disetujui
+func (c *OAuthConsent) Covers(scopes []string) bool {
+	for _, scope := range scopes {
+		if !containsValue(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// OAuthToken merepresentasikan tabel oauth_tokens, yaitu access token atau refresh token
+// opaque milik client. UserID nil untuk token client credentials.
+type OAuthToken struct {
+	ID        string     `db:"id"`
+	GrantID   string     `db:"grant_id"`
+	TokenType string     `db:"token_type"`
+	TokenHash string     `db:"token_hash"`
+	ClientID  string     `db:"client_id"`
+	UserID    *string    `db:"user_id"`
+	Scopes    []string   `db:"scopes"`
+	AuthTime  *time.Time `db:"auth_time"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+// Active melaporkan apakah token belum dicabut dan belum kedaluwarsa
+func (t *OAuthToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && t.ExpiresAt.After(now)
+}
+
+// HasScope memeriksa apakah token membawa scope tertentu
+func (t *OAuthToken) HasScope(scope string) bool {
+	return containsValue(t.Scopes, scope)
+}
+
+func containsValue(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
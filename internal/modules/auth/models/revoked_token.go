@@ -2,10 +2,18 @@ package models
 
 import "time"
 
-// RevokedToken merepresentasikan token yang telah dicabut di database
+// RevokedToken merepresentasikan token yang telah dicabut di database.
+// TokenID berisi klaim jti dari access token atau refresh token.
 type RevokedToken struct {
 	ID        string    `json:"id"`
-	TokenId     string    `json:"token_id"`
+	TokenID   string    `json:"token_id"`
 	RevokedAt time.Time `json:"revoked_at"`
 	ExpiresAt time.Time `json:"expires_at"`
-}
\ No newline at end of file
+}
+
+// UserTokenEpoch berisi epoch token pengguna (users.tokens_valid_after). Semua token milik
+// pengguna yang terbit sebelum atau pada ValidAfter dianggap dicabut.
+type UserTokenEpoch struct {
+	UserID     string    `json:"user_id"`
+	ValidAfter time.Time `json:"valid_after"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Alasan pencabutan sesi yang disimpan di kolom revoked_reason
+const (
+	SessionRevokedLogout        = "logout"
+	SessionRevokedLogoutAll     = "logout_all"
+	SessionRevokedByUser        = "revoked_by_user"
+	SessionRevokedTokenReuse    = "refresh_token_reuse"
+	SessionRevokedPasswordReset = "password_reset"
+	// SessionRevokedByAdmin dipakai ketika admin menangguhkan atau memblokir akun
+	SessionRevokedByAdmin = "revoked_by_admin"
+)
+
+// Session merepresentasikan tabel sessions, yaitu satu keluarga refresh token per perangkat
+type Session struct {
+	ID             string     `json:"id" db:"id"`
+	UserID         string     `json:"user_id" db:"user_id"`
+	RefreshTokenID string     `json:"-" db:"refresh_token_id"`
+	UserAgent      *string    `json:"user_agent" db:"user_agent"`
+	IPAddress      *string    `json:"ip_address" db:"ip_address"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt     time.Time  `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevokedReason  *string    `json:"revoked_reason,omitempty" db:"revoked_reason"`
+}
+
+// Active melaporkan apakah sesi belum dicabut dan belum kedaluwarsa
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(now)
+}
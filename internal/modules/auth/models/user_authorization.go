@@ -0,0 +1,11 @@
+package models
+
+// UserAuthorization berisi role, permission, dan versi otorisasi pengguna
+// yang disalin ke klaim access token
+type UserAuthorization struct {
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+	Version     int64    `json:"version"`
+	// MFARequired bernilai true jika salah satu role pengguna mewajibkan MFA
+	MFARequired bool `json:"mfa_required"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Jenis ceremony WebAuthn yang challenge-nya disimpan di tabel webauthn_challenges
+const (
+	WebAuthnCeremonyRegistration = "registration"
+	// WebAuthnCeremonyLogin adalah login tanpa password dengan passkey
+	WebAuthnCeremonyLogin = "login"
+	// WebAuthnCeremonyMFA adalah langkah kedua setelah login dengan password
+	WebAuthnCeremonyMFA = "mfa"
+)
+
+// WebAuthnCredential merepresentasikan tabel webauthn_credentials
+type WebAuthnCredential struct {
+	ID             string     `json:"id" db:"id"`
+	UserID         string     `json:"user_id" db:"user_id"`
+	Name           string     `json:"name" db:"name"`
+	CredentialID   string     `json:"credential_id" db:"credential_id"`
+	PublicKey      []byte     `json:"-" db:"public_key"`
+	SignCount      int64      `json:"-" db:"sign_count"`
+	AAGUID         string     `json:"aaguid" db:"aaguid"`
+	Transports     []string   `json:"transports" db:"transports"`
+	BackupEligible bool       `json:"backup_eligible" db:"backup_eligible"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at" db:"last_used_at"`
+}
+
+// WebAuthnChallenge merepresentasikan tabel webauthn_challenges
+type WebAuthnChallenge struct {
+	ChallengeHash string    `json:"-" db:"challenge_hash"`
+	UserID        *string   `json:"user_id" db:"user_id"`
+	Ceremony      string    `json:"ceremony" db:"ceremony"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt     time.Time `json:"expires_at" db:"expires_at"`
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+
+	"github.com/lib/pq"
+)
+
+const apiTokenColumns = `
+	id, user_id, name, token_prefix, token_hash, scopes,
+	expires_at, last_used_at, last_used_ip, created_at, revoked_at
+`
+
+// SaveAPIToken menyimpan personal access token baru
+func (r *AuthRepository) SaveAPIToken(ctx context.Context, token *models.APIToken) error {
+	query := `
+		INSERT INTO api_tokens (id, user_id, name, token_prefix, token_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Name,
+		token.TokenPrefix,
+		token.TokenHash,
+		pq.Array(token.Scopes),
+		token.ExpiresAt,
+	).Scan(&token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan API token: %w", err)
+	}
+	return nil
+}
+
+// CountActiveAPITokens menghitung token pengguna yang belum dicabut dan belum kedaluwarsa
+func (r *AuthRepository) CountActiveAPITokens(ctx context.Context, userID string) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM api_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+	`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("gagal menghitung API token: %w", err)
+	}
+	return count, nil
+}
+
+// ListAPITokens mengambil token pengguna yang belum dicabut, termasuk yang sudah kedaluwarsa,
+// diurutkan dari yang terbaru
+func (r *AuthRepository) ListAPITokens(ctx context.Context, userID string) ([]models.APIToken, error) {
+	query := `
+		SELECT ` + apiTokenColumns + `
+		FROM api_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar API token: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []models.APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca API token: %w", err)
+		}
+		tokens = append(tokens, *token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar API token: %w", err)
+	}
+	return tokens, nil
+}
+
+// FindAPITokenByHash mengambil token berdasarkan hash-nya. Token milik pengguna yang tidak
+// aktif diperlakukan seperti tidak ada sehingga akun yang dinonaktifkan tidak dapat memakainya.
+func (r *AuthRepository) FindAPITokenByHash(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	query := `
+		SELECT ` + apiTokenColumns + `
+		FROM api_tokens t
+		WHERE t.token_hash = $1
+			AND EXISTS (SELECT 1 FROM users u WHERE u.id = t.user_id AND u.status = 'active')
+	`
+	token, err := scanAPIToken(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil API token: %w", err)
+	}
+	return token, nil
+}
+
+// TouchAPIToken mencatat waktu dan IP terakhir token dipakai. Pembaruan dibatasi paling
+// sering sekali per menit agar permintaan beruntun tidak menulis ke database setiap kali.
+func (r *AuthRepository) TouchAPIToken(ctx context.Context, tokenID string, ip *string) error {
+	query := `
+		UPDATE api_tokens SET last_used_at = NOW(), last_used_ip = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`
+	if _, err := r.db.ExecContext(ctx, query, tokenID, ip); err != nil {
+		return fmt.Errorf("gagal memperbarui pemakaian API token: %w", err)
+	}
+	return nil
+}
+
+// RevokeAPIToken mencabut satu token milik pengguna. Hasil false berarti token tidak
+// ditemukan atau sudah dicabut sebelumnya.
+func (r *AuthRepository) RevokeAPIToken(ctx context.Context, userID, tokenID string) (bool, error) {
+	query := `
+		UPDATE api_tokens SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, tokenID, userID)
+	if err != nil {
+		return false, fmt.Errorf("gagal mencabut API token: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func scanAPIToken(row rowScanner) (*models.APIToken, error) {
+	token := &models.APIToken{}
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.TokenPrefix,
+		&token.TokenHash,
+		pq.Array(&token.Scopes),
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+		&token.LastUsedIP,
+		&token.CreatedAt,
+		&token.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
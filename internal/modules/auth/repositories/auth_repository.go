@@ -3,28 +3,98 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
 	profiles "github.com/jokosaputro95/cms-go/internal/modules/profile/models"
+	roles "github.com/jokosaputro95/cms-go/internal/modules/role/models"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// ErrTokenNotUsable dikembalikan ketika token sudah dipakai atau tidak lagi cocok dengan pengguna
+var ErrTokenNotUsable = errors.New("token tidak dapat digunakan")
+
+// ErrMFAStateChanged dikembalikan ketika status MFA pengguna berubah di tengah operasi
+var ErrMFAStateChanged = errors.New("status MFA pengguna sudah berubah")
+
 // AuthRepositoryInterface mendefinisikan kontrak untuk interaksi database otentikasi
 type AuthRepositoryInterface interface {
 	SaveUser(ctx context.Context, user *models.User, profile *profiles.UserProfile) error
 	FindUserByEmail(ctx context.Context, email string) (*models.User, error)
 	FindUserByUsername(ctx context.Context, username string) (*models.User, error)
+	FindUserByID(ctx context.Context, userID string) (*models.User, error)
+	FindUserAuthorization(ctx context.Context, userID string) (*models.UserAuthorization, error)
+	GetAuthzVersion(ctx context.Context, userID string) (int64, error)
 	SaveVerificationToken(ctx context.Context, token *models.EmailVerificationToken) error
 	FindVerificationToken(ctx context.Context, tokenID string) (*models.EmailVerificationToken, error)
 	UpdateUserStatus(ctx context.Context, userID string, tokenStr string) error
+	UseVerificationToken(ctx context.Context, tokenStr string) (bool, error)
+	IssueVerificationToken(ctx context.Context, token *models.EmailVerificationToken, limit *TokenIssueLimit) (*models.TokenIssueStats, bool, error)
+	ResetPassword(ctx context.Context, userID, tokenStr, passwordHash string) error
+	ClearUserPassword(ctx context.Context, userID string, before time.Time) error
 	UpdateUserLoginStatus(ctx context.Context, userID string, ip string, failedAttempts int, lockUntil *time.Time) error
-	RevokeToken(ctx context.Context, token string, expiresAt time.Time) error
-	IsTokenRevoked(ctx context.Context, token string) (bool, error)
-	FindTokenByUserID(ctx context.Context, userID string) (*models.RevokedToken, error)
-	FindTokenByID(ctx context.Context, tokenID string) (*models.RevokedToken, error)
+	RevokeToken(ctx context.Context, token *models.RevokedToken) error
+	IsTokenRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error)
+	RevokeUserTokensBefore(ctx context.Context, userID string, before time.Time, reason string) error
+	PurgeExpiredRevokedTokens(ctx context.Context, now time.Time, limit int) (int64, error)
+	ListRevokedTokensSince(ctx context.Context, since, now time.Time) ([]models.RevokedToken, error)
+	ListUserTokenEpochsSince(ctx context.Context, since, notBefore time.Time) ([]models.UserTokenEpoch, error)
+	FindUserMFA(ctx context.Context, userID string) (*models.UserMFA, error)
+	SavePendingMFASecret(ctx context.Context, userID, secret string) (bool, error)
+	ConfirmUserMFA(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error
+	ConsumeTOTPStep(ctx context.Context, userID string, step int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, recoveryCodeHashes []string) error
+	DeleteUserMFA(ctx context.Context, userID string) error
+	SaveSession(ctx context.Context, session *models.Session) error
+	FindSessionByID(ctx context.Context, sessionID string) (*models.Session, error)
+	RotateSession(ctx context.Context, sessionID, oldRefreshTokenID, newRefreshTokenID string, ip, userAgent *string, expiresAt time.Time) (bool, error)
+	ListActiveSessions(ctx context.Context, userID string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID, reason string) (bool, error)
+	RevokeOtherSessions(ctx context.Context, userID, keepSessionID, reason string) (int64, error)
+	SaveAPIToken(ctx context.Context, token *models.APIToken) error
+	CountActiveAPITokens(ctx context.Context, userID string) (int, error)
+	ListAPITokens(ctx context.Context, userID string) ([]models.APIToken, error)
+	FindAPITokenByHash(ctx context.Context, tokenHash string) (*models.APIToken, error)
+	TouchAPIToken(ctx context.Context, tokenID string, ip *string) error
+	RevokeAPIToken(ctx context.Context, userID, tokenID string) (bool, error)
+	SaveOAuthLoginState(ctx context.Context, state *models.OAuthLoginState) error
+	ConsumeOAuthLoginState(ctx context.Context, state, provider string) (*models.OAuthLoginState, error)
+	FindUserIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	LinkUserIdentity(ctx context.Context, identity *models.UserIdentity, claimUnverifiedUser bool) error
+	TouchUserIdentity(ctx context.Context, identityID string, email *string) error
+	SaveOAuthClient(ctx context.Context, client *models.OAuthClient) error
+	FindOAuthClient(ctx context.Context, clientID string) (*models.OAuthClient, error)
+	ListOAuthClients(ctx context.Context) ([]models.OAuthClient, error)
+	UpdateOAuthClientSecret(ctx context.Context, clientID, secretHash string) (bool, error)
+	RevokeOAuthClient(ctx context.Context, clientID string) (bool, error)
+	SaveOAuthAuthorizationCode(ctx context.Context, code *models.OAuthAuthorizationCode) error
+	ConsumeOAuthAuthorizationCode(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error)
+	FindOAuthConsent(ctx context.Context, userID, clientID string) (*models.OAuthConsent, error)
+	SaveOAuthConsent(ctx context.Context, userID, clientID string, scopes []string) error
+	ListOAuthConsents(ctx context.Context, userID string) ([]models.OAuthConsent, error)
+	RevokeOAuthConsent(ctx context.Context, userID, clientID string) (bool, error)
+	SaveOAuthTokens(ctx context.Context, tokens ...*models.OAuthToken) error
+	RotateOAuthRefreshToken(ctx context.Context, oldTokenID string, tokens ...*models.OAuthToken) (bool, error)
+	FindOAuthTokenByHash(ctx context.Context, tokenHash string) (*models.OAuthToken, error)
+	RevokeOAuthToken(ctx context.Context, tokenID string) error
+	RevokeOAuthGrant(ctx context.Context, grantID string) error
+	PurgeExpiredOAuthTokens(ctx context.Context, now time.Time, limit int) (int64, error)
+	FindUserProfile(ctx context.Context, userID string) (*profiles.UserProfile, error)
+	SaveWebAuthnChallenge(ctx context.Context, challenge *models.WebAuthnChallenge) error
+	ConsumeWebAuthnChallenge(ctx context.Context, challengeHash, ceremony string) (*models.WebAuthnChallenge, error)
+	SaveWebAuthnCredential(ctx context.Context, credential *models.WebAuthnCredential) error
+	CountWebAuthnCredentials(ctx context.Context, userID string) (int, error)
+	ListWebAuthnCredentials(ctx context.Context, userID string) ([]models.WebAuthnCredential, error)
+	FindWebAuthnCredential(ctx context.Context, credentialID string) (*models.WebAuthnCredential, error)
+	UpdateWebAuthnSignCount(ctx context.Context, id string, signCount int64) (bool, error)
+	RenameWebAuthnCredential(ctx context.Context, userID, id, name string) (bool, error)
+	DeleteWebAuthnCredential(ctx context.Context, userID, id string) (bool, error)
 }
 
 // AuthRepository adalah implementasi dari AuthRepositoryInterface
@@ -49,13 +119,23 @@ func (r *AuthRepository) SaveUser(ctx context.Context, user *models.User, profil
 	user.ID = uuid.New().String()
 	user.CreatedAt = time.Now().UTC()
 	user.UpdatedAt = user.CreatedAt
-	user.Status = "pending"
-	user.RegistrationMethod = "manual"
+	// Registrasi manual selalu dimulai sebagai pending; pengguna dari login OAuth sudah
+	// membawa status aktif dan email terverifikasi dari penyedia
+	if user.RegistrationMethod == "" {
+		user.RegistrationMethod = models.RegistrationMethodManual
+	}
+	if user.Status == "" {
+		user.Status = "pending"
+	}
+	if user.EmailVerified && user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &user.CreatedAt
+	}
 
 	userQuery := `
 		INSERT INTO users (
-			id, username, email, password_hash, registration_method, status, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			id, username, email, password_hash, registration_method, oauth_provider, status,
+			email_verified, email_verified_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 	_, err = tx.ExecContext(ctx, userQuery,
 		user.ID,
@@ -63,7 +143,10 @@ func (r *AuthRepository) SaveUser(ctx context.Context, user *models.User, profil
 		user.Email,
 		user.PasswordHash,
 		user.RegistrationMethod,
+		user.OAuthProvider,
 		user.Status,
+		user.EmailVerified,
+		user.EmailVerifiedAt,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -71,6 +154,14 @@ func (r *AuthRepository) SaveUser(ctx context.Context, user *models.User, profil
 		return fmt.Errorf("gagal menyimpan pengguna: %w", err)
 	}
 
+	// Profil selalu terhubung ke pengguna yang baru dibuat
+	if profile.ID == "" {
+		profile.ID = uuid.New().String()
+	}
+	profile.UserID = user.ID
+	profile.CreatedAt = user.CreatedAt
+	profile.UpdatedAt = user.CreatedAt
+
 	var nilString *string
     var nilTime *time.Time
     var country = "Indonesia"
@@ -106,6 +197,16 @@ func (r *AuthRepository) SaveUser(ctx context.Context, user *models.User, profil
         return fmt.Errorf("gagal menyimpan profil pengguna: %w", err)
     }
 
+	// Berikan role bawaan. Jika role belum di-seed, tidak ada baris yang ditambahkan.
+	roleQuery := `
+		INSERT INTO user_roles (id, user_id, role_id)
+		SELECT $1, $2, id FROM roles WHERE name = $3
+	`
+	_, err = tx.ExecContext(ctx, roleQuery, uuid.New().String(), user.ID, roles.DefaultRoleName)
+	if err != nil {
+		return fmt.Errorf("gagal memberikan role bawaan: %w", err)
+	}
+
     return tx.Commit()
 }
 
@@ -165,12 +266,95 @@ func (r *AuthRepository) FindUserByUsername(ctx context.Context, username string
 	return user, nil
 }
 
+// FindUserByID mencari pengguna berdasarkan ID
+func (r *AuthRepository) FindUserByID(ctx context.Context, userID string) (*models.User, error) {
+	query := `SELECT id, username, email, password_hash, registration_method, status, email_verified, email_verified_at, failed_login_attempts, locked_until, current_login_ip, created_at, updated_at FROM users WHERE id = $1`
+	user := &models.User{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.PasswordHash,
+		&user.RegistrationMethod,
+		&user.Status,
+		&user.EmailVerified,
+		&user.EmailVerifiedAt,
+		&user.FailedLoginAttempts,
+		&user.LockedUntil,
+		&user.CurrentLoginIP,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari pengguna berdasarkan ID: %w", err)
+	}
+	return user, nil
+}
+
+// FindUserAuthorization mengambil role, permission, dan versi otorisasi pengguna
+func (r *AuthRepository) FindUserAuthorization(ctx context.Context, userID string) (*models.UserAuthorization, error) {
+	query := `
+		SELECT
+			u.authz_version,
+			COALESCE(ARRAY(
+				SELECT DISTINCT r.name FROM user_roles ur
+				JOIN roles r ON r.id = ur.role_id
+				WHERE ur.user_id = u.id
+				ORDER BY r.name
+			), '{}'),
+			COALESCE(ARRAY(
+				SELECT DISTINCT p.name FROM user_roles ur
+				JOIN role_permissions rp ON rp.role_id = ur.role_id
+				JOIN permissions p ON p.id = rp.permission_id
+				WHERE ur.user_id = u.id
+				ORDER BY p.name
+			), '{}'),
+			EXISTS(
+				SELECT 1 FROM user_roles ur
+				JOIN roles r ON r.id = ur.role_id
+				WHERE ur.user_id = u.id AND r.require_mfa
+			)
+		FROM users u
+		WHERE u.id = $1
+	`
+	authz := &models.UserAuthorization{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&authz.Version,
+		pq.Array(&authz.Roles),
+		pq.Array(&authz.Permissions),
+		&authz.MFARequired,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil otorisasi pengguna: %w", err)
+	}
+	return authz, nil
+}
+
+// GetAuthzVersion mengambil versi otorisasi terbaru milik pengguna
+func (r *AuthRepository) GetAuthzVersion(ctx context.Context, userID string) (int64, error) {
+	query := `SELECT authz_version FROM users WHERE id = $1`
+	var version int64
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&version); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("gagal mengambil versi otorisasi pengguna: %w", err)
+	}
+	return version, nil
+}
+
 // SaveVerificationToken menyimpan token verifikasi email baru
 func (r *AuthRepository) SaveVerificationToken(ctx context.Context, token *models.EmailVerificationToken) error {
 	query := `
 		INSERT INTO email_verification_tokens 
-		(id, user_id, email, token, token_type, expires_at) 
-		VALUES ($1, $2, $3, $4, $5, $6)
+		(id, user_id, email, token, token_type, expires_at, nonce_hash) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		token.ID,
@@ -179,6 +363,7 @@ func (r *AuthRepository) SaveVerificationToken(ctx context.Context, token *model
 		token.Token, 
 		token.TokenType, 
 		token.ExpiresAt,
+		token.NonceHash,
 	)
 	if err != nil {
 		return fmt.Errorf("gagal menyimpan token verifikasi: %w", err)
@@ -189,7 +374,7 @@ func (r *AuthRepository) SaveVerificationToken(ctx context.Context, token *model
 // FindVerificationToken mencari token verifikasi berdasarkan tokenID
 func (r *AuthRepository) FindVerificationToken(ctx context.Context, tokenStr string) (*models.EmailVerificationToken, error) {
 	query := `
-		SELECT user_id, email, token, token_type, expires_at, used_at
+		SELECT user_id, email, token, token_type, expires_at, used_at, nonce_hash
 		FROM email_verification_tokens
 		WHERE token = $1
 		LIMIT 1
@@ -202,6 +387,7 @@ func (r *AuthRepository) FindVerificationToken(ctx context.Context, tokenStr str
 		&token.TokenType,
 		&token.ExpiresAt,
 		&token.UsedAt,
+		&token.NonceHash,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -212,6 +398,46 @@ func (r *AuthRepository) FindVerificationToken(ctx context.Context, tokenStr str
 	return token, nil
 }
 
+// UseVerificationToken menandai token sudah dipakai jika belum dipakai dan belum kedaluwarsa.
+// Mengembalikan false jika token sudah dipakai lebih dulu, misalnya oleh permintaan paralel.
+func (r *AuthRepository) UseVerificationToken(ctx context.Context, tokenStr string) (bool, error) {
+	query := `
+		UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE token = $1 AND used_at IS NULL AND expires_at > NOW()
+	`
+	res, err := r.db.ExecContext(ctx, query, tokenStr)
+	if err != nil {
+		return false, fmt.Errorf("gagal menandai token sebagai sudah digunakan: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ActivateUser mengaktifkan pengguna dan menandai email-nya terverifikasi tanpa token verifikasi
+func (r *AuthRepository) ActivateUser(ctx context.Context, userID string) error {
+	query := `
+		UPDATE users
+		SET status = 'active', email_verified = true, email_verified_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`
+	res, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("gagal mengaktifkan pengguna: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("pengguna tidak ditemukan dengan ID: %s", userID)
+	}
+	return nil
+}
+
 // UpdateUserStatus memperbarui status pengguna setelah verifikasi email
 func (r *AuthRepository) UpdateUserStatus(ctx context.Context, userID, tokenStr string) error {
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -258,6 +484,213 @@ func (r *AuthRepository) UpdateUserStatus(ctx context.Context, userID, tokenStr
 	return nil
 }
 
+// TokenIssueLimit membatasi penerbitan token email per alamat email: jeda minimal antar token
+// dan jumlah token maksimal dalam 24 jam terakhir
+type TokenIssueLimit struct {
+	Cooldown  time.Duration
+	MaxPerDay int
+}
+
+// IssueVerificationToken menginvalidasi token sejenis milik pengguna yang belum dipakai lalu
+// menyimpan token baru. Jika limit diisi, riwayat penerbitan token sejenis untuk email tersebut
+// diperiksa dalam transaksi yang sama di bawah advisory lock per email, sehingga permintaan
+// paralel tidak dapat melewati cooldown maupun batas harian. Hasil false berarti token tidak
+// disimpan karena dibatasi; statistik yang dikembalikan dipakai untuk menghitung waktu coba lagi.
+func (r *AuthRepository) IssueVerificationToken(ctx context.Context, token *models.EmailVerificationToken, limit *TokenIssueLimit) (*models.TokenIssueStats, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, token.Email); err != nil {
+		return nil, false, fmt.Errorf("gagal mengunci penerbitan token email: %w", err)
+	}
+
+	now := time.Now()
+	statsQuery := `
+		SELECT COUNT(*), MIN(created_at), MAX(created_at)
+		FROM email_verification_tokens
+		WHERE email = $1 AND token_type = $2 AND created_at >= $3
+	`
+	stats := &models.TokenIssueStats{}
+	var firstIssuedAt, lastIssuedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, statsQuery, token.Email, token.TokenType, now.Add(-24*time.Hour)).Scan(&stats.Count, &firstIssuedAt, &lastIssuedAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal menghitung token yang diterbitkan: %w", err)
+	}
+	if firstIssuedAt.Valid {
+		stats.FirstIssuedAt = &firstIssuedAt.Time
+	}
+	if lastIssuedAt.Valid {
+		stats.LastIssuedAt = &lastIssuedAt.Time
+	}
+
+	if limit != nil {
+		inCooldown := stats.LastIssuedAt != nil && now.Sub(*stats.LastIssuedAt) < limit.Cooldown
+		if inCooldown || stats.Count >= limit.MaxPerDay {
+			return stats, false, nil
+		}
+	}
+
+	invalidateQuery := `
+		UPDATE email_verification_tokens
+		SET expires_at = NOW()
+		WHERE user_id = $1 AND token_type = $2 AND used_at IS NULL AND expires_at > NOW()
+	`
+	if _, err := tx.ExecContext(ctx, invalidateQuery, token.UserID, token.TokenType); err != nil {
+		return nil, false, fmt.Errorf("gagal menginvalidasi token pengguna: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO email_verification_tokens
+		(id, user_id, email, token, token_type, expires_at, nonce_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err = tx.ExecContext(ctx, insertQuery,
+		token.ID,
+		token.UserID,
+		token.Email,
+		token.Token,
+		token.TokenType,
+		token.ExpiresAt,
+		token.NonceHash,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal menyimpan token verifikasi: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return stats, true, nil
+}
+
+// ResetPassword mengganti password pengguna, membuka kunci akun, menandai token reset
+// sebagai sudah digunakan, dan mencabut semua token lain milik pengguna dalam satu transaksi
+func (r *AuthRepository) ResetPassword(ctx context.Context, userID, tokenStr, passwordHash string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Query 1: Tandai token sebagai sudah digunakan. Kondisi used_at IS NULL
+	// memastikan token hanya bisa dipakai sekali meskipun ada permintaan bersamaan,
+	// dan token yang kedaluwarsa tidak dapat dipakai.
+	tokenQuery := `
+		UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE token = $1 AND user_id = $2 AND token_type = $3 AND used_at IS NULL AND expires_at > NOW()
+	`
+	res, err := tx.ExecContext(ctx, tokenQuery, tokenStr, userID, models.TokenTypePasswordReset)
+	if err != nil {
+		return fmt.Errorf("gagal menandai token sebagai sudah digunakan: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrTokenNotUsable
+	}
+
+	// Query 2: Simpan password baru dan reset status penguncian akun. Epoch token tidak
+	// pernah dimundurkan.
+	userQuery := `
+		UPDATE users
+		SET password_hash = $1, failed_login_attempts = 0, locked_until = NULL,
+			tokens_valid_after = GREATEST(COALESCE(tokens_valid_after, date_trunc('second', NOW())), date_trunc('second', NOW()))
+		WHERE id = $2
+	`
+	res, err = tx.ExecContext(ctx, userQuery, passwordHash, userID)
+	if err != nil {
+		return fmt.Errorf("gagal memperbarui password pengguna: %w", err)
+	}
+	rowsAffected, err = res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("pengguna tidak ditemukan dengan ID: %s", userID)
+	}
+
+	// Query 3: Cabut semua token lain milik pengguna yang masih berlaku
+	revokeQuery := `
+		UPDATE email_verification_tokens
+		SET expires_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL AND expires_at > NOW()
+	`
+	if _, err = tx.ExecContext(ctx, revokeQuery, userID); err != nil {
+		return fmt.Errorf("gagal mencabut token pengguna: %w", err)
+	}
+
+	// Query 4: Akhiri semua sesi login sehingga refresh token lama tidak dapat dipakai lagi
+	sessionQuery := `
+		UPDATE sessions SET revoked_at = NOW(), revoked_reason = $2
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+	if _, err = tx.ExecContext(ctx, sessionQuery, userID, models.SessionRevokedPasswordReset); err != nil {
+		return fmt.Errorf("gagal mencabut sesi pengguna: %w", err)
+	}
+
+	// Query 5: Cabut token OAuth yang diterbitkan untuk client pihak ketiga
+	if err := RevokeUserOAuthTokensTx(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+
+	return nil
+}
+
+// ClearUserPassword menghapus password pengguna sehingga login dengan password tidak mungkin
+// sampai password baru dibuat lewat tautan reset. Token yang terbit sebelum waktu before dan
+// semua sesi login dicabut dalam transaksi yang sama.
+func (r *AuthRepository) ClearUserPassword(ctx context.Context, userID string, before time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	userQuery := `
+		UPDATE users
+		SET password_hash = NULL,
+			tokens_valid_after = GREATEST(COALESCE(tokens_valid_after, $2), $2)
+		WHERE id = $1
+	`
+	res, err := tx.ExecContext(ctx, userQuery, userID, TokenEpoch(before))
+	if err != nil {
+		return fmt.Errorf("gagal menghapus password pengguna: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("pengguna tidak ditemukan dengan ID: %s", userID)
+	}
+
+	sessionQuery := `
+		UPDATE sessions SET revoked_at = NOW(), revoked_reason = $2
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+	if _, err = tx.ExecContext(ctx, sessionQuery, userID, models.SessionRevokedPasswordReset); err != nil {
+		return fmt.Errorf("gagal mencabut sesi pengguna: %w", err)
+	}
+	if err := RevokeUserOAuthTokensTx(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return nil
+}
+
 // UpdateUserLoginStatus memperbarui status login pengguna
 func (r *AuthRepository) UpdateUserLoginStatus(ctx context.Context, userID string, ip string, failedAttempts int, lockUntil *time.Time) error {
 	query := `
@@ -280,32 +713,181 @@ func (r *AuthRepository) UpdateUserLoginStatus(ctx context.Context, userID strin
 	return nil
 }
 
-// RevokeToken mencatat token yang dicabut ke dalam database
-func (r *AuthRepository) RevokeToken(ctx context.Context, token string, expiresAt time.Time) error {
+// RevokeToken mencatat jti token yang dicabut ke dalam database. Mencabut jti yang sama
+// dua kali tidak dianggap error; ID dan RevokedAt diisi dari baris yang tersimpan.
+func (r *AuthRepository) RevokeToken(ctx context.Context, token *models.RevokedToken) error {
 	query := `
-		INSERT INTO revoked_tokens 
-		(token, expires_at) 
+		INSERT INTO revoked_tokens (token_id, expires_at)
 		VALUES ($1, $2)
+		ON CONFLICT (token_id) DO UPDATE SET expires_at = GREATEST(revoked_tokens.expires_at, EXCLUDED.expires_at)
+		RETURNING id, revoked_at
 	`
-	_, err := r.db.ExecContext(ctx, query, token, expiresAt)
+	err := r.db.QueryRowContext(ctx, query, token.TokenID, token.ExpiresAt).Scan(&token.ID, &token.RevokedAt)
 	if err != nil {
 		return fmt.Errorf("gagal mencabut token: %w", err)
 	}
 	return nil
 }
 
-// IsTokenRevoked memeriksa apakah token sudah dicabut
-func (r *AuthRepository) IsTokenRevoked(ctx context.Context, token string) (bool, error) {
+// TokenEpoch membulatkan waktu pencabutan ke bawah menjadi detik penuh. Klaim iat hanya
+// berpresisi detik, sehingga epoch disimpan dengan presisi yang sama dan token ditolak jika
+// iat < epoch. Token yang terbit di detik yang sama setelah pencabutan, misalnya login tepat
+// setelah reset password, tetap berlaku.
+func TokenEpoch(t time.Time) time.Time {
+	return t.Truncate(time.Second)
+}
+
+// IsTokenRevoked memeriksa apakah token sudah dicabut, baik lewat jti-nya sendiri maupun
+// karena diterbitkan sebelum epoch token milik pengguna (tokens_valid_after)
+func (r *AuthRepository) IsTokenRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error) {
 	query := `
-		SELECT EXISTS (
-			SELECT 1 FROM revoked_tokens 
-			WHERE token = $1
-		)
+		SELECT
+			EXISTS (SELECT 1 FROM revoked_tokens WHERE token_id = $1)
+			OR EXISTS (
+				SELECT 1 FROM users
+				WHERE id = $2 AND tokens_valid_after IS NOT NULL AND tokens_valid_after > $3
+			)
 	`
 	var isRevoked bool
-	err := r.db.QueryRowContext(ctx, query, token).Scan(&isRevoked)
+	err := r.db.QueryRowContext(ctx, query, tokenID, userID, issuedAt).Scan(&isRevoked)
 	if err != nil {
 		return false, fmt.Errorf("gagal memeriksa revoked token: %w", err)
 	}
 	return isRevoked, nil
 }
+
+// RevokeUserTokensBefore memajukan epoch token pengguna ke waktu before sehingga semua token
+// yang terbit sebelumnya ditolak, lalu mengakhiri semua sesi pengguna dalam satu transaksi
+func (r *AuthRepository) RevokeUserTokensBefore(ctx context.Context, userID string, before time.Time, reason string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	found, err := RevokeUserAccessTx(ctx, tx, userID, before, reason)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("pengguna tidak ditemukan dengan ID: %s", userID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserAccessTx memajukan epoch token pengguna ke waktu before dan mengakhiri semua sesi
+// pengguna di dalam transaksi milik pemanggil. Modul lain memakainya agar pencabutan akses
+// tersimpan bersama perubahan datanya sendiri, misalnya perubahan status akun oleh admin.
+// Hasil false berarti pengguna tidak ditemukan.
+func RevokeUserAccessTx(ctx context.Context, tx *sql.Tx, userID string, before time.Time, reason string) (bool, error) {
+	userQuery := `
+		UPDATE users
+		SET tokens_valid_after = GREATEST(COALESCE(tokens_valid_after, $2), $2)
+		WHERE id = $1
+	`
+	res, err := tx.ExecContext(ctx, userQuery, userID, TokenEpoch(before))
+	if err != nil {
+		return false, fmt.Errorf("gagal memperbarui epoch token pengguna: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	sessionQuery := `
+		UPDATE sessions SET revoked_at = NOW(), revoked_reason = $2
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, sessionQuery, userID, reason); err != nil {
+		return false, fmt.Errorf("gagal mencabut sesi pengguna: %w", err)
+	}
+	return true, nil
+}
+
+// PurgeExpiredRevokedTokens menghapus paling banyak limit baris revoked_tokens yang tokennya
+// sudah kedaluwarsa. Token kedaluwarsa sudah ditolak oleh validasi JWT sehingga barisnya
+// tidak lagi diperlukan.
+func (r *AuthRepository) PurgeExpiredRevokedTokens(ctx context.Context, now time.Time, limit int) (int64, error) {
+	query := `
+		DELETE FROM revoked_tokens
+		WHERE id IN (
+			SELECT id FROM revoked_tokens
+			WHERE expires_at < $1
+			ORDER BY expires_at
+			LIMIT $2
+		)
+	`
+	res, err := r.db.ExecContext(ctx, query, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("gagal menghapus revoked token kedaluwarsa: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows, nil
+}
+
+// ListRevokedTokensSince mengambil token yang dicabut sejak waktu since dan belum kedaluwarsa
+// pada waktu now. since bernilai nol berarti semua token yang belum kedaluwarsa.
+func (r *AuthRepository) ListRevokedTokensSince(ctx context.Context, since, now time.Time) ([]models.RevokedToken, error) {
+	query := `
+		SELECT id, token_id, revoked_at, expires_at
+		FROM revoked_tokens
+		WHERE revoked_at >= $1 AND expires_at > $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, since, now)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar revoked token: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []models.RevokedToken{}
+	for rows.Next() {
+		var token models.RevokedToken
+		if err := rows.Scan(&token.ID, &token.TokenID, &token.RevokedAt, &token.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("gagal membaca revoked token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar revoked token: %w", err)
+	}
+	return tokens, nil
+}
+
+// ListUserTokenEpochsSince mengambil epoch token pengguna yang berubah sejak waktu since.
+// Epoch yang lebih lama dari notBefore diabaikan karena semua token yang terbit sebelumnya
+// pasti sudah kedaluwarsa.
+func (r *AuthRepository) ListUserTokenEpochsSince(ctx context.Context, since, notBefore time.Time) ([]models.UserTokenEpoch, error) {
+	query := `
+		SELECT id, tokens_valid_after
+		FROM users
+		WHERE tokens_valid_after IS NOT NULL AND tokens_valid_after > $2 AND updated_at >= $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, since, notBefore)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil epoch token pengguna: %w", err)
+	}
+	defer rows.Close()
+
+	epochs := []models.UserTokenEpoch{}
+	for rows.Next() {
+		var epoch models.UserTokenEpoch
+		if err := rows.Scan(&epoch.UserID, &epoch.ValidAfter); err != nil {
+			return nil, fmt.Errorf("gagal membaca epoch token pengguna: %w", err)
+		}
+		epochs = append(epochs, epoch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar epoch token pengguna: %w", err)
+	}
+	return epochs, nil
+}
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+
+	"github.com/google/uuid"
+)
+
+// FindUserMFA mengambil data TOTP pengguna, nil jika pengguna belum pernah mendaftar
+func (r *AuthRepository) FindUserMFA(ctx context.Context, userID string) (*models.UserMFA, error) {
+	query := `
+		SELECT user_id, secret, confirmed_at, last_used_step, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = $1
+	`
+	mfa := &models.UserMFA{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&mfa.UserID,
+		&mfa.Secret,
+		&mfa.ConfirmedAt,
+		&mfa.LastUsedStep,
+		&mfa.CreatedAt,
+		&mfa.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil data MFA pengguna: %w", err)
+	}
+	return mfa, nil
+}
+
+// SavePendingMFASecret menyimpan secret TOTP baru yang belum dikonfirmasi. Secret yang sudah
+// dikonfirmasi tidak ditimpa sehingga MFA aktif tidak dapat diganti tanpa dinonaktifkan dulu.
+func (r *AuthRepository) SavePendingMFASecret(ctx context.Context, userID, secret string) (bool, error) {
+	query := `
+		INSERT INTO user_mfa (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0
+		WHERE user_mfa.confirmed_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		return false, fmt.Errorf("gagal menyimpan secret MFA: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ConfirmUserMFA mengaktifkan TOTP dan mengganti seluruh kode pemulihan dalam satu transaksi
+func (r *AuthRepository) ConfirmUserMFA(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE user_mfa SET confirmed_at = NOW(), last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NULL AND last_used_step < $2
+	`
+	res, err := tx.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("gagal mengaktifkan MFA: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+		return ErrMFAStateChanged
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryCodeHashes); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ConsumeTOTPStep mencatat nomor periode TOTP yang baru dipakai. Hasil false berarti kode
+// untuk periode tersebut (atau yang lebih baru) sudah pernah dipakai.
+func (r *AuthRepository) ConsumeTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	query := `UPDATE user_mfa SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`
+	res, err := r.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("gagal mencatat pemakaian kode MFA: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ConsumeRecoveryCode menandai kode pemulihan sebagai terpakai. Hasil false berarti kode
+// tidak ditemukan atau sudah pernah dipakai.
+func (r *AuthRepository) ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	query := `
+		UPDATE mfa_recovery_codes SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("gagal memakai kode pemulihan: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// CountUnusedRecoveryCodes menghitung kode pemulihan yang masih dapat dipakai
+func (r *AuthRepository) CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("gagal menghitung kode pemulihan: %w", err)
+	}
+	return count, nil
+}
+
+// ReplaceRecoveryCodes menghapus semua kode pemulihan lama dan menyimpan yang baru
+func (r *AuthRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryCodeHashes); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteUserMFA menonaktifkan MFA dengan menghapus secret dan semua kode pemulihan
+func (r *AuthRepository) DeleteUserMFA(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("gagal menghapus kode pemulihan: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("gagal menghapus data MFA: %w", err)
+	}
+	return tx.Commit()
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID string, recoveryCodeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("gagal menghapus kode pemulihan lama: %w", err)
+	}
+	for _, hash := range recoveryCodeHashes {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO mfa_recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3)`,
+			uuid.New().String(), userID, hash,
+		)
+		if err != nil {
+			return fmt.Errorf("gagal menyimpan kode pemulihan: %w", err)
+		}
+	}
+	return nil
+}
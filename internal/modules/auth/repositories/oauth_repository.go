@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+)
+
+// ErrAccountNotLinkable dikembalikan ketika identitas penyedia akan dihubungkan ke akun
+// yang tidak berstatus active atau pending, misalnya akun yang di-suspend atau di-banned
+var ErrAccountNotLinkable = errors.New("akun tidak dapat dihubungkan dengan penyedia login")
+
+// SaveOAuthLoginState menyimpan state login OAuth baru dan membersihkan state yang sudah
+// kedaluwarsa milik percobaan login yang tidak pernah kembali dari penyedia
+func (r *AuthRepository) SaveOAuthLoginState(ctx context.Context, state *models.OAuthLoginState) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM oauth_login_states WHERE expires_at < NOW()`); err != nil {
+		return fmt.Errorf("gagal membersihkan state OAuth: %w", err)
+	}
+
+	query := `
+		INSERT INTO oauth_login_states (state, provider, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		state.State,
+		state.Provider,
+		state.Nonce,
+		state.CodeVerifier,
+		state.ExpiresAt,
+	).Scan(&state.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan state OAuth: %w", err)
+	}
+	return nil
+}
+
+// ConsumeOAuthLoginState mengambil sekaligus menghapus state sehingga setiap state hanya
+// dapat dipakai sekali. Mengembalikan nil jika state tidak ada atau milik penyedia lain.
+func (r *AuthRepository) ConsumeOAuthLoginState(ctx context.Context, state, provider string) (*models.OAuthLoginState, error) {
+	query := `
+		DELETE FROM oauth_login_states
+		WHERE state = $1 AND provider = $2
+		RETURNING state, provider, nonce, code_verifier, created_at, expires_at
+	`
+	loginState := &models.OAuthLoginState{}
+	err := r.db.QueryRowContext(ctx, query, state, provider).Scan(
+		&loginState.State,
+		&loginState.Provider,
+		&loginState.Nonce,
+		&loginState.CodeVerifier,
+		&loginState.CreatedAt,
+		&loginState.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil state OAuth: %w", err)
+	}
+	return loginState, nil
+}
+
+// FindUserIdentity mencari identitas penyedia berdasarkan subject, nil jika belum terhubung
+func (r *AuthRepository) FindUserIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at, last_login_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+	identity := &models.UserIdentity{}
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari identitas OAuth: %w", err)
+	}
+	return identity, nil
+}
+
+// LinkUserIdentity menghubungkan identitas penyedia ke pengguna berstatus active atau pending.
+// Jika claimUnverifiedUser true dan akun masih pending dengan email belum terverifikasi, akun
+// diaktifkan sekaligus password dan token email yang belum dipakai dihapus: pemilik email yang
+// sebenarnya baru terbukti lewat penyedia, sehingga password yang dibuat sebelumnya tidak boleh
+// tetap berlaku. Akun yang diblokir admin tidak pernah dihubungkan maupun diaktifkan.
+func (r *AuthRepository) LinkUserIdentity(ctx context.Context, identity *models.UserIdentity, claimUnverifiedUser bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Baris pengguna dikunci agar status tidak berubah di antara pemeriksaan dan penghubungan
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM users WHERE id = $1 FOR UPDATE`, identity.UserID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAccountNotLinkable
+		}
+		return fmt.Errorf("gagal mengunci pengguna: %w", err)
+	}
+	if status != "active" && status != "pending" {
+		return ErrAccountNotLinkable
+	}
+
+	if claimUnverifiedUser {
+		claimQuery := `
+			UPDATE users
+			SET status = 'active', email_verified = true, email_verified_at = NOW(), password_hash = NULL
+			WHERE id = $1 AND email_verified = false AND status = 'pending'
+		`
+		res, err := tx.ExecContext(ctx, claimQuery, identity.UserID)
+		if err != nil {
+			return fmt.Errorf("gagal mengaktifkan pengguna: %w", err)
+		}
+		if rows, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+		} else if rows > 0 {
+			tokenQuery := `
+				UPDATE email_verification_tokens SET expires_at = NOW()
+				WHERE user_id = $1 AND used_at IS NULL AND expires_at > NOW()
+			`
+			if _, err := tx.ExecContext(ctx, tokenQuery, identity.UserID); err != nil {
+				return fmt.Errorf("gagal menginvalidasi token pengguna: %w", err)
+			}
+		}
+	}
+
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, subject, email)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, last_login_at
+	`
+	err = tx.QueryRowContext(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	).Scan(&identity.CreatedAt, &identity.LastLoginAt)
+	if err != nil {
+		return fmt.Errorf("gagal menghubungkan identitas OAuth: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// TouchUserIdentity mencatat waktu login terakhir dan email terbaru dari penyedia
+func (r *AuthRepository) TouchUserIdentity(ctx context.Context, identityID string, email *string) error {
+	query := `UPDATE user_identities SET last_login_at = NOW(), email = COALESCE($2, email) WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, identityID, email); err != nil {
+		return fmt.Errorf("gagal memperbarui identitas OAuth: %w", err)
+	}
+	return nil
+}
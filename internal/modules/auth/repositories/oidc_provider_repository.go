@@ -0,0 +1,520 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+	profiles "github.com/jokosaputro95/cms-go/internal/modules/profile/models"
+
+	"github.com/lib/pq"
+)
+
+const oauthClientColumns = `
+	id, name, secret_hash, redirect_uris, grant_types, scopes,
+	created_by, created_at, updated_at, revoked_at
+`
+
+const oauthTokenColumns = `
+	id, grant_id, token_type, token_hash, client_id, user_id, scopes,
+	auth_time, expires_at, created_at, revoked_at
+`
+
+// SaveOAuthClient menyimpan client OAuth baru
+func (r *AuthRepository) SaveOAuthClient(ctx context.Context, client *models.OAuthClient) error {
+	query := `
+		INSERT INTO oauth_clients (id, name, secret_hash, redirect_uris, grant_types, scopes, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		client.ID,
+		client.Name,
+		client.SecretHash,
+		pq.Array(client.RedirectURIs),
+		pq.Array(client.GrantTypes),
+		pq.Array(client.Scopes),
+		client.CreatedBy,
+	).Scan(&client.CreatedAt, &client.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan client OAuth: %w", err)
+	}
+	return nil
+}
+
+// FindOAuthClient mengambil client yang belum dicabut, nil jika tidak ada
+func (r *AuthRepository) FindOAuthClient(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	query := `
+		SELECT ` + oauthClientColumns + `
+		FROM oauth_clients
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	client, err := scanOAuthClient(r.db.QueryRowContext(ctx, query, clientID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil client OAuth: %w", err)
+	}
+	return client, nil
+}
+
+// ListOAuthClients mengambil semua client yang belum dicabut, diurutkan berdasarkan nama
+func (r *AuthRepository) ListOAuthClients(ctx context.Context) ([]models.OAuthClient, error) {
+	query := `
+		SELECT ` + oauthClientColumns + `
+		FROM oauth_clients
+		WHERE revoked_at IS NULL
+		ORDER BY name
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar client OAuth: %w", err)
+	}
+	defer rows.Close()
+
+	clients := []models.OAuthClient{}
+	for rows.Next() {
+		client, err := scanOAuthClient(rows)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca client OAuth: %w", err)
+		}
+		clients = append(clients, *client)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar client OAuth: %w", err)
+	}
+	return clients, nil
+}
+
+// UpdateOAuthClientSecret mengganti hash secret client. Hasil false berarti client tidak
+// ditemukan, sudah dicabut, atau berupa public client.
+func (r *AuthRepository) UpdateOAuthClientSecret(ctx context.Context, clientID, secretHash string) (bool, error) {
+	query := `
+		UPDATE oauth_clients SET secret_hash = $2
+		WHERE id = $1 AND revoked_at IS NULL AND secret_hash IS NOT NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, clientID, secretHash)
+	if err != nil {
+		return false, fmt.Errorf("gagal memperbarui secret client OAuth: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// RevokeOAuthClient mencabut client beserta semua token dan authorization code miliknya.
+// Hasil false berarti client tidak ditemukan atau sudah dicabut sebelumnya.
+func (r *AuthRepository) RevokeOAuthClient(ctx context.Context, clientID string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE oauth_clients SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, clientID)
+	if err != nil {
+		return false, fmt.Errorf("gagal mencabut client OAuth: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM oauth_authorization_codes WHERE client_id = $1`, clientID); err != nil {
+		return false, fmt.Errorf("gagal menghapus authorization code client: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE oauth_tokens SET revoked_at = NOW() WHERE client_id = $1 AND revoked_at IS NULL`, clientID); err != nil {
+		return false, fmt.Errorf("gagal mencabut token client: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return true, nil
+}
+
+// SaveOAuthAuthorizationCode menyimpan authorization code baru dan membersihkan code yang
+// sudah kedaluwarsa tanpa pernah ditukar
+func (r *AuthRepository) SaveOAuthAuthorizationCode(ctx context.Context, code *models.OAuthAuthorizationCode) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM oauth_authorization_codes WHERE expires_at < NOW()`); err != nil {
+		return fmt.Errorf("gagal membersihkan authorization code: %w", err)
+	}
+
+	query := `
+		INSERT INTO oauth_authorization_codes (
+			code_hash, client_id, user_id, redirect_uri, scopes, nonce, code_challenge, auth_time, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		code.CodeHash,
+		code.ClientID,
+		code.UserID,
+		code.RedirectURI,
+		pq.Array(code.Scopes),
+		code.Nonce,
+		code.CodeChallenge,
+		code.AuthTime,
+		code.ExpiresAt,
+	).Scan(&code.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan authorization code: %w", err)
+	}
+	return nil
+}
+
+// ConsumeOAuthAuthorizationCode mengambil sekaligus menghapus authorization code sehingga
+// setiap code hanya dapat ditukar sekali. Mengembalikan nil jika code tidak ada.
+func (r *AuthRepository) ConsumeOAuthAuthorizationCode(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error) {
+	query := `
+		DELETE FROM oauth_authorization_codes
+		WHERE code_hash = $1
+		RETURNING code_hash, client_id, user_id, redirect_uri, scopes, nonce, code_challenge,
+			auth_time, created_at, expires_at
+	`
+	code := &models.OAuthAuthorizationCode{}
+	err := r.db.QueryRowContext(ctx, query, codeHash).Scan(
+		&code.CodeHash,
+		&code.ClientID,
+		&code.UserID,
+		&code.RedirectURI,
+		pq.Array(&code.Scopes),
+		&code.Nonce,
+		&code.CodeChallenge,
+		&code.AuthTime,
+		&code.CreatedAt,
+		&code.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil authorization code: %w", err)
+	}
+	return code, nil
+}
+
+// FindOAuthConsent mengambil consent pengguna untuk client, nil jika belum pernah disetujui
+func (r *AuthRepository) FindOAuthConsent(ctx context.Context, userID, clientID string) (*models.OAuthConsent, error) {
+	query := `
+		SELECT oc.user_id, oc.client_id, c.name, oc.scopes, oc.created_at, oc.updated_at
+		FROM oauth_consents oc
+		JOIN oauth_clients c ON c.id = oc.client_id
+		WHERE oc.user_id = $1 AND oc.client_id = $2
+	`
+	consent, err := scanOAuthConsent(r.db.QueryRowContext(ctx, query, userID, clientID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil consent OAuth: %w", err)
+	}
+	return consent, nil
+}
+
+// SaveOAuthConsent menyimpan scope yang disetujui pengguna, digabung dengan scope yang
+// sudah disetujui sebelumnya
+func (r *AuthRepository) SaveOAuthConsent(ctx context.Context, userID, clientID string, scopes []string) error {
+	query := `
+		INSERT INTO oauth_consents (user_id, client_id, scopes)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, client_id) DO UPDATE
+		SET scopes = ARRAY(SELECT DISTINCT unnest(oauth_consents.scopes || EXCLUDED.scopes)),
+			updated_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, userID, clientID, pq.Array(scopes)); err != nil {
+		return fmt.Errorf("gagal menyimpan consent OAuth: %w", err)
+	}
+	return nil
+}
+
+// ListOAuthConsents mengambil aplikasi yang sudah diberi akses oleh pengguna
+func (r *AuthRepository) ListOAuthConsents(ctx context.Context, userID string) ([]models.OAuthConsent, error) {
+	query := `
+		SELECT oc.user_id, oc.client_id, c.name, oc.scopes, oc.created_at, oc.updated_at
+		FROM oauth_consents oc
+		JOIN oauth_clients c ON c.id = oc.client_id
+		WHERE oc.user_id = $1 AND c.revoked_at IS NULL
+		ORDER BY oc.updated_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar consent OAuth: %w", err)
+	}
+	defer rows.Close()
+
+	consents := []models.OAuthConsent{}
+	for rows.Next() {
+		consent, err := scanOAuthConsent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca consent OAuth: %w", err)
+		}
+		consents = append(consents, *consent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar consent OAuth: %w", err)
+	}
+	return consents, nil
+}
+
+// RevokeOAuthConsent menghapus consent pengguna untuk client sekaligus mencabut semua token
+// yang sudah diterbitkan untuk pengguna tersebut. Hasil false berarti consent tidak ada.
+func (r *AuthRepository) RevokeOAuthConsent(ctx context.Context, userID, clientID string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM oauth_consents WHERE user_id = $1 AND client_id = $2`, userID, clientID)
+	if err != nil {
+		return false, fmt.Errorf("gagal menghapus consent OAuth: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	query := `
+		UPDATE oauth_tokens SET revoked_at = NOW()
+		WHERE user_id = $1 AND client_id = $2 AND revoked_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, clientID); err != nil {
+		return false, fmt.Errorf("gagal mencabut token OAuth: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return true, nil
+}
+
+// SaveOAuthTokens menyimpan token yang diterbitkan bersamaan dalam satu transaksi
+func (r *AuthRepository) SaveOAuthTokens(ctx context.Context, tokens ...*models.OAuthToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertOAuthTokens(ctx, tx, tokens); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RotateOAuthRefreshToken mencabut refresh token lama lalu menyimpan token pengganti dalam
+// satu transaksi. Hasil false berarti refresh token lama sudah dicabut, misalnya karena
+// dipakai bersamaan oleh dua permintaan.
+func (r *AuthRepository) RotateOAuthRefreshToken(ctx context.Context, oldTokenID string, tokens ...*models.OAuthToken) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE oauth_tokens SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, oldTokenID)
+	if err != nil {
+		return false, fmt.Errorf("gagal mencabut refresh token OAuth: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	if err := insertOAuthTokens(ctx, tx, tokens); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return true, nil
+}
+
+// FindOAuthTokenByHash mengambil token berdasarkan hash-nya, termasuk yang sudah dicabut
+// agar pemakaian ulang refresh token dapat dikenali. Token milik client yang dicabut atau
+// pengguna yang tidak aktif diperlakukan seperti tidak ada.
+func (r *AuthRepository) FindOAuthTokenByHash(ctx context.Context, tokenHash string) (*models.OAuthToken, error) {
+	query := `
+		SELECT ` + oauthTokenColumns + `
+		FROM oauth_tokens t
+		WHERE t.token_hash = $1
+			AND EXISTS (SELECT 1 FROM oauth_clients c WHERE c.id = t.client_id AND c.revoked_at IS NULL)
+			AND (t.user_id IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.id = t.user_id AND u.status = 'active'))
+	`
+	token, err := scanOAuthToken(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil token OAuth: %w", err)
+	}
+	return token, nil
+}
+
+// RevokeOAuthToken mencabut satu token
+func (r *AuthRepository) RevokeOAuthToken(ctx context.Context, tokenID string) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE oauth_tokens SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, tokenID); err != nil {
+		return fmt.Errorf("gagal mencabut token OAuth: %w", err)
+	}
+	return nil
+}
+
+// RevokeOAuthGrant mencabut semua token yang berasal dari satu otorisasi
+func (r *AuthRepository) RevokeOAuthGrant(ctx context.Context, grantID string) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE oauth_tokens SET revoked_at = NOW() WHERE grant_id = $1 AND revoked_at IS NULL`, grantID); err != nil {
+		return fmt.Errorf("gagal mencabut grant OAuth: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserOAuthTokensTx mencabut semua token OAuth milik pengguna di dalam transaksi milik
+// pemanggil, dipakai saat password direset atau akun dinonaktifkan
+func RevokeUserOAuthTokensTx(ctx context.Context, tx *sql.Tx, userID string) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE oauth_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID); err != nil {
+		return fmt.Errorf("gagal mencabut token OAuth pengguna: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpiredOAuthTokens menghapus paling banyak limit token OAuth yang sudah kedaluwarsa.
+// Token kedaluwarsa sudah ditolak sehingga barisnya tidak lagi diperlukan, termasuk untuk
+// mengenali pemakaian ulang refresh token.
+func (r *AuthRepository) PurgeExpiredOAuthTokens(ctx context.Context, now time.Time, limit int) (int64, error) {
+	query := `
+		DELETE FROM oauth_tokens
+		WHERE id IN (
+			SELECT id FROM oauth_tokens
+			WHERE expires_at < $1
+			LIMIT $2
+		)
+	`
+	res, err := r.db.ExecContext(ctx, query, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("gagal menghapus token OAuth kedaluwarsa: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows, nil
+}
+
+// FindUserProfile mengambil nama dan foto profil pengguna untuk klaim OpenID Connect,
+// nil jika pengguna belum memiliki profil
+func (r *AuthRepository) FindUserProfile(ctx context.Context, userID string) (*profiles.UserProfile, error) {
+	query := `SELECT user_id, first_name, last_name, avatar_url, updated_at FROM user_profiles WHERE user_id = $1`
+	profile := &profiles.UserProfile{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&profile.UserID,
+		&profile.FirstName,
+		&profile.LastName,
+		&profile.AvatarURL,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil profil pengguna: %w", err)
+	}
+	return profile, nil
+}
+
+func insertOAuthTokens(ctx context.Context, tx *sql.Tx, tokens []*models.OAuthToken) error {
+	query := `
+		INSERT INTO oauth_tokens (id, grant_id, token_type, token_hash, client_id, user_id, scopes, auth_time, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at
+	`
+	for _, token := range tokens {
+		err := tx.QueryRowContext(ctx, query,
+			token.ID,
+			token.GrantID,
+			token.TokenType,
+			token.TokenHash,
+			token.ClientID,
+			token.UserID,
+			pq.Array(token.Scopes),
+			token.AuthTime,
+			token.ExpiresAt,
+		).Scan(&token.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("gagal menyimpan token OAuth: %w", err)
+		}
+	}
+	return nil
+}
+
+func scanOAuthClient(row rowScanner) (*models.OAuthClient, error) {
+	client := &models.OAuthClient{}
+	err := row.Scan(
+		&client.ID,
+		&client.Name,
+		&client.SecretHash,
+		pq.Array(&client.RedirectURIs),
+		pq.Array(&client.GrantTypes),
+		pq.Array(&client.Scopes),
+		&client.CreatedBy,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+		&client.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func scanOAuthConsent(row rowScanner) (*models.OAuthConsent, error) {
+	consent := &models.OAuthConsent{}
+	err := row.Scan(
+		&consent.UserID,
+		&consent.ClientID,
+		&consent.ClientName,
+		pq.Array(&consent.Scopes),
+		&consent.CreatedAt,
+		&consent.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return consent, nil
+}
+
+func scanOAuthToken(row rowScanner) (*models.OAuthToken, error) {
+	token := &models.OAuthToken{}
+	err := row.Scan(
+		&token.ID,
+		&token.GrantID,
+		&token.TokenType,
+		&token.TokenHash,
+		&token.ClientID,
+		&token.UserID,
+		pq.Array(&token.Scopes),
+		&token.AuthTime,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
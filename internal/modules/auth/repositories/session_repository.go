@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+)
+
+const sessionColumns = `
+	id, user_id, refresh_token_id, user_agent, ip_address,
+	created_at, last_used_at, expires_at, revoked_at, revoked_reason
+`
+
+// SaveSession menyimpan sesi baru ketika pengguna berhasil login
+func (r *AuthRepository) SaveSession(ctx context.Context, session *models.Session) error {
+	query := `
+		INSERT INTO sessions (id, user_id, refresh_token_id, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, last_used_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		session.ID,
+		session.UserID,
+		session.RefreshTokenID,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+	).Scan(&session.CreatedAt, &session.LastUsedAt)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan sesi: %w", err)
+	}
+	return nil
+}
+
+// FindSessionByID mengambil sesi berdasarkan ID keluarga refresh token, nil jika tidak ada
+func (r *AuthRepository) FindSessionByID(ctx context.Context, sessionID string) (*models.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = $1`
+	session, err := scanSession(r.db.QueryRowContext(ctx, query, sessionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil sesi: %w", err)
+	}
+	return session, nil
+}
+
+// RotateSession mengganti jti refresh token terbaru milik sesi. Penggantian hanya terjadi jika
+// jti lama masih yang terbaru dan sesi masih aktif; hasil false berarti refresh token lama
+// sudah dipakai oleh permintaan lain.
+func (r *AuthRepository) RotateSession(ctx context.Context, sessionID, oldRefreshTokenID, newRefreshTokenID string, ip, userAgent *string, expiresAt time.Time) (bool, error) {
+	query := `
+		UPDATE sessions
+		SET refresh_token_id = $3, ip_address = $4, user_agent = COALESCE($5, user_agent),
+			last_used_at = NOW(), expires_at = $6
+		WHERE id = $1 AND refresh_token_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+	`
+	res, err := r.db.ExecContext(ctx, query, sessionID, oldRefreshTokenID, newRefreshTokenID, ip, userAgent, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("gagal memperbarui sesi: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ListActiveSessions mengambil sesi pengguna yang belum dicabut dan belum kedaluwarsa,
+// diurutkan dari yang terakhir dipakai
+func (r *AuthRepository) ListActiveSessions(ctx context.Context, userID string) ([]models.Session, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar sesi: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca sesi: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar sesi: %w", err)
+	}
+	return sessions, nil
+}
+
+// RevokeSession mencabut satu sesi milik pengguna. Hasil false berarti sesi tidak ditemukan
+// atau sudah dicabut sebelumnya.
+func (r *AuthRepository) RevokeSession(ctx context.Context, userID, sessionID, reason string) (bool, error) {
+	query := `
+		UPDATE sessions SET revoked_at = NOW(), revoked_reason = $3
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, sessionID, userID, reason)
+	if err != nil {
+		return false, fmt.Errorf("gagal mencabut sesi: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// RevokeOtherSessions mencabut semua sesi aktif pengguna kecuali keepSessionID.
+// keepSessionID kosong berarti semua sesi dicabut.
+func (r *AuthRepository) RevokeOtherSessions(ctx context.Context, userID, keepSessionID, reason string) (int64, error) {
+	query := `
+		UPDATE sessions SET revoked_at = NOW(), revoked_reason = $3
+		WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, userID, keepSessionID, reason)
+	if err != nil {
+		return 0, fmt.Errorf("gagal mencabut sesi pengguna: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row rowScanner) (*models.Session, error) {
+	session := &models.Session{}
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.RefreshTokenID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+		&session.RevokedReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
@@ -0,0 +1,235 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+
+	"github.com/lib/pq"
+)
+
+const webAuthnCredentialColumns = `
+	id, user_id, name, credential_id, public_key, sign_count,
+	aaguid, transports, backup_eligible, created_at, last_used_at
+`
+
+var (
+	// ErrDuplicateWebAuthnCredential dikembalikan ketika credential ID sudah terdaftar
+	ErrDuplicateWebAuthnCredential = errors.New("kredensial WebAuthn sudah terdaftar")
+	// ErrDuplicateWebAuthnName dikembalikan ketika pengguna sudah memakai nama kredensial yang sama
+	ErrDuplicateWebAuthnName = errors.New("nama kredensial WebAuthn sudah dipakai")
+)
+
+// SaveWebAuthnChallenge menyimpan challenge baru dan membersihkan challenge yang sudah
+// kedaluwarsa tanpa pernah dipakai
+func (r *AuthRepository) SaveWebAuthnChallenge(ctx context.Context, challenge *models.WebAuthnChallenge) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM webauthn_challenges WHERE expires_at < NOW()`); err != nil {
+		return fmt.Errorf("gagal membersihkan challenge WebAuthn: %w", err)
+	}
+
+	query := `
+		INSERT INTO webauthn_challenges (challenge_hash, user_id, ceremony, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		challenge.ChallengeHash,
+		challenge.UserID,
+		challenge.Ceremony,
+		challenge.ExpiresAt,
+	).Scan(&challenge.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan challenge WebAuthn: %w", err)
+	}
+	return nil
+}
+
+// ConsumeWebAuthnChallenge mengambil sekaligus menghapus challenge untuk ceremony tertentu
+// sehingga setiap challenge hanya dapat dipakai sekali. Mengembalikan nil jika tidak ada.
+func (r *AuthRepository) ConsumeWebAuthnChallenge(ctx context.Context, challengeHash, ceremony string) (*models.WebAuthnChallenge, error) {
+	query := `
+		DELETE FROM webauthn_challenges
+		WHERE challenge_hash = $1 AND ceremony = $2
+		RETURNING challenge_hash, user_id, ceremony, created_at, expires_at
+	`
+	challenge := &models.WebAuthnChallenge{}
+	err := r.db.QueryRowContext(ctx, query, challengeHash, ceremony).Scan(
+		&challenge.ChallengeHash,
+		&challenge.UserID,
+		&challenge.Ceremony,
+		&challenge.CreatedAt,
+		&challenge.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil challenge WebAuthn: %w", err)
+	}
+	return challenge, nil
+}
+
+// SaveWebAuthnCredential menyimpan kredensial yang baru didaftarkan
+func (r *AuthRepository) SaveWebAuthnCredential(ctx context.Context, credential *models.WebAuthnCredential) error {
+	query := `
+		INSERT INTO webauthn_credentials (
+			id, user_id, name, credential_id, public_key, sign_count, aaguid, transports, backup_eligible
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		credential.ID,
+		credential.UserID,
+		credential.Name,
+		credential.CredentialID,
+		credential.PublicKey,
+		credential.SignCount,
+		credential.AAGUID,
+		pq.Array(credential.Transports),
+		credential.BackupEligible,
+	).Scan(&credential.CreatedAt)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err, "webauthn_credentials_credential_id_key"):
+			return ErrDuplicateWebAuthnCredential
+		case isUniqueViolation(err, "uq_webauthn_credentials_user_name"):
+			return ErrDuplicateWebAuthnName
+		}
+		return fmt.Errorf("gagal menyimpan kredensial WebAuthn: %w", err)
+	}
+	return nil
+}
+
+// CountWebAuthnCredentials menghitung kredensial milik pengguna
+func (r *AuthRepository) CountWebAuthnCredentials(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM webauthn_credentials WHERE user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("gagal menghitung kredensial WebAuthn: %w", err)
+	}
+	return count, nil
+}
+
+// ListWebAuthnCredentials mengambil kredensial milik pengguna, diurutkan dari yang terlama
+func (r *AuthRepository) ListWebAuthnCredentials(ctx context.Context, userID string) ([]models.WebAuthnCredential, error) {
+	query := `
+		SELECT ` + webAuthnCredentialColumns + `
+		FROM webauthn_credentials
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar kredensial WebAuthn: %w", err)
+	}
+	defer rows.Close()
+
+	credentials := []models.WebAuthnCredential{}
+	for rows.Next() {
+		credential, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca kredensial WebAuthn: %w", err)
+		}
+		credentials = append(credentials, *credential)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar kredensial WebAuthn: %w", err)
+	}
+	return credentials, nil
+}
+
+// FindWebAuthnCredential mengambil kredensial berdasarkan credential ID (base64url),
+// nil jika tidak ada
+func (r *AuthRepository) FindWebAuthnCredential(ctx context.Context, credentialID string) (*models.WebAuthnCredential, error) {
+	query := `SELECT ` + webAuthnCredentialColumns + ` FROM webauthn_credentials WHERE credential_id = $1`
+	credential, err := scanWebAuthnCredential(r.db.QueryRowContext(ctx, query, credentialID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil kredensial WebAuthn: %w", err)
+	}
+	return credential, nil
+}
+
+// UpdateWebAuthnSignCount menyimpan penghitung tanda tangan setelah login berhasil. Penyimpanan
+// hanya terjadi jika penghitung naik (atau authenticator tidak memakai penghitung) sehingga
+// dua login paralel dengan penghitung yang sama tidak keduanya diterima.
+func (r *AuthRepository) UpdateWebAuthnSignCount(ctx context.Context, id string, signCount int64) (bool, error) {
+	query := `
+		UPDATE webauthn_credentials
+		SET sign_count = $2, last_used_at = NOW()
+		WHERE id = $1 AND (sign_count < $2 OR (sign_count = 0 AND $2 = 0))
+	`
+	res, err := r.db.ExecContext(ctx, query, id, signCount)
+	if err != nil {
+		return false, fmt.Errorf("gagal memperbarui kredensial WebAuthn: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// RenameWebAuthnCredential mengganti nama kredensial milik pengguna. Hasil false berarti
+// kredensial tidak ditemukan.
+func (r *AuthRepository) RenameWebAuthnCredential(ctx context.Context, userID, id, name string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `UPDATE webauthn_credentials SET name = $3 WHERE id = $1 AND user_id = $2`, id, userID, name)
+	if err != nil {
+		if isUniqueViolation(err, "uq_webauthn_credentials_user_name") {
+			return false, ErrDuplicateWebAuthnName
+		}
+		return false, fmt.Errorf("gagal mengganti nama kredensial WebAuthn: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// DeleteWebAuthnCredential menghapus kredensial milik pengguna. Hasil false berarti
+// kredensial tidak ditemukan.
+func (r *AuthRepository) DeleteWebAuthnCredential(ctx context.Context, userID, id string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("gagal menghapus kredensial WebAuthn: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func scanWebAuthnCredential(row rowScanner) (*models.WebAuthnCredential, error) {
+	credential := &models.WebAuthnCredential{}
+	err := row.Scan(
+		&credential.ID,
+		&credential.UserID,
+		&credential.Name,
+		&credential.CredentialID,
+		&credential.PublicKey,
+		&credential.SignCount,
+		&credential.AAGUID,
+		pq.Array(&credential.Transports),
+		&credential.BackupEligible,
+		&credential.CreatedAt,
+		&credential.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// isUniqueViolation melaporkan apakah err adalah pelanggaran constraint unik tertentu
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
@@ -16,11 +16,71 @@ func NewAuthRoutes(authHandler *handlers.AuthHandler) *AuthRoutes {
 	return &AuthRoutes{authHandler: authHandler}
 }
 
-// RegisterRoutes mendaftarkan rute-rute otentikasi ke router yang diberikan
-func (r *AuthRoutes) RegisterRoutes(router *http.ServeMux) {
+// RegisterRoutes mendaftarkan rute-rute otentikasi ke router yang diberikan.
+// Rute pengelolaan akun milik pengguna yang sedang login (MFA, passkey, sesi, API token) dibungkus
+// dengan authMiddleware yang hanya menerima sesi login, bukan personal access token.
+func (r *AuthRoutes) RegisterRoutes(router *http.ServeMux, authMiddleware func(http.Handler) http.Handler) {
 	router.HandleFunc("/auth/register", r.authHandler.Register)
 	router.HandleFunc("/auth/login", r.authHandler.Login)
 	router.HandleFunc("/auth/logout", r.authHandler.Logout)
+	router.Handle("/auth/logout-all", authMiddleware(http.HandlerFunc(r.authHandler.LogoutAll)))
 	router.HandleFunc("/auth/verify-email", r.authHandler.VerifyEmail)
+	router.HandleFunc("/auth/resend-verification", r.authHandler.ResendVerification)
 	router.HandleFunc("/auth/refresh-token", r.authHandler.RefreshToken)
+	router.HandleFunc("/auth/forgot-password", r.authHandler.ForgotPassword)
+	router.HandleFunc("/auth/reset-password", r.authHandler.ResetPassword)
+	router.HandleFunc("/auth/magic-link", r.authHandler.MagicLink)
+	router.HandleFunc("/auth/magic-link/verify", r.authHandler.MagicLinkVerify)
+	router.HandleFunc("/auth/webauthn/login/options", r.authHandler.WebAuthnLoginOptions)
+	router.HandleFunc("/auth/webauthn/login", r.authHandler.WebAuthnLogin)
+
+	// Login lewat penyedia OAuth/OIDC
+	router.HandleFunc("/auth/oauth/providers", r.authHandler.OAuthProviders)
+	router.HandleFunc("/auth/oauth/{provider}/login", r.authHandler.OAuthLogin)
+	router.HandleFunc("/auth/oauth/{provider}/callback", r.authHandler.OAuthCallback)
+
+	// Kunci publik access token untuk layanan lain
+	router.HandleFunc("/.well-known/jwks.json", r.authHandler.JWKS)
+
+	// Langkah kedua login memakai token tantangan MFA, bukan access token
+	router.HandleFunc("/auth/mfa/verify", r.authHandler.VerifyMFA)
+	router.HandleFunc("/auth/mfa/enroll", r.authHandler.EnrollMFA)
+	router.HandleFunc("/auth/mfa/webauthn/options", r.authHandler.WebAuthnMFAOptions)
+
+	router.Handle("/auth/mfa", authMiddleware(http.HandlerFunc(r.authHandler.MFAStatus)))
+	router.Handle("/auth/mfa/totp/setup", authMiddleware(http.HandlerFunc(r.authHandler.SetupTOTP)))
+	router.Handle("/auth/mfa/totp/confirm", authMiddleware(http.HandlerFunc(r.authHandler.ConfirmTOTP)))
+	router.Handle("/auth/mfa/disable", authMiddleware(http.HandlerFunc(r.authHandler.DisableMFA)))
+	router.Handle("/auth/mfa/recovery-codes", authMiddleware(http.HandlerFunc(r.authHandler.RegenerateRecoveryCodes)))
+
+	router.Handle("/auth/webauthn/register/options", authMiddleware(http.HandlerFunc(r.authHandler.WebAuthnRegisterOptions)))
+	router.Handle("/auth/webauthn/register", authMiddleware(http.HandlerFunc(r.authHandler.WebAuthnRegister)))
+	router.Handle("/auth/webauthn/credentials", authMiddleware(http.HandlerFunc(r.authHandler.WebAuthnCredentials)))
+	router.Handle("/auth/webauthn/credentials/{credentialID}", authMiddleware(http.HandlerFunc(r.authHandler.WebAuthnCredential)))
+
+	router.Handle("/auth/sessions", authMiddleware(http.HandlerFunc(r.authHandler.Sessions)))
+	router.Handle("/auth/sessions/revoke-others", authMiddleware(http.HandlerFunc(r.authHandler.RevokeOtherSessions)))
+	router.Handle("/auth/sessions/{sessionID}", authMiddleware(http.HandlerFunc(r.authHandler.Session)))
+
+	router.Handle("/auth/api-tokens", authMiddleware(http.HandlerFunc(r.authHandler.APITokens)))
+	router.Handle("/auth/api-tokens/{tokenID}", authMiddleware(http.HandlerFunc(r.authHandler.APIToken)))
+}
+
+// RegisterOIDCProviderRoutes mendaftarkan rute saat CMS bertindak sebagai penyedia OpenID Connect.
+// Endpoint protokol diautentikasi dengan kredensial client atau token OAuth, API consent
+// memakai sesi login pengguna, dan pengelolaan client dibatasi dengan clientAdminMiddleware.
+func (r *AuthRoutes) RegisterOIDCProviderRoutes(router *http.ServeMux, sessionMiddleware, clientAdminMiddleware func(http.Handler) http.Handler) {
+	router.HandleFunc("/.well-known/openid-configuration", r.authHandler.OpenIDConfiguration)
+	router.HandleFunc("/oauth2/token", r.authHandler.OAuth2Token)
+	router.HandleFunc("/oauth2/userinfo", r.authHandler.OAuth2UserInfo)
+	router.HandleFunc("/oauth2/introspect", r.authHandler.OAuth2Introspect)
+	router.HandleFunc("/oauth2/revoke", r.authHandler.OAuth2Revoke)
+
+	router.Handle("/oauth2/authorize", sessionMiddleware(http.HandlerFunc(r.authHandler.OAuth2Authorize)))
+	router.Handle("/oauth2/consents", sessionMiddleware(http.HandlerFunc(r.authHandler.OAuthConsents)))
+	router.Handle("/oauth2/consents/{clientID}", sessionMiddleware(http.HandlerFunc(r.authHandler.OAuthConsent)))
+
+	router.Handle("/oauth2/clients", clientAdminMiddleware(http.HandlerFunc(r.authHandler.OAuthClients)))
+	router.Handle("/oauth2/clients/{clientID}", clientAdminMiddleware(http.HandlerFunc(r.authHandler.OAuthClient)))
+	router.Handle("/oauth2/clients/{clientID}/secret", clientAdminMiddleware(http.HandlerFunc(r.authHandler.OAuthClientSecret)))
 }
\ No newline at end of file
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ErrAPITokenNotFound        = AuthServiceError("API token tidak ditemukan")
+	ErrInvalidAPIToken         = AuthServiceError("API token tidak valid, kedaluwarsa, atau sudah dicabut")
+	ErrAPITokenScopeNotAllowed = AuthServiceError("scope API token melebihi permission pengguna")
+	ErrAPITokenExpiryInPast    = AuthServiceError("waktu kedaluwarsa API token harus di masa depan")
+	ErrAPITokenLimitReached    = AuthServiceError("jumlah API token aktif sudah mencapai batas")
+
+	// APITokenPrefix menandai personal access token sehingga dapat dibedakan dari JWT dan
+	// mudah dikenali oleh secret scanner jika tidak sengaja ter-commit
+	APITokenPrefix = "cms_pat_"
+	// apiTokenSecretSize adalah jumlah byte acak pada token (256 bit)
+	apiTokenSecretSize = 32
+	// apiTokenVisibleChars adalah jumlah karakter acak setelah prefiks yang disimpan untuk
+	// membantu pengguna mengenali token di daftar
+	apiTokenVisibleChars = 6
+	maxAPITokensPerUser  = 50
+)
+
+// APITokenPrincipal adalah identitas hasil otentikasi personal access token.
+// Permissions adalah irisan scope token dengan permission pengguna saat ini.
+type APITokenPrincipal struct {
+	TokenID      string
+	UserID       string
+	Permissions  []string
+	AuthzVersion int64
+}
+
+// IsAPIToken memeriksa apakah nilai Bearer berupa personal access token, bukan JWT
+func IsAPIToken(tokenStr string) bool {
+	return strings.HasPrefix(tokenStr, APITokenPrefix)
+}
+
+// CreateAPIToken membuat personal access token baru. Scope dibatasi pada permission yang
+// dimiliki pengguna saat ini. Token mentah hanya dikembalikan sekali; yang disimpan hanya hash-nya.
+func (s *AuthService) CreateAPIToken(ctx context.Context, userID string, req *dto.CreateAPITokenRequestDTO) (*dto.CreatedAPITokenResponseDTO, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrAPITokenExpiryInPast
+	}
+
+	authz, err := s.authRepo.FindUserAuthorization(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if authz == nil {
+		return nil, fmt.Errorf("pengguna %s tidak ditemukan", userID)
+	}
+
+	scopes := make([]string, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if containsScope(scopes, scope) {
+			continue
+		}
+		if !containsScope(authz.Permissions, scope) {
+			return nil, fmt.Errorf("%w: %s", ErrAPITokenScopeNotAllowed, scope)
+		}
+		scopes = append(scopes, scope)
+	}
+
+	count, err := s.authRepo.CountActiveAPITokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxAPITokensPerUser {
+		return nil, ErrAPITokenLimitReached
+	}
+
+	secret := make([]byte, apiTokenSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("gagal membuat API token: %w", err)
+	}
+	rawToken := APITokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	token := &models.APIToken{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Name:        strings.TrimSpace(req.Name),
+		TokenPrefix: rawToken[:len(APITokenPrefix)+apiTokenVisibleChars],
+		TokenHash:   hashAPIToken(rawToken),
+		Scopes:      scopes,
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if err := s.authRepo.SaveAPIToken(ctx, token); err != nil {
+		return nil, err
+	}
+
+	log.Printf("SECURITY: API token %s (%s) dibuat untuk pengguna %s dengan scope %v", token.ID, token.TokenPrefix, userID, scopes)
+	return &dto.CreatedAPITokenResponseDTO{
+		APITokenResponseDTO: apiTokenResponse(token),
+		Token:               rawToken,
+	}, nil
+}
+
+// ListAPITokens mengembalikan personal access token milik pengguna yang belum dicabut
+func (s *AuthService) ListAPITokens(ctx context.Context, userID string) ([]dto.APITokenResponseDTO, error) {
+	tokens, err := s.authRepo.ListAPITokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.APITokenResponseDTO, 0, len(tokens))
+	for i := range tokens {
+		result = append(result, apiTokenResponse(&tokens[i]))
+	}
+	return result, nil
+}
+
+// RevokeAPIToken mencabut personal access token milik pengguna. Token langsung ditolak
+// pada permintaan berikutnya karena setiap pemakaian diperiksa ke database.
+func (s *AuthService) RevokeAPIToken(ctx context.Context, userID, tokenID string) error {
+	revoked, err := s.authRepo.RevokeAPIToken(ctx, userID, tokenID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAPITokenNotFound
+	}
+	log.Printf("SECURITY: API token %s milik pengguna %s dicabut", tokenID, userID)
+	return nil
+}
+
+// AuthenticateAPIToken memvalidasi personal access token untuk AuthMiddleware dan mencatat
+// waktu pemakaiannya. Permission dihitung ulang dari role pengguna pada setiap permintaan
+// sehingga perubahan role langsung berlaku tanpa menerbitkan token baru.
+func (s *AuthService) AuthenticateAPIToken(ctx context.Context, tokenStr, ip string) (*APITokenPrincipal, error) {
+	if !IsAPIToken(tokenStr) {
+		return nil, ErrInvalidAPIToken
+	}
+
+	token, err := s.authRepo.FindAPITokenByHash(ctx, hashAPIToken(tokenStr))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || !token.Active(time.Now()) {
+		return nil, ErrInvalidAPIToken
+	}
+
+	authz, err := s.authRepo.FindUserAuthorization(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if authz == nil {
+		return nil, ErrInvalidAPIToken
+	}
+
+	permissions := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		if containsScope(authz.Permissions, scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+
+	// Kegagalan mencatat pemakaian tidak boleh menggagalkan permintaan
+	if err := s.authRepo.TouchAPIToken(ctx, token.ID, optionalString(ip)); err != nil {
+		log.Printf("WARNING: %v", err)
+	}
+
+	return &APITokenPrincipal{
+		TokenID:      token.ID,
+		UserID:       token.UserID,
+		Permissions:  permissions,
+		AuthzVersion: authz.Version,
+	}, nil
+}
+
+func apiTokenResponse(token *models.APIToken) dto.APITokenResponseDTO {
+	return dto.APITokenResponseDTO{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.TokenPrefix,
+		Scopes:     token.Scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		LastUsedIP: token.LastUsedIP,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+// hashAPIToken menghitung SHA-256 dari token mentah. Token berisi 256 bit acak sehingga
+// hash cepat tanpa salt sudah cukup dan memungkinkan pencarian langsung lewat indeks.
+func hashAPIToken(tokenStr string) string {
+	sum := sha256.Sum256([]byte(tokenStr))
+	return hex.EncodeToString(sum[:])
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, item := range scopes {
+		if item == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -9,11 +9,15 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jokosaputro95/cms-go/config"
 	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
 	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
 	"github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
 	profiles "github.com/jokosaputro95/cms-go/internal/modules/profile/models"
 	"github.com/jokosaputro95/cms-go/internal/pkg/email"
+	"github.com/jokosaputro95/cms-go/internal/pkg/jwk"
+	"github.com/jokosaputro95/cms-go/internal/pkg/oidc"
+	"github.com/jokosaputro95/cms-go/internal/pkg/webauthn"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -33,10 +37,21 @@ const (
 	ErrTokenAlreadyUsed  = AuthServiceError("token sudah digunakan sebelumnya")
 	ErrInvalidCredentials = AuthServiceError("kredensial tidak valid")
 	ErrUserLocked        = AuthServiceError("Account is temporarily locked, please try again later")
+	ErrRefreshTokenReused = AuthServiceError("refresh token sudah pernah dipakai, sesi dicabut")
+	ErrSessionNotFound   = AuthServiceError("sesi tidak ditemukan")
 	maxFailedAttempts = 5
 	lockoutDuration   = 30 * time.Minute
+	passwordResetTTL  = 15 * time.Minute
+
+	verificationTokenTTL = 30 * time.Minute
+	// Batas pengiriman email per alamat email agar endpoint tidak disalahgunakan
+	emailResendCooldown = time.Minute
+	maxEmailsPerDay     = 5
 )
 
+// emailIssueLimit adalah batas penerbitan token untuk endpoint publik yang mengirim email
+var emailIssueLimit = &repositories.TokenIssueLimit{Cooldown: emailResendCooldown, MaxPerDay: maxEmailsPerDay}
+
 type LockoutError struct {
 	Message string
 	UnlockAt time.Time
@@ -47,14 +62,73 @@ func (e LockoutError) Error() string {
 
 }
 
+// ThrottleError dikembalikan ketika permintaan pengiriman email terlalu sering
+type ThrottleError struct {
+	Message string
+	RetryAt time.Time
+}
+
+func (e ThrottleError) Error() string {
+	return e.Message
+}
+
 // AuthServiceInterface mendefinisikan kontrak untuk service otentikasi
 type AuthServiceInterface interface {
 	RegisterUser(ctx context.Context, req *dto.RegisterRequestDTO) error
-    LoginUser(ctx context.Context, req *dto.LoginRequestDTO, ip string) (*dto.AuthResponseDTO, error)
+    LoginUser(ctx context.Context, req *dto.LoginRequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error)
     LogoutUser(ctx context.Context, tokenStr string) error
-    RefreshToken(ctx context.Context, refreshTokenStr string) (*dto.AuthResponseDTO, error)
+    RefreshToken(ctx context.Context, refreshTokenStr, ip, userAgent string) (*dto.AuthResponseDTO, error)
     VerifyEmail(ctx context.Context, token string) error
-    IsTokenRevoked(ctx context.Context, token string) (bool, error)
+    IsTokenRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error)
+    LogoutAllSessions(ctx context.Context, userID string) error
+    ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequestDTO) error
+    ResetPassword(ctx context.Context, req *dto.ResetPasswordRequestDTO) error
+    ResendVerification(ctx context.Context, req *dto.ResendVerificationRequestDTO) error
+    ForcePasswordReset(ctx context.Context, userID string) error
+    SetUserTokenEpoch(userID string, validAfter time.Time)
+    RequestMagicLink(ctx context.Context, req *dto.MagicLinkRequestDTO) (*dto.MagicLinkResponseDTO, error)
+    LoginWithMagicLink(ctx context.Context, req *dto.MagicLinkLoginRequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error)
+    GetAuthzVersion(ctx context.Context, userID string) (int64, error)
+    VerifyMFA(ctx context.Context, req *dto.VerifyMFARequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error)
+    EnrollMFA(ctx context.Context, req *dto.EnrollMFARequestDTO) (*dto.TOTPSetupResponseDTO, error)
+    GetMFAStatus(ctx context.Context, userID string) (*dto.MFAStatusResponseDTO, error)
+    SetupTOTP(ctx context.Context, userID string) (*dto.TOTPSetupResponseDTO, error)
+    ConfirmTOTP(ctx context.Context, userID string, req *dto.MFACodeRequestDTO) (*dto.RecoveryCodesResponseDTO, error)
+    DisableMFA(ctx context.Context, userID string, req *dto.MFACodeRequestDTO) error
+    RegenerateRecoveryCodes(ctx context.Context, userID string, req *dto.MFACodeRequestDTO) (*dto.RecoveryCodesResponseDTO, error)
+    BeginWebAuthnRegistration(ctx context.Context, userID string) (*dto.WebAuthnCreationOptionsDTO, error)
+    FinishWebAuthnRegistration(ctx context.Context, userID string, req *dto.WebAuthnRegisterRequestDTO) (*dto.WebAuthnCredentialResponseDTO, error)
+    ListWebAuthnCredentials(ctx context.Context, userID string) ([]dto.WebAuthnCredentialResponseDTO, error)
+    RenameWebAuthnCredential(ctx context.Context, userID, credentialID string, req *dto.WebAuthnRenameRequestDTO) error
+    DeleteWebAuthnCredential(ctx context.Context, userID, credentialID string) error
+    BeginWebAuthnLogin(ctx context.Context, req *dto.WebAuthnLoginOptionsRequestDTO) (*dto.WebAuthnRequestOptionsDTO, error)
+    FinishWebAuthnLogin(ctx context.Context, req *dto.WebAuthnLoginRequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error)
+    BeginWebAuthnMFA(ctx context.Context, req *dto.WebAuthnMFAOptionsRequestDTO) (*dto.WebAuthnRequestOptionsDTO, error)
+    ListSessions(ctx context.Context, userID, currentSessionID string) ([]dto.SessionResponseDTO, error)
+    RevokeSession(ctx context.Context, userID, sessionID string) error
+    RevokeOtherSessions(ctx context.Context, userID, currentSessionID string) (int64, error)
+    PublicKeys() jwk.JSONWebKeySet
+    CreateAPIToken(ctx context.Context, userID string, req *dto.CreateAPITokenRequestDTO) (*dto.CreatedAPITokenResponseDTO, error)
+    ListAPITokens(ctx context.Context, userID string) ([]dto.APITokenResponseDTO, error)
+    RevokeAPIToken(ctx context.Context, userID, tokenID string) error
+    AuthenticateAPIToken(ctx context.Context, tokenStr, ip string) (*APITokenPrincipal, error)
+    OAuthProviders() []string
+    StartOAuthLogin(ctx context.Context, provider string) (*dto.OAuthLoginResponseDTO, error)
+    CompleteOAuthLogin(ctx context.Context, provider string, req *dto.OAuthCallbackRequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error)
+    OpenIDConfiguration() (*dto.OpenIDConfigurationDTO, error)
+    GetOAuth2Authorization(ctx context.Context, userID string, req *dto.OAuth2AuthorizeRequestDTO) (*dto.OAuth2AuthorizationDTO, error)
+    DecideOAuth2Authorization(ctx context.Context, userID, sessionID string, req *dto.OAuth2ConsentRequestDTO) (*dto.OAuth2RedirectDTO, error)
+    ExchangeOAuth2Token(ctx context.Context, auth *dto.OAuth2ClientAuthDTO, req *dto.OAuth2TokenRequestDTO) (*dto.OAuth2TokenResponseDTO, error)
+    OAuth2UserInfo(ctx context.Context, accessToken string) (map[string]interface{}, error)
+    IntrospectOAuth2Token(ctx context.Context, auth *dto.OAuth2ClientAuthDTO, token string) (*dto.OAuth2IntrospectionDTO, error)
+    RevokeOAuth2Token(ctx context.Context, auth *dto.OAuth2ClientAuthDTO, token string) error
+    CreateOAuthClient(ctx context.Context, createdBy string, req *dto.CreateOAuthClientRequestDTO) (*dto.OAuthClientSecretResponseDTO, error)
+    ListOAuthClients(ctx context.Context) ([]dto.OAuthClientResponseDTO, error)
+    GetOAuthClient(ctx context.Context, clientID string) (*dto.OAuthClientResponseDTO, error)
+    RotateOAuthClientSecret(ctx context.Context, clientID string) (*dto.OAuthClientSecretResponseDTO, error)
+    RevokeOAuthClient(ctx context.Context, clientID string) error
+    ListOAuthConsents(ctx context.Context, userID string) ([]dto.OAuthConsentResponseDTO, error)
+    RevokeOAuthConsent(ctx context.Context, userID, clientID string) error
 }
 
 // AuthService adalah implementasi dari AuthServiceInterface
@@ -63,46 +137,88 @@ type AuthService struct {
 	jwtSvc JWTService
 	emailSvc email.EmailService
 	validate *validator.Validate
+	// mfaIssuer ditampilkan oleh aplikasi authenticator sebagai nama layanan
+	mfaIssuer string
+	// revocations boleh nil; pemeriksaan pencabutan token langsung ke database
+	revocations *RevocationCache
+	// oauthProviders boleh nil; login OAuth/OIDC tidak tersedia
+	oauthProviders *oidc.Registry
+	// oidcProvider mengatur CMS sebagai penyedia OIDC; issuer kosong berarti tidak aktif
+	oidcProvider config.OIDCProviderConfig
+	// webAuthn boleh nil; passkey dan security key tidak tersedia
+	webAuthn *webauthn.RelyingParty
 }
 
 // NewAuthService membuat instance baru dari AuthService
-func NewAuthService(authRepo *repositories.AuthRepository, jwtSvc JWTService, emailSvc email.EmailService) *AuthService {
+func NewAuthService(authRepo *repositories.AuthRepository, jwtSvc JWTService, emailSvc email.EmailService, mfaIssuer string, revocations *RevocationCache, oauthProviders *oidc.Registry, oidcProvider config.OIDCProviderConfig, webAuthn *webauthn.RelyingParty) *AuthService {
 	return &AuthService{
 		authRepo: authRepo,
 		jwtSvc: jwtSvc,
 		emailSvc: emailSvc,
 		validate: validator.New(),
+		mfaIssuer: mfaIssuer,
+		revocations: revocations,
+		oauthProviders: oauthProviders,
+		oidcProvider: oidcProvider,
+		webAuthn: webAuthn,
 	}
 }
 
 // RegisterUser memproses logika registrasi pengguna baru
 func (s *AuthService) RegisterUser(ctx context.Context, req *dto.RegisterRequestDTO) error {
+	user, err := s.createUser(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// Buat dan simpan token verifikasi email, lalu kirim email
+	return s.issueVerificationToken(ctx, user, nil)
+}
+
+// CreateActiveUser membuat pengguna yang langsung aktif dengan email terverifikasi.
+// Dipakai oleh perintah CLI create-admin sehingga tidak ada email verifikasi yang dikirim.
+func (s *AuthService) CreateActiveUser(ctx context.Context, req *dto.RegisterRequestDTO) (*models.User, error) {
+	user, err := s.createUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authRepo.ActivateUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	user.Status = "active"
+	user.EmailVerified = true
+	return user, nil
+}
+
+// createUser memvalidasi input lalu menyimpan pengguna baru beserta profilnya dengan status pending
+func (s *AuthService) createUser(ctx context.Context, req *dto.RegisterRequestDTO) (*models.User, error) {
 	// 1. Validasi input menggunakan DTO
 	err := s.validate.Struct(req)
 	if err != nil {
-		return fmt.Errorf("validasi input gagal: %w", err)
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
 	}
 
 	// 2. Cek apakah email atau username sudah ada
 	existingUser, err := s.authRepo.FindUserByEmail(ctx, req.Email)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if existingUser != nil {
-		return ErrUserAlreadyExists
+		return nil, ErrUserAlreadyExists
 	}
 	existingUser, err = s.authRepo.FindUserByUsername(ctx, req.Username)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if existingUser != nil {
-		return ErrUserAlreadyExists
+		return nil, ErrUserAlreadyExists
 	}
 
 	// 3. Hashing password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return fmt.Errorf("gagal melakukan hashing password: %w", err)
+		return nil, fmt.Errorf("gagal melakukan hashing password: %w", err)
 	}
 	hashedPasswordStr := string(hashedPassword)
 
@@ -123,28 +239,32 @@ func (s *AuthService) RegisterUser(ctx context.Context, req *dto.RegisterRequest
 	// 5. Simpan user dan profile ke database
 	err = s.authRepo.SaveUser(ctx, user, profile)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return user, nil
+}
 
-	// 6. Buat dan simpan token verifikasi email, lalu kirim email
+// issueVerificationToken menyimpan token verifikasi email baru dan mengirimkannya di goroutine.
+// Token verifikasi lama diinvalidasi; jika limit diisi, *ThrottleError dikembalikan ketika
+// email sudah mencapai batas pengiriman.
+func (s *AuthService) issueVerificationToken(ctx context.Context, user *models.User, limit *repositories.TokenIssueLimit) error {
 	verificationToken := &models.EmailVerificationToken{
 		ID: uuid.New().String(),
 		UserID: user.ID,
 		Email:  user.Email,
 		Token:  uuid.New().String(),
-		TokenType: "email_verification",
-		ExpiresAt: time.Now().Add(time.Minute * 30),
+		TokenType: models.TokenTypeEmailVerification,
+		ExpiresAt: time.Now().Add(verificationTokenTTL),
 	}
 	
 	// Panggil metode repository untuk menyimpan token
-	err = s.authRepo.SaveVerificationToken(ctx, verificationToken)
-	if err != nil {
+	if err := s.saveEmailToken(ctx, verificationToken, limit); err != nil {
 		return err
-	}	
+	}
 
-	// 7. Kirim email verifikasi di goroutine
+	// Kirim email verifikasi di goroutine
 	go func(to, token, username string) {
-		err := s.emailSvc.SendVerificationEmail(user.Email, verificationToken.Token, user.Username)
+		err := s.emailSvc.SendVerificationEmail(to, token, username)
 		if err != nil {
 			log.Printf("Gagal mengirim email verifikasi ke %s: %v", to, err)
 		} else {
@@ -158,7 +278,7 @@ func (s *AuthService) RegisterUser(ctx context.Context, req *dto.RegisterRequest
 var emailRegex = regexp.MustCompile(`^[^\s]+$`)
 
 // LoginUser memproses logika login pengguna
-func (s *AuthService) LoginUser(ctx context.Context, req *dto.LoginRequestDTO, ip string) (*dto.AuthResponseDTO, error) {
+func (s *AuthService) LoginUser(ctx context.Context, req *dto.LoginRequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error) {
 	// 1. Validasi input
 	if err := s.validate.Struct(req); err != nil {
 		return nil, fmt.Errorf("validasi input gagal: %w", err)
@@ -173,12 +293,6 @@ func (s *AuthService) LoginUser(ctx context.Context, req *dto.LoginRequestDTO, i
 		user, err = s.authRepo.FindUserByUsername(ctx, req.Identifier)
 	}
 
-	// 🔍 DEBUG: Log nilai dari database
-    if user != nil {
-        log.Printf("DEBUG - User found: ID=%s, FailedAttempts=%d, LockedUntil=%v", 
-            user.ID, user.FailedLoginAttempts, user.LockedUntil)
-    }
-
 	if err != nil {
 		return nil, err
 	}
@@ -196,61 +310,40 @@ func (s *AuthService) LoginUser(ctx context.Context, req *dto.LoginRequestDTO, i
 
 	// 3. Bandingkan/Cek password
 	if err := bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(req.Password)); err != nil {
-    // DEBUG: Log sebelum increment
-	log.Printf("DEBUG - Password salah. Current FailedAttempts: %d", user.FailedLoginAttempts)
-	
-	// Hanya increment sekali
-    newFailedAttempts := user.FailedLoginAttempts + 1
-
-	// // 🔍 DEBUG: Log nilai yang akan di-update
-    //     log.Printf("DEBUG - Will update to: newFailedAttempts=%d", newFailedAttempts)
-    
-    // Cek apakah perlu lock
-    var lockUntil *time.Time
-    
-	if newFailedAttempts >= maxFailedAttempts {
-        lockedTime := time.Now().Add(lockoutDuration)
-        lockUntil = &lockedTime
-        log.Printf("SECURITY: Account %s locked from IP %s after %d failed attempts", user.ID, ip, newFailedAttempts)
-		log.Printf("SECURITY: Account locked until: %v", lockedTime)
-    }
-
-	if newFailedAttempts == 3 {
-		log.Printf("WARNING: User %s has %d failed attempts from IP %s", user.ID, newFailedAttempts, ip)
+		if lockErr := s.recordFailedLogin(ctx, user, ip); lockErr != nil {
+			return nil, lockErr
+		}
+		return nil, ErrInvalidCredentials
 	}
-    
-    // Update ke database
-    if errUpd := s.authRepo.UpdateUserLoginStatus(ctx, user.ID, ip, newFailedAttempts, lockUntil); errUpd != nil {
-        log.Printf("ERROR: UpdateUserLoginStatus gagal: %v", errUpd)
-        return nil, fmt.Errorf("failed to update login status: %w", errUpd)
-    }
-
-	// // 🔍 DEBUG: Konfirmasi update berhasil
-    //     log.Printf("DEBUG - UpdateUserLoginStatus SUCCESS for user %s with attempts=%d", user.ID, newFailedAttempts)
-    
-    // Return error yang sesuai
-    if lockUntil != nil {
-        return nil, &LockoutError{
-            Message: string(ErrUserLocked),
-            UnlockAt: *lockUntil,
-        }
-    }
-    	return nil, ErrInvalidCredentials
-	}
-	// Login berhasil: reset failed attempts
-	if err := s.authRepo.UpdateUserLoginStatus(ctx, user.ID, ip, 0, nil); err != nil {
+
+	// Tentukan apakah login masih membutuhkan langkah MFA
+	challenge, err := s.mfaChallenge(ctx, user)
+	if err != nil {
 		return nil, err
 	}
 
+	// Login berhasil: reset failed attempts. Jika masih ada langkah MFA, jumlah kegagalan
+	// baru direset setelah kode MFA valid agar kode tidak dapat ditebak tanpa batas.
+	if challenge == nil {
+		if err := s.authRepo.UpdateUserLoginStatus(ctx, user.ID, ip, 0, nil); err != nil {
+			return nil, err
+		}
+	}
+
 	// 4. Periksa status pengguna
 	if user.Status != "active" {
 		return nil, errors.New("akun tidak aktif, silakan verifikasi email")
 	}
 
-	// 5. Buat access token dan refresh token
-	tokenPair, err := s.jwtSvc.GenerateTokenPair(user.ID, user.Email)
+	if challenge != nil {
+		log.Printf("Pengguna %s:%s membutuhkan verifikasi MFA.", user.Username, user.Email)
+		return &dto.AuthResponseDTO{ID: user.ID, MFA: challenge}, nil
+	}
+
+	// 5. Buka sesi baru lalu buat access token dan refresh token
+	tokenPair, err := s.startSession(ctx, user, ip, userAgent)
 	if err != nil {
-		return nil, fmt.Errorf("gagal membuat token: %w", err)
+		return nil, err
 	}
 
 	data := &dto.AuthResponseDTO{
@@ -267,6 +360,40 @@ func (s *AuthService) LoginUser(ctx context.Context, req *dto.LoginRequestDTO, i
 	return data, nil
 }
 
+// recordFailedLogin menambah jumlah kegagalan login dan mengunci akun ketika batas tercapai.
+// Mengembalikan *LockoutError jika akun baru saja terkunci.
+func (s *AuthService) recordFailedLogin(ctx context.Context, user *models.User, ip string) error {
+	// Hanya increment sekali
+	newFailedAttempts := user.FailedLoginAttempts + 1
+
+	// Cek apakah perlu lock
+	var lockUntil *time.Time
+	if newFailedAttempts >= maxFailedAttempts {
+		lockedTime := time.Now().Add(lockoutDuration)
+		lockUntil = &lockedTime
+		log.Printf("SECURITY: Account %s locked from IP %s after %d failed attempts", user.ID, ip, newFailedAttempts)
+		log.Printf("SECURITY: Account locked until: %v", lockedTime)
+	}
+
+	if newFailedAttempts == 3 {
+		log.Printf("WARNING: User %s has %d failed attempts from IP %s", user.ID, newFailedAttempts, ip)
+	}
+
+	// Update ke database
+	if errUpd := s.authRepo.UpdateUserLoginStatus(ctx, user.ID, ip, newFailedAttempts, lockUntil); errUpd != nil {
+		log.Printf("ERROR: UpdateUserLoginStatus gagal: %v", errUpd)
+		return fmt.Errorf("failed to update login status: %w", errUpd)
+	}
+
+	if lockUntil != nil {
+		return &LockoutError{
+			Message:  string(ErrUserLocked),
+			UnlockAt: *lockUntil,
+		}
+	}
+	return nil
+}
+
 // VerifyEmail memproses logika verifikasi email
 func (s *AuthService) VerifyEmail(ctx context.Context, tokenStr string) error {
 	// 1. Cari token di database
@@ -278,7 +405,10 @@ func (s *AuthService) VerifyEmail(ctx context.Context, tokenStr string) error {
 		return ErrInvalidToken // Token tidak ditemukan
 	}
 
-	// 2. Cek apakah token sudah kedaluwarsa
+	// 2. Cek jenis token dan apakah token sudah kedaluwarsa
+	if token.TokenType != models.TokenTypeEmailVerification {
+		return ErrInvalidToken
+	}
 	if token.ExpiresAt.Before(time.Now()) {
 		return ErrInvalidToken
 	}
@@ -309,77 +439,96 @@ func (s *AuthService) VerifyEmail(ctx context.Context, tokenStr string) error {
 	return nil
 }
 
-// RefreshToken memproses permintaan untuk mendapatkan access token baru
-func (s *AuthService) RefreshToken(ctx context.Context, refreshTokenStr string) (*dto.AuthResponseDTO, error) {
+// RefreshToken memproses permintaan untuk mendapatkan access token baru. Setiap refresh
+// token hanya dapat dipakai sekali; refresh token lama yang dipakai ulang dianggap bocor
+// sehingga seluruh sesi (keluarga refresh token) dicabut.
+func (s *AuthService) RefreshToken(ctx context.Context, refreshTokenStr, ip, userAgent string) (*dto.AuthResponseDTO, error) {
 	// 1. Validasi refresh token secara sintaksis dan cek kedaluwarsa
 	token, err := s.jwtSvc.ValidateRefreshToken(refreshTokenStr)
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
 
-	// 2. Periksa apakah refresh token sudah dicabut (di-blacklist)
-	isRevoked, err := s.authRepo.IsTokenRevoked(ctx, refreshTokenStr)
-	if err != nil {
-		return nil, err
-	}
-	if isRevoked {
-		return nil, ErrInvalidToken // Tolak jika token sudah ada di daftar hitam
-	}
-
-	// 3. Ambil klaim dari token
+	// 2. Ambil klaim dari token
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
 		return nil, ErrInvalidToken
 	}
 
-	// 4. Periksa jenis token
+	// 3. Periksa jenis token
 	tokenType, ok := claims["token_type"].(string)
 	if !ok || tokenType != "refresh" {
 		return nil, ErrInvalidToken
 	}
 	
-	// 5. Ambil data user
-	userID, ok := claims["user_id"].(string)
-	if !ok {
+	// 4. Ambil data user, sesi dan jti. Refresh token tanpa sesi berasal dari versi lama
+	// dan harus login ulang.
+	userID, _ := claims["user_id"].(string)
+	sessionID, _ := claims["sid"].(string)
+	refreshTokenID, _ := claims["jti"].(string)
+	if userID == "" || sessionID == "" || refreshTokenID == "" {
 		return nil, ErrInvalidToken
 	}
-	email, ok := claims["email"].(string)
-	if !ok {
+
+	// Tolak refresh token yang dicabut lewat jti atau epoch token pengguna
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
 		return nil, ErrInvalidToken
 	}
-	
-	// 6. Dapatkan waktu kedaluwarsa token lama dari klaim
-	expiresAt, err := claims.GetExpirationTime()
+	isRevoked, err := s.IsTokenRevoked(ctx, refreshTokenID, userID, issuedAt.Time)
 	if err != nil {
+		return nil, err
+	}
+	if isRevoked {
 		return nil, ErrInvalidToken
 	}
 
-	// 7. Revoke (cabut) refresh token lama
-	err = s.authRepo.RevokeToken(ctx, refreshTokenStr, expiresAt.Time)
+	// 5. Pastikan sesi masih aktif dan refresh token ini adalah yang terbaru dari keluarganya
+	session, err := s.authRepo.FindSessionByID(ctx, sessionID)
 	if err != nil {
 		return nil, err
 	}
+	if session == nil || session.UserID != userID || !session.Active(time.Now()) {
+		return nil, ErrInvalidToken
+	}
+	if session.RefreshTokenID != refreshTokenID {
+		return nil, s.revokeReusedSession(ctx, session, ip)
+	}
 
-	// 8. Buat pasangan token baru
-	tokenPair, err := s.jwtSvc.GenerateTokenPair(userID, email)
+	// 6. Pastikan pengguna masih ada dan aktif
+	user, err := s.authRepo.FindUserByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("gagal membuat token baru: %w", err)
+		return nil, err
+	}
+	if user == nil || user.Status != "active" {
+		return nil, ErrInvalidToken
 	}
 
+	// 7. Rotasi refresh token. Jika permintaan lain sudah merotasi token yang sama lebih dulu,
+	// token ini juga dianggap dipakai ulang.
+	session.RefreshTokenID = uuid.New().String()
+	session.ExpiresAt = time.Now().Add(s.jwtSvc.RefreshTokenTTL())
+	rotated, err := s.authRepo.RotateSession(ctx, session.ID, refreshTokenID, session.RefreshTokenID,
+		optionalString(ip), optionalString(userAgent), session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, s.revokeReusedSession(ctx, session, ip)
+	}
 
-	user, err := s.authRepo.FindUserByEmail(ctx, email)
-	if err != nil || user == nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+	// 8. Buat pasangan token baru dengan role dan permission terbaru
+	tokenPair, err := s.generateTokenPair(ctx, user, session)
+	if err != nil {
+		return nil, err
 	}
 
 	responseDTO := &dto.AuthResponseDTO{
-		ID: userID,
+		ID: user.ID,
 		AccessToken: tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
 		TokenType: tokenPair.TokenType,
 		ExpiresIn: tokenPair.ExpiresIn,
-		// CreatedAt: time.Now().UTC(),
-		// UpdatedAt: time.Now().UTC(),
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -412,16 +561,283 @@ func (s *AuthService) LogoutUser(ctx context.Context, tokenStr string) error {
 		return ErrInvalidToken
 	}
 
-	// 3. Cabut token dan store ke database
-	err = s.authRepo.RevokeToken(ctx, tokenStr, expiresAt.Time)
+	// 3. Akhiri sesi perangkat ini sehingga refresh token-nya tidak dapat dipakai lagi
+	userID, _ := claims["user_id"].(string)
+	if sessionID, ok := claims["sid"].(string); ok && sessionID != "" {
+		if _, err := s.authRepo.RevokeSession(ctx, userID, sessionID, models.SessionRevokedLogout); err != nil {
+			return err
+		}
+	}
+
+	// 4. Cabut access token berdasarkan jti-nya
+	tokenID, ok := claims["jti"].(string)
+	if !ok || tokenID == "" {
+		return ErrInvalidToken
+	}
+	err = s.authRepo.RevokeToken(ctx, &models.RevokedToken{TokenID: tokenID, ExpiresAt: expiresAt.Time})
 	if err != nil {
 		return fmt.Errorf("gagal cabut token: %w", err)
 	}
+	s.revocations.AddToken(tokenID, expiresAt.Time)
 
 	return nil
 }
 
-// IsTokenRevoked adalah implementasi untuk service yang akan dipanggil oleh middleware
-func (s *AuthService) IsTokenRevoked(ctx context.Context, token string) (bool, error) {
-    return s.authRepo.IsTokenRevoked(ctx, token)
-}
\ No newline at end of file
+// LogoutAllSessions mencabut semua token milik pengguna yang sudah terbit, termasuk token
+// yang sedang dipakai, dan mengakhiri semua sesi login
+func (s *AuthService) LogoutAllSessions(ctx context.Context, userID string) error {
+	now := time.Now()
+	if err := s.authRepo.RevokeUserTokensBefore(ctx, userID, now, models.SessionRevokedLogoutAll); err != nil {
+		return err
+	}
+	s.revocations.SetUserEpoch(userID, now)
+	log.Printf("SECURITY: Semua token dan sesi milik pengguna %s dicabut", userID)
+	return nil
+}
+
+// SetUserTokenEpoch mencatat di cache pencabutan bahwa epoch token pengguna sudah dimajukan
+// di database, misalnya lewat repositories.RevokeUserAccessTx saat admin mengubah status akun.
+// Personal access token dan token OAuth ditolak lewat pemeriksaan status akun.
+func (s *AuthService) SetUserTokenEpoch(userID string, validAfter time.Time) {
+	s.revocations.SetUserEpoch(userID, validAfter)
+}
+
+// generateTokenPair membuat pasangan token untuk sesi yang membawa role dan permission
+// terkini milik pengguna
+func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User, session *models.Session) (*dto.AuthResponseDTO, error) {
+	authz, err := s.authRepo.FindUserAuthorization(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if authz == nil {
+		authz = &models.UserAuthorization{}
+	}
+
+	tokenPair, err := s.jwtSvc.GenerateTokenPair(TokenSubject{
+		UserID:         user.ID,
+		Email:          user.Email,
+		Roles:          authz.Roles,
+		Permissions:    authz.Permissions,
+		AuthzVersion:   authz.Version,
+		SessionID:      session.ID,
+		RefreshTokenID: session.RefreshTokenID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat token: %w", err)
+	}
+	return tokenPair, nil
+}
+
+// GetAuthzVersion mengembalikan versi otorisasi terbaru milik pengguna
+func (s *AuthService) GetAuthzVersion(ctx context.Context, userID string) (int64, error) {
+	return s.authRepo.GetAuthzVersion(ctx, userID)
+}
+
+// PublicKeys mengembalikan JWKS untuk layanan lain yang memverifikasi access token secara mandiri
+func (s *AuthService) PublicKeys() jwk.JSONWebKeySet {
+	return s.jwtSvc.JWKS()
+}
+
+// IsTokenRevoked adalah implementasi untuk service yang akan dipanggil oleh middleware.
+// Token dianggap dicabut jika jti-nya dicabut atau terbit sebelum epoch token pengguna.
+// Cache pencabutan dipakai jika tersedia dan siap; selain itu pemeriksaan ke database.
+func (s *AuthService) IsTokenRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error) {
+	if revoked, ok := s.revocations.IsRevoked(tokenID, userID, issuedAt); ok {
+		return revoked, nil
+	}
+	return s.authRepo.IsTokenRevoked(ctx, tokenID, userID, issuedAt)
+}
+
+// ForgotPassword membuat token reset password dan mengirimkannya ke email pengguna.
+// Fungsi ini tidak mengembalikan error jika email tidak terdaftar agar keberadaan akun tidak bocor.
+func (s *AuthService) ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequestDTO) error {
+	// 1. Validasi input
+	if err := s.validate.Struct(req); err != nil {
+		return fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	// 2. Cari user berdasarkan email
+	user, err := s.authRepo.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		log.Printf("Permintaan reset password untuk email yang tidak terdaftar: %s", req.Email)
+		return nil
+	}
+
+	// 3. Terbitkan token reset password dan kirim emailnya. Permintaan yang terlalu sering
+	// diabaikan tanpa error agar respons tetap seragam.
+	if err := s.issuePasswordResetToken(ctx, user, emailIssueLimit); err != nil {
+		var throttleErr *ThrottleError
+		if errors.As(err, &throttleErr) {
+			log.Printf("WARNING: Permintaan reset password untuk %s dibatasi hingga %v", user.Email, throttleErr.RetryAt)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// ForcePasswordReset dipakai admin untuk memaksa pengguna membuat password baru. Password
+// lama dihapus, semua token dan sesi login dicabut, lalu tautan reset dikirim ke email
+// pengguna. Login tanpa password (passkey, OAuth, magic link) tidak terpengaruh.
+func (s *AuthService) ForcePasswordReset(ctx context.Context, userID string) error {
+	user, err := s.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrInvalidToken
+	}
+
+	now := time.Now()
+	if err := s.authRepo.ClearUserPassword(ctx, user.ID, now); err != nil {
+		return err
+	}
+	s.revocations.SetUserEpoch(user.ID, now)
+	log.Printf("SECURITY: Password pengguna %s dihapus, pengguna wajib membuat password baru", user.ID)
+
+	return s.issuePasswordResetToken(ctx, user, nil)
+}
+
+// issuePasswordResetToken menerbitkan token reset password dan mengirim emailnya.
+// Token reset sebelumnya diinvalidasi agar hanya tautan terbaru yang berlaku; jika limit diisi,
+// *ThrottleError dikembalikan ketika email sudah mencapai batas pengiriman. Database hanya
+// menyimpan hash token seperti magic link, token mentah hanya dikirim lewat email.
+func (s *AuthService) issuePasswordResetToken(ctx context.Context, user *models.User, limit *repositories.TokenIssueLimit) error {
+	rawToken, err := oidc.RandomString()
+	if err != nil {
+		return err
+	}
+
+	// Buat dan simpan token reset password
+	resetToken := &models.EmailVerificationToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		Token:     hashAPIToken(rawToken),
+		TokenType: models.TokenTypePasswordReset,
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	if err := s.saveEmailToken(ctx, resetToken, limit); err != nil {
+		return err
+	}
+
+	// Kirim email reset password di goroutine
+	go func(to, token, username string) {
+		err := s.emailSvc.SendPasswordResetEmail(to, token, username)
+		if err != nil {
+			log.Printf("Gagal mengirim email reset password ke %s: %v", to, err)
+		} else {
+			log.Printf("Email reset password berhasil dikirim ke %s", to)
+		}
+	}(user.Email, rawToken, user.Username)
+
+	return nil
+}
+
+// ResetPassword mengganti password pengguna menggunakan token reset yang valid
+func (s *AuthService) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequestDTO) error {
+	// 1. Validasi input
+	if err := s.validate.Struct(req); err != nil {
+		return fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	// 2. Cari token berdasarkan hash-nya dan pastikan jenisnya password_reset
+	token, err := s.authRepo.FindVerificationToken(ctx, hashAPIToken(req.Token))
+	if err != nil {
+		return err
+	}
+	if token == nil || token.TokenType != models.TokenTypePasswordReset {
+		return ErrInvalidToken
+	}
+
+	// 3. Cek kedaluwarsa dan pemakaian token
+	if token.UsedAt != nil {
+		return ErrTokenAlreadyUsed
+	}
+	if token.ExpiresAt.Before(time.Now()) {
+		return ErrInvalidToken
+	}
+
+	// 4. Hashing password baru
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("gagal melakukan hashing password: %w", err)
+	}
+
+	// 5. Simpan password, buka kunci akun dan cabut semua token lain
+	err = s.authRepo.ResetPassword(ctx, token.UserID, token.Token, string(hashedPassword))
+	if err != nil {
+		if errors.Is(err, repositories.ErrTokenNotUsable) {
+			return ErrTokenAlreadyUsed
+		}
+		return err
+	}
+
+	log.Printf("SECURITY: Password untuk pengguna %s berhasil direset", token.UserID)
+	return nil
+}
+
+// ResendVerification menerbitkan ulang token verifikasi email untuk akun yang masih pending.
+// Token verifikasi lama yang belum dipakai akan diinvalidasi.
+func (s *AuthService) ResendVerification(ctx context.Context, req *dto.ResendVerificationRequestDTO) error {
+	// 1. Validasi input
+	if err := s.validate.Struct(req); err != nil {
+		return fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	// 2. Cari user, abaikan jika tidak ada atau sudah terverifikasi
+	user, err := s.authRepo.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Status != "pending" || user.EmailVerified {
+		log.Printf("Permintaan kirim ulang verifikasi diabaikan untuk %s", req.Email)
+		return nil
+	}
+
+	// 3. Invalidasi token verifikasi lama lalu terbitkan yang baru dengan cooldown dan batas
+	// harian per email. Permintaan yang dibatasi diabaikan tanpa error agar respons sama untuk
+	// semua email dan tidak membocorkan email yang terdaftar.
+	if err := s.issueVerificationToken(ctx, user, emailIssueLimit); err != nil {
+		var throttleErr *ThrottleError
+		if errors.As(err, &throttleErr) {
+			log.Printf("WARNING: Permintaan kirim ulang verifikasi untuk %s dibatasi hingga %v", user.Email, throttleErr.RetryAt)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// saveEmailToken menyimpan token email lewat repository. Pemeriksaan batas dan penyimpanan
+// berjalan dalam satu transaksi; jika token dibatasi, *ThrottleError dikembalikan dengan waktu
+// permintaan berikutnya diperbolehkan.
+func (s *AuthService) saveEmailToken(ctx context.Context, token *models.EmailVerificationToken, limit *repositories.TokenIssueLimit) error {
+	stats, issued, err := s.authRepo.IssueVerificationToken(ctx, token, limit)
+	if err != nil {
+		return err
+	}
+	if issued {
+		return nil
+	}
+
+	if stats.LastIssuedAt != nil && time.Since(*stats.LastIssuedAt) < limit.Cooldown {
+		return &ThrottleError{
+			Message: "Please wait before requesting another email",
+			RetryAt: stats.LastIssuedAt.Add(limit.Cooldown),
+		}
+	}
+
+	// Kuota kembali tersedia ketika email tertua dalam jendela 24 jam keluar dari jendela
+	retryAt := time.Now().Add(24 * time.Hour)
+	if stats.FirstIssuedAt != nil {
+		retryAt = stats.FirstIssuedAt.Add(24 * time.Hour)
+	}
+	return &ThrottleError{
+		Message: "Daily email limit reached, please try again later",
+		RetryAt: retryAt,
+	}
+}
@@ -1,69 +1,131 @@
 package services
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jokosaputro95/cms-go/config"
 	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/pkg/jwk"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // JWTService mendefinisikan kontrak untuk layanan JWT
 type JWTService interface {
-	GenerateTokenPair(userID, email string) (*dto.AuthResponseDTO, error)
+	GenerateTokenPair(subject TokenSubject) (*dto.AuthResponseDTO, error)
 	ValidateAccessToken(tokenStr string) (*jwt.Token, error)
 	ValidateRefreshToken(tokenStr string) (*jwt.Token, error)
+	GenerateMFAToken(userID string, ttl time.Duration) (string, error)
+	ValidateMFAToken(tokenStr string) (string, error)
+	RefreshTokenTTL() time.Duration
+	JWKS() jwk.JSONWebKeySet
+	SignIDToken(claims jwt.Claims) (string, error)
 }
 
-// jwtCustomClaims menyimpan data custom yang akan dimasukkan ke dalam JWT
+// TokenSubject berisi data pengguna yang dimasukkan ke dalam token
+type TokenSubject struct {
+	UserID       string
+	Email        string
+	Roles        []string
+	Permissions  []string
+	AuthzVersion int64
+	// SessionID adalah ID keluarga refresh token (klaim sid) yang dibawa oleh kedua token
+	SessionID string
+	// RefreshTokenID menjadi klaim jti refresh token dan dicatat sebagai jti terbaru sesi
+	RefreshTokenID string
+}
+
+// jwtCustomClaims menyimpan data custom yang akan dimasukkan ke dalam JWT.
+// Roles, Permissions dan AuthzVersion hanya diisi pada access token.
 type jwtCustomClaims struct {
-	UserID    string `json:"user_id"`
-	Email     string `json:"email"`
-	TokenType string `json:"token_type"`
+	UserID       string   `json:"user_id"`
+	Email        string   `json:"email"`
+	TokenType    string   `json:"token_type"`
+	Roles        []string `json:"roles,omitempty"`
+	Permissions  []string `json:"permissions,omitempty"`
+	AuthzVersion int64    `json:"authz_ver,omitempty"`
+	SessionID    string   `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// jwtService adalah implementasi dari JWTService
+// jwtService adalah implementasi dari JWTService. Access token ditandatangani dengan kunci
+// asimetris dari keys jika dikonfigurasi, atau HS256 dengan JWTSecret jika keys nil.
+// Refresh token dan token tantangan MFA hanya diverifikasi oleh layanan ini sehingga
+// tetap memakai HS256.
 type jwtService struct {
-	cfg *config.Config
+	cfg  *config.Config
+	keys *jwk.Set
 }
 
-// NewJWTService membuat instance baru dari jwtService
-func NewJWTService(cfg *config.Config) JWTService {
-	return &jwtService{cfg: cfg}
+// NewJWTService membuat instance baru dari jwtService dan memuat kunci penandatanganan
+// dari file PEM yang dikonfigurasi
+func NewJWTService(cfg *config.Config) (JWTService, error) {
+	s := &jwtService{cfg: cfg}
+	if cfg.JWT.JWTSigningKeyFile == "" {
+		if cfg.JWT.JWTVerificationKeyFiles != "" {
+			return nil, errors.New("JWT_VERIFICATION_KEY_FILES membutuhkan JWT_SIGNING_KEY_FILE")
+		}
+		return s, nil
+	}
+
+	signing, err := jwk.LoadFile(cfg.JWT.JWTSigningKeyFile, cfg.JWT.JWTSigningKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memuat kunci penandatanganan JWT: %w", err)
+	}
+	verification, err := jwk.LoadFiles(cfg.JWT.JWTVerificationKeyFiles)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memuat kunci verifikasi JWT: %w", err)
+	}
+	if s.keys, err = jwk.NewSet(signing, verification...); err != nil {
+		return nil, fmt.Errorf("kunci JWT tidak valid: %w", err)
+	}
+	return s, nil
 }
 
 // GenerateTokenPair membuat access token dan refresh token
-func (s *jwtService) GenerateTokenPair(userID, email string) (*dto.AuthResponseDTO, error) {
+func (s *jwtService) GenerateTokenPair(subject TokenSubject) (*dto.AuthResponseDTO, error) {
+	now := time.Now()
+
 	// Buat access token
 	accessClaims := &jwtCustomClaims{
-		UserID:    userID,
-		Email:     email,
-		TokenType: "access",
+		UserID:       subject.UserID,
+		Email:        subject.Email,
+		TokenType:    "access",
+		Roles:        subject.Roles,
+		Permissions:  subject.Permissions,
+		AuthzVersion: subject.AuthzVersion,
+		SessionID:    subject.SessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * time.Duration(s.cfg.JWT.JWTExpiresIn))),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "cms-go",
+			ID:        uuid.New().String(),
+			Subject:   subject.UserID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.cfg.JWT.JWTExpiresIn)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    s.cfg.JWT.JWTIssuer,
 		},
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	signedAccessToken, err := accessToken.SignedString([]byte(s.cfg.JWT.JWTSecret))
+	signedAccessToken, err := s.signAccessToken(accessClaims)
 	if err != nil {
 		return nil, fmt.Errorf("gagal menandatangani access token: %w", err)
 	}
 
 	// Buat refresh token
 	refreshClaims := &jwtCustomClaims{
-		UserID:    userID,
-		Email:     email,
+		UserID:    subject.UserID,
+		Email:     subject.Email,
 		TokenType: "refresh",
+		SessionID: subject.SessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * time.Duration(s.cfg.JWT.JWTRefreshExpiresIn))),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "cms-go",
+			ID:        subject.RefreshTokenID,
+			Subject:   subject.UserID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.cfg.JWT.JWTRefreshExpiresIn)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    s.cfg.JWT.JWTIssuer,
 		},
 	}
 
@@ -81,14 +143,72 @@ func (s *jwtService) GenerateTokenPair(userID, email string) (*dto.AuthResponseD
 	}, nil
 }
 
-// ValidateAccessToken memvalidasi access token menggunakan secret key
+// RefreshTokenTTL mengembalikan masa berlaku refresh token, dipakai juga sebagai masa berlaku sesi
+func (s *jwtService) RefreshTokenTTL() time.Duration {
+	return s.cfg.JWT.JWTRefreshExpiresIn
+}
+
+// JWKS mengembalikan kunci publik untuk memverifikasi access token. Set kosong jika access
+// token masih ditandatangani HS256.
+func (s *jwtService) JWKS() jwk.JSONWebKeySet {
+	if s.keys == nil {
+		return jwk.JSONWebKeySet{Keys: []jwk.JSONWebKey{}}
+	}
+	return s.keys.JWKS()
+}
+
+// SignIDToken menandatangani ID token OpenID Connect dengan kunci yang sama dengan access
+// token. Client memverifikasinya lewat JWKS sehingga kunci asimetris wajib dikonfigurasi.
+// ID token tidak membawa token_type access sehingga tidak diterima sebagai access token.
+func (s *jwtService) SignIDToken(claims jwt.Claims) (string, error) {
+	if s.keys == nil {
+		return "", errors.New("ID token membutuhkan JWT_SIGNING_KEY_FILE")
+	}
+	return s.signAccessToken(claims)
+}
+
+// signAccessToken menandatangani klaim access token dengan kunci aktif dan menambahkan
+// header kid agar verifier dapat memilih kunci yang tepat dari JWKS
+func (s *jwtService) signAccessToken(claims jwt.Claims) (string, error) {
+	if s.keys == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.JWT.JWTSecret))
+	}
+
+	key := s.keys.SigningKey()
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// ValidateAccessToken memvalidasi access token. Token asimetris diverifikasi dengan kunci
+// yang cocok dengan header kid, termasuk kunci lama yang masih dalam masa rotasi.
 func (s *jwtService) ValidateAccessToken(tokenStr string) (*jwt.Token, error) {
-	return jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+	return jwt.Parse(tokenStr, s.accessTokenKey,
+		jwt.WithValidMethods([]string{jwk.AlgRS256, jwk.AlgEdDSA, jwt.SigningMethodHS256.Alg()}))
+}
+
+// accessTokenKey memilih kunci verifikasi access token. Algoritma pada header harus sama
+// dengan algoritma kunci agar public key tidak pernah dipakai sebagai secret HMAC.
+func (s *jwtService) accessTokenKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if s.keys != nil && !s.cfg.JWT.JWTAcceptHS256 {
 			return nil, fmt.Errorf("metode penandatanganan tidak valid: %v", token.Header["alg"])
 		}
 		return []byte(s.cfg.JWT.JWTSecret), nil
-	})
+	}
+
+	if s.keys == nil {
+		return nil, fmt.Errorf("metode penandatanganan tidak valid: %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+	key := s.keys.Find(kid)
+	if key == nil {
+		return nil, fmt.Errorf("kid tidak dikenal: %q", kid)
+	}
+	if key.Algorithm != token.Method.Alg() {
+		return nil, fmt.Errorf("metode penandatanganan tidak cocok dengan kunci %s: %v", kid, token.Header["alg"])
+	}
+	return key.Public, nil
 }
 
 // ValidateRefreshToken memvalidasi refresh token menggunakan refresh secret key
@@ -99,4 +219,56 @@ func (s *jwtService) ValidateRefreshToken(tokenStr string) (*jwt.Token, error) {
 		}
 		return []byte(s.cfg.JWT.JWTRefreshSecret), nil
 	})
-}
\ No newline at end of file
+}
+
+// mfaTokenType adalah token_type untuk token tantangan MFA
+const mfaTokenType = "mfa_challenge"
+
+// mfaSigningKey diturunkan dari secret access token sehingga token tantangan MFA tidak
+// pernah lolos validasi sebagai access token meskipun memakai algoritma yang sama
+func (s *jwtService) mfaSigningKey() []byte {
+	mac := hmac.New(sha256.New, []byte(s.cfg.JWT.JWTSecret))
+	mac.Write([]byte(mfaTokenType))
+	return mac.Sum(nil)
+}
+
+// GenerateMFAToken membuat token berumur pendek yang membuktikan password sudah benar
+// dan hanya dapat ditukar dengan token pair setelah kode MFA valid dikirim
+func (s *jwtService) GenerateMFAToken(userID string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := &jwtCustomClaims{
+		UserID:    userID,
+		TokenType: mfaTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    s.cfg.JWT.JWTIssuer,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(s.mfaSigningKey())
+	if err != nil {
+		return "", fmt.Errorf("gagal menandatangani token MFA: %w", err)
+	}
+	return signed, nil
+}
+
+// ValidateMFAToken memvalidasi token tantangan MFA dan mengembalikan ID pengguna
+func (s *jwtService) ValidateMFAToken(tokenStr string) (string, error) {
+	claims := &jwtCustomClaims{}
+	_, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("metode penandatanganan tidak valid: %v", token.Header["alg"])
+		}
+		return s.mfaSigningKey(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if claims.TokenType != mfaTokenType || claims.UserID == "" {
+		return "", fmt.Errorf("jenis token tidak valid: %s", claims.TokenType)
+	}
+	return claims.UserID, nil
+}
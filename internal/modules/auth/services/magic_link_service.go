@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+	"github.com/jokosaputro95/cms-go/internal/pkg/oidc"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ErrMagicLinkBrowserMismatch = AuthServiceError("tautan login harus dibuka di browser yang memintanya")
+
+	// MagicLinkTTL adalah masa berlaku tautan login yang dikirim lewat email
+	MagicLinkTTL = 15 * time.Minute
+)
+
+// RequestMagicLink mengirim tautan login sekali pakai ke email pengguna aktif. Nonce selalu
+// dikembalikan, juga untuk email yang tidak terdaftar, agar keberadaan akun tidak bocor.
+// Hanya hash token dan nonce yang disimpan.
+func (s *AuthService) RequestMagicLink(ctx context.Context, req *dto.MagicLinkRequestDTO) (*dto.MagicLinkResponseDTO, error) {
+	// 1. Validasi input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	response := &dto.MagicLinkResponseDTO{Nonce: nonce}
+
+	// 2. Cari user; akun yang belum aktif atau sedang terkunci tidak dikirimi tautan
+	user, err := s.authRepo.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != "active" {
+		log.Printf("Permintaan magic link diabaikan untuk %s", req.Email)
+		return response, nil
+	}
+	if user.LockedUntil != nil && user.LockedUntil.After(time.Now().UTC()) {
+		log.Printf("WARNING: Permintaan magic link untuk akun terkunci %s diabaikan", user.ID)
+		return response, nil
+	}
+
+	// 3. Hanya tautan terbaru yang berlaku. Permintaan yang terlalu sering diabaikan tanpa
+	// error agar respons tetap seragam.
+	linkToken, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	nonceHash := hashAPIToken(nonce)
+	magicLink := &models.EmailVerificationToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		Token:     hashAPIToken(linkToken),
+		TokenType: models.TokenTypeMagicLink,
+		ExpiresAt: time.Now().Add(MagicLinkTTL),
+		NonceHash: &nonceHash,
+	}
+	if err := s.saveEmailToken(ctx, magicLink, emailIssueLimit); err != nil {
+		var throttleErr *ThrottleError
+		if errors.As(err, &throttleErr) {
+			log.Printf("WARNING: Permintaan magic link untuk %s dibatasi hingga %v", user.Email, throttleErr.RetryAt)
+			return response, nil
+		}
+		return nil, err
+	}
+
+	// 4. Kirim email magic link di goroutine
+	go func(to, token, username string) {
+		err := s.emailSvc.SendMagicLinkEmail(to, token, username)
+		if err != nil {
+			log.Printf("Gagal mengirim magic link ke %s: %v", to, err)
+		} else {
+			log.Printf("Magic link berhasil dikirim ke %s", to)
+		}
+	}(user.Email, linkToken, user.Username)
+
+	return response, nil
+}
+
+// LoginWithMagicLink memakai token dari magic link lalu menerbitkan token pair seperti login
+// dengan password. Nonce harus berasal dari browser yang meminta tautan. Status akun dan
+// penguncian akun tetap berlaku, begitu juga langkah MFA.
+func (s *AuthService) LoginWithMagicLink(ctx context.Context, req *dto.MagicLinkLoginRequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error) {
+	// 1. Validasi input
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	// 2. Cari token dan pastikan jenisnya magic_link
+	tokenHash := hashAPIToken(req.Token)
+	token, err := s.authRepo.FindVerificationToken(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.TokenType != models.TokenTypeMagicLink {
+		return nil, ErrInvalidToken
+	}
+	if token.UsedAt != nil {
+		return nil, ErrTokenAlreadyUsed
+	}
+	if token.ExpiresAt.Before(time.Now()) {
+		return nil, ErrInvalidToken
+	}
+
+	// 3. Tautan yang dibuka di browser lain tidak dipakai agar masih bisa dibuka di browser asal
+	if token.NonceHash == nil || subtle.ConstantTimeCompare([]byte(hashAPIToken(req.Nonce)), []byte(*token.NonceHash)) != 1 {
+		log.Printf("SECURITY: Magic link pengguna %s dibuka dari browser lain (IP %s)", token.UserID, ip)
+		return nil, ErrMagicLinkBrowserMismatch
+	}
+
+	// 4. Tautan hanya berlaku untuk alamat email pengguna saat tautan dikirim
+	user, err := s.authRepo.FindUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Email != token.Email {
+		return nil, ErrInvalidToken
+	}
+	if user.LockedUntil != nil && user.LockedUntil.After(time.Now().UTC()) {
+		return nil, &LockoutError{
+			Message:  string(ErrUserLocked),
+			UnlockAt: *user.LockedUntil,
+		}
+	}
+	if user.Status != "active" {
+		return nil, ErrAccountInactive
+	}
+
+	// 5. Tandai token terpakai sebelum menerbitkan token agar tautan tidak dapat dipakai dua kali
+	used, err := s.authRepo.UseVerificationToken(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrTokenAlreadyUsed
+	}
+
+	// 6. Magic link menggantikan password, bukan langkah MFA
+	challenge, err := s.mfaChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		log.Printf("Pengguna %s:%s login lewat magic link dan membutuhkan verifikasi MFA.", user.Username, user.Email)
+		return &dto.AuthResponseDTO{ID: user.ID, MFA: challenge}, nil
+	}
+
+	if err := s.authRepo.UpdateUserLoginStatus(ctx, user.ID, ip, 0, nil); err != nil {
+		return nil, err
+	}
+	tokenPair, err := s.startSession(ctx, user, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Pengguna %s:%s berhasil login lewat magic link.", user.Username, user.Email)
+	return &dto.AuthResponseDTO{
+		ID:           user.ID,
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		TokenType:    tokenPair.TokenType,
+		ExpiresIn:    tokenPair.ExpiresIn,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,451 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
+	"github.com/jokosaputro95/cms-go/internal/pkg/totp"
+)
+
+const (
+	ErrInvalidMFACode    = AuthServiceError("kode MFA tidak valid")
+	ErrInvalidMFAToken   = AuthServiceError("token MFA tidak valid atau kedaluwarsa")
+	ErrMFANotEnabled     = AuthServiceError("MFA belum diaktifkan")
+	ErrMFAAlreadyEnabled = AuthServiceError("MFA sudah aktif")
+	ErrMFASetupMissing   = AuthServiceError("TOTP belum disiapkan, lakukan setup terlebih dahulu")
+	ErrMFARequiredByRole = AuthServiceError("MFA diwajibkan oleh role pengguna")
+	ErrMFANotRequired    = AuthServiceError("pendaftaran MFA saat login hanya untuk role yang mewajibkan MFA")
+	ErrTOTPCodeRequired  = AuthServiceError("kode dari aplikasi authenticator wajib diisi")
+
+	mfaChallengeTTL = 5 * time.Minute
+	// Toleransi satu periode (30 detik) sebelum dan sesudah untuk selisih jam perangkat
+	totpSkew          = 1
+	recoveryCodeCount = 10
+
+	MFAMethodTOTP         = "totp"
+	MFAMethodRecoveryCode = "recovery_code"
+	MFAMethodWebAuthn     = "webauthn"
+)
+
+// recoveryCodeAlphabet tidak memuat karakter yang mudah tertukar seperti 0/o dan 1/l
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// GetMFAStatus mengembalikan status MFA pengguna yang sedang login
+func (s *AuthService) GetMFAStatus(ctx context.Context, userID string) (*dto.MFAStatusResponseDTO, error) {
+	mfa, err := s.authRepo.FindUserMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	authz, err := s.authRepo.FindUserAuthorization(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &dto.MFAStatusResponseDTO{
+		Enabled:  mfa.Enabled(),
+		Required: authz != nil && authz.MFARequired,
+	}
+	status.WebAuthnCredentials, err = s.authRepo.CountWebAuthnCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.Enabled() {
+		status.ConfirmedAt = mfa.ConfirmedAt
+		status.RemainingRecoveryCodes, err = s.authRepo.CountUnusedRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// SetupTOTP membuat secret TOTP baru yang belum aktif sampai dikonfirmasi dengan ConfirmTOTP.
+// Memanggil ulang sebelum konfirmasi akan mengganti secret sebelumnya.
+func (s *AuthService) SetupTOTP(ctx context.Context, userID string) (*dto.TOTPSetupResponseDTO, error) {
+	user, err := s.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidToken
+	}
+	return s.setupTOTP(ctx, user)
+}
+
+// ConfirmTOTP mengaktifkan MFA setelah pengguna membuktikan aplikasi authenticator sudah
+// menyimpan secret, lalu mengembalikan kode pemulihan yang hanya ditampilkan sekali
+func (s *AuthService) ConfirmTOTP(ctx context.Context, userID string, req *dto.MFACodeRequestDTO) (*dto.RecoveryCodesResponseDTO, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+	if req.Code == "" {
+		return nil, ErrTOTPCodeRequired
+	}
+
+	codes, err := s.confirmTOTP(ctx, userID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("SECURITY: MFA diaktifkan untuk pengguna %s", userID)
+	return &dto.RecoveryCodesResponseDTO{RecoveryCodes: codes}, nil
+}
+
+// DisableMFA menonaktifkan MFA setelah kode TOTP atau kode pemulihan diverifikasi.
+// Pengguna dengan role yang mewajibkan MFA hanya dapat menonaktifkan TOTP jika masih
+// memiliki passkey atau security key sebagai faktor kedua.
+func (s *AuthService) DisableMFA(ctx context.Context, userID string, req *dto.MFACodeRequestDTO) error {
+	if err := s.validate.Struct(req); err != nil {
+		return fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	authz, err := s.authRepo.FindUserAuthorization(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if authz != nil && authz.MFARequired {
+		hasWebAuthn, err := s.hasWebAuthnCredentials(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !hasWebAuthn {
+			return ErrMFARequiredByRole
+		}
+	}
+
+	mfa, err := s.authRepo.FindUserMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.verifySecondFactor(ctx, userID, mfa, req.Code, req.RecoveryCode); err != nil {
+		return err
+	}
+
+	if err := s.authRepo.DeleteUserMFA(ctx, userID); err != nil {
+		return err
+	}
+	log.Printf("SECURITY: MFA dinonaktifkan untuk pengguna %s", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes mengganti seluruh kode pemulihan setelah kode MFA diverifikasi
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID string, req *dto.MFACodeRequestDTO) (*dto.RecoveryCodesResponseDTO, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	mfa, err := s.authRepo.FindUserMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifySecondFactor(ctx, userID, mfa, req.Code, req.RecoveryCode); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	log.Printf("SECURITY: Kode pemulihan MFA diganti untuk pengguna %s", userID)
+	return &dto.RecoveryCodesResponseDTO{RecoveryCodes: codes}, nil
+}
+
+// EnrollMFA menyiapkan TOTP bagi pengguna yang role-nya mewajibkan MFA tetapi belum
+// mendaftar. Token tantangan dari login menggantikan access token yang belum diterbitkan.
+// Pengguna yang sudah memiliki passkey harus memakainya, bukan mendaftarkan TOTP baru.
+func (s *AuthService) EnrollMFA(ctx context.Context, req *dto.EnrollMFARequestDTO) (*dto.TOTPSetupResponseDTO, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	user, err := s.userFromMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	authz, err := s.authRepo.FindUserAuthorization(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if authz == nil || !authz.MFARequired {
+		return nil, ErrMFANotRequired
+	}
+	hasWebAuthn, err := s.hasWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if hasWebAuthn {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	return s.setupTOTP(ctx, user)
+}
+
+// VerifyMFA menyelesaikan login dua langkah dan menerbitkan token pair. Jika pengguna sedang
+// mendaftar lewat EnrollMFA, kode TOTP pertama sekaligus mengonfirmasi pendaftaran dan
+// kode pemulihan dikembalikan bersama token. Assertion WebAuthn dari BeginWebAuthnMFA juga
+// diterima sebagai langkah kedua. Kode atau assertion yang salah dihitung sebagai login gagal.
+func (s *AuthService) VerifyMFA(ctx context.Context, req *dto.VerifyMFARequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	user, err := s.userFromMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+	if user.LockedUntil != nil && user.LockedUntil.After(time.Now().UTC()) {
+		return nil, &LockoutError{
+			Message:  string(ErrUserLocked),
+			UnlockAt: *user.LockedUntil,
+		}
+	}
+
+	mfa, err := s.authRepo.FindUserMFA(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	switch {
+	case req.WebAuthn != nil:
+		err = s.verifyWebAuthnSecondFactor(ctx, user.ID, req.WebAuthn)
+	case mfa.Enabled():
+		err = s.verifySecondFactor(ctx, user.ID, mfa, req.Code, req.RecoveryCode)
+	default:
+		// Pendaftaran saat login hanya untuk pengguna tanpa faktor kedua dan hanya dapat
+		// diselesaikan dengan kode TOTP
+		var hasWebAuthn bool
+		if hasWebAuthn, err = s.hasWebAuthnCredentials(ctx, user.ID); err != nil {
+			return nil, err
+		}
+		if hasWebAuthn {
+			return nil, ErrInvalidMFACode
+		}
+		if req.Code == "" {
+			return nil, ErrTOTPCodeRequired
+		}
+		recoveryCodes, err = s.confirmTOTP(ctx, user.ID, req.Code)
+	}
+	if err != nil {
+		var invalid error
+		switch {
+		case errors.Is(err, ErrInvalidMFACode):
+			invalid = ErrInvalidMFACode
+		case errors.Is(err, ErrInvalidWebAuthnResponse):
+			invalid = ErrInvalidWebAuthnResponse
+		default:
+			return nil, err
+		}
+		if lockErr := s.recordFailedLogin(ctx, user, ip); lockErr != nil {
+			return nil, lockErr
+		}
+		return nil, invalid
+	}
+
+	if err := s.authRepo.UpdateUserLoginStatus(ctx, user.ID, ip, 0, nil); err != nil {
+		return nil, err
+	}
+
+	tokenPair, err := s.startSession(ctx, user, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Pengguna %s:%s berhasil login dengan MFA.", user.Username, user.Email)
+	return &dto.AuthResponseDTO{
+		ID:            user.ID,
+		AccessToken:   tokenPair.AccessToken,
+		RefreshToken:  tokenPair.RefreshToken,
+		TokenType:     tokenPair.TokenType,
+		ExpiresIn:     tokenPair.ExpiresIn,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// mfaChallenge menentukan apakah login membutuhkan langkah MFA. Hasil nil berarti token
+// pair dapat langsung diterbitkan. Passkey atau security key yang terdaftar juga berlaku
+// sebagai faktor kedua untuk login dengan password.
+func (s *AuthService) mfaChallenge(ctx context.Context, user *models.User) (*dto.MFAChallengeDTO, error) {
+	mfa, err := s.authRepo.FindUserMFA(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	hasWebAuthn, err := s.hasWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &dto.MFAChallengeDTO{ExpiresIn: int64(mfaChallengeTTL.Seconds())}
+	if mfa.Enabled() {
+		challenge.Methods = []string{MFAMethodTOTP, MFAMethodRecoveryCode}
+	}
+	if hasWebAuthn {
+		challenge.Methods = append(challenge.Methods, MFAMethodWebAuthn)
+	}
+	if len(challenge.Methods) == 0 {
+		authz, err := s.authRepo.FindUserAuthorization(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if authz == nil || !authz.MFARequired {
+			return nil, nil
+		}
+		challenge.EnrollmentRequired = true
+		challenge.Methods = []string{MFAMethodTOTP}
+	}
+
+	challenge.MFAToken, err = s.jwtSvc.GenerateMFAToken(user.ID, mfaChallengeTTL)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat token MFA: %w", err)
+	}
+	return challenge, nil
+}
+
+// userFromMFAToken memvalidasi token tantangan MFA dan memastikan pengguna masih aktif
+func (s *AuthService) userFromMFAToken(ctx context.Context, mfaToken string) (*models.User, error) {
+	userID, err := s.jwtSvc.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	user, err := s.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != "active" {
+		return nil, ErrInvalidMFAToken
+	}
+	return user, nil
+}
+
+// setupTOTP membuat dan menyimpan secret TOTP yang belum dikonfirmasi
+func (s *AuthService) setupTOTP(ctx context.Context, user *models.User) (*dto.TOTPSetupResponseDTO, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat secret TOTP: %w", err)
+	}
+
+	saved, err := s.authRepo.SavePendingMFASecret(ctx, user.ID, secret)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	return &dto.TOTPSetupResponseDTO{
+		Secret: secret,
+		URI:    totp.URI(s.mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+// confirmTOTP memverifikasi kode pertama dari secret yang belum aktif, lalu mengaktifkan MFA
+// beserta kode pemulihan baru
+func (s *AuthService) confirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	mfa, err := s.authRepo.FindUserMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, ErrMFASetupMissing
+	}
+	if mfa.Enabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(mfa.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authRepo.ConfirmUserMFA(ctx, userID, step, hashes); err != nil {
+		if errors.Is(err, repositories.ErrMFAStateChanged) {
+			return nil, ErrInvalidMFACode
+		}
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifySecondFactor memeriksa kode TOTP atau kode pemulihan milik pengguna dengan MFA aktif.
+// Setiap kode hanya dapat dipakai sekali.
+func (s *AuthService) verifySecondFactor(ctx context.Context, userID string, mfa *models.UserMFA, code, recoveryCode string) error {
+	if !mfa.Enabled() {
+		return ErrMFANotEnabled
+	}
+
+	if code != "" {
+		step, ok := totp.Validate(mfa.Secret, code, time.Now(), totpSkew)
+		if !ok {
+			return ErrInvalidMFACode
+		}
+		consumed, err := s.authRepo.ConsumeTOTPStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	consumed, err := s.authRepo.ConsumeRecoveryCode(ctx, userID, hashRecoveryCode(recoveryCode))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrInvalidMFACode
+	}
+	log.Printf("SECURITY: Kode pemulihan MFA dipakai oleh pengguna %s", userID)
+	return nil
+}
+
+// generateRecoveryCodes membuat kode pemulihan berformat xxxxx-xxxxx beserta hash-nya
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	alphabetSize := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := 0; i < recoveryCodeCount; i++ {
+		chars := make([]byte, 10)
+		for j := range chars {
+			n, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return nil, nil, fmt.Errorf("gagal membuat kode pemulihan: %w", err)
+			}
+			chars[j] = recoveryCodeAlphabet[n.Int64()]
+		}
+		code := string(chars[:5]) + "-" + string(chars[5:])
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode menormalisasi kode pemulihan (huruf kecil, tanpa spasi dan tanda hubung)
+// lalu mengembalikan hash SHA-256. Kode pemulihan acak dan panjang sehingga bcrypt tidak
+// diperlukan, dan hash deterministik memungkinkan pencarian langsung di database.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+	"github.com/jokosaputro95/cms-go/internal/pkg/oidc"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ErrOAuthClientNotFound  = AuthServiceError("client OAuth tidak ditemukan")
+	ErrOAuthConsentNotFound = AuthServiceError("akses aplikasi tidak ditemukan")
+	ErrInvalidOAuthClient   = AuthServiceError("konfigurasi client OAuth tidak valid")
+	ErrInvalidRedirectURI   = AuthServiceError("redirect URI harus https atau http ke localhost, tanpa fragment")
+
+	// OAuthClientSecretPrefix menandai secret client agar mudah dikenali secret scanner
+	OAuthClientSecretPrefix = "cms_cs_"
+)
+
+// oauthScopePattern membatasi nama scope kustom untuk client credentials, misalnya paywall:read
+var oauthScopePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:-]*$`)
+
+// CreateOAuthClient mendaftarkan aplikasi yang login dengan akun CMS. Tanpa grant_types
+// client memakai authorization code dengan refresh token; tanpa scopes client mendapat
+// semua scope OpenID Connect. Secret hanya dikembalikan sekali.
+func (s *AuthService) CreateOAuthClient(ctx context.Context, createdBy string, req *dto.CreateOAuthClientRequestDTO) (*dto.OAuthClientSecretResponseDTO, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	grantTypes := uniqueStrings(req.GrantTypes)
+	if len(grantTypes) == 0 {
+		grantTypes = []string{models.GrantTypeAuthorizationCode, models.GrantTypeRefreshToken}
+	}
+	usesCode := containsScope(grantTypes, models.GrantTypeAuthorizationCode)
+	if containsScope(grantTypes, models.GrantTypeRefreshToken) && !usesCode {
+		return nil, fmt.Errorf("%w: refresh_token membutuhkan authorization_code", ErrInvalidOAuthClient)
+	}
+	if req.Public && containsScope(grantTypes, models.GrantTypeClientCredentials) {
+		return nil, fmt.Errorf("%w: public client tidak dapat memakai client_credentials", ErrInvalidOAuthClient)
+	}
+
+	redirectURIs := uniqueStrings(req.RedirectURIs)
+	if usesCode && len(redirectURIs) == 0 {
+		return nil, fmt.Errorf("%w: redirect_uris wajib diisi untuk authorization_code", ErrInvalidOAuthClient)
+	}
+	for _, redirectURI := range redirectURIs {
+		if !validRedirectURI(redirectURI) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRedirectURI, redirectURI)
+		}
+	}
+
+	scopes := uniqueStrings(req.Scopes)
+	if len(scopes) == 0 && usesCode {
+		scopes = append(scopes, oidcUserScopes...)
+	}
+	for _, scope := range scopes {
+		if !oauthScopePattern.MatchString(scope) {
+			return nil, fmt.Errorf("%w: nama scope tidak valid: %s", ErrInvalidOAuthClient, scope)
+		}
+	}
+
+	client := &models.OAuthClient{
+		ID:           uuid.New().String(),
+		Name:         strings.TrimSpace(req.Name),
+		RedirectURIs: redirectURIs,
+		GrantTypes:   grantTypes,
+		Scopes:       scopes,
+		CreatedBy:    optionalString(createdBy),
+	}
+	secret := ""
+	if !req.Public {
+		var err error
+		if secret, err = newOAuthClientSecret(); err != nil {
+			return nil, err
+		}
+		secretHash := hashAPIToken(secret)
+		client.SecretHash = &secretHash
+	}
+	if err := s.authRepo.SaveOAuthClient(ctx, client); err != nil {
+		return nil, err
+	}
+
+	log.Printf("SECURITY: Client OAuth %s (%s) didaftarkan oleh pengguna %s dengan grant %v", client.ID, client.Name, createdBy, grantTypes)
+	return &dto.OAuthClientSecretResponseDTO{
+		OAuthClientResponseDTO: oauthClientResponse(client),
+		ClientSecret:           secret,
+	}, nil
+}
+
+// ListOAuthClients mengembalikan semua client yang belum dicabut
+func (s *AuthService) ListOAuthClients(ctx context.Context) ([]dto.OAuthClientResponseDTO, error) {
+	clients, err := s.authRepo.ListOAuthClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.OAuthClientResponseDTO, 0, len(clients))
+	for i := range clients {
+		result = append(result, oauthClientResponse(&clients[i]))
+	}
+	return result, nil
+}
+
+// GetOAuthClient mengembalikan satu client
+func (s *AuthService) GetOAuthClient(ctx context.Context, clientID string) (*dto.OAuthClientResponseDTO, error) {
+	client, err := s.authRepo.FindOAuthClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrOAuthClientNotFound
+	}
+	response := oauthClientResponse(client)
+	return &response, nil
+}
+
+// RotateOAuthClientSecret membuat secret baru untuk client confidential. Secret lama langsung
+// tidak berlaku, sedangkan token yang sudah diterbitkan tetap berlaku.
+func (s *AuthService) RotateOAuthClientSecret(ctx context.Context, clientID string) (*dto.OAuthClientSecretResponseDTO, error) {
+	client, err := s.authRepo.FindOAuthClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrOAuthClientNotFound
+	}
+	if client.Public() {
+		return nil, fmt.Errorf("%w: public client tidak memiliki secret", ErrInvalidOAuthClient)
+	}
+
+	secret, err := newOAuthClientSecret()
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.authRepo.UpdateOAuthClientSecret(ctx, clientID, hashAPIToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrOAuthClientNotFound
+	}
+
+	log.Printf("SECURITY: Secret client OAuth %s diganti", clientID)
+	return &dto.OAuthClientSecretResponseDTO{
+		OAuthClientResponseDTO: oauthClientResponse(client),
+		ClientSecret:           secret,
+	}, nil
+}
+
+// RevokeOAuthClient mencabut client beserta semua token yang sudah diterbitkan untuknya
+func (s *AuthService) RevokeOAuthClient(ctx context.Context, clientID string) error {
+	revoked, err := s.authRepo.RevokeOAuthClient(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrOAuthClientNotFound
+	}
+	log.Printf("SECURITY: Client OAuth %s dicabut", clientID)
+	return nil
+}
+
+// ListOAuthConsents mengembalikan aplikasi yang sudah diberi akses oleh pengguna
+func (s *AuthService) ListOAuthConsents(ctx context.Context, userID string) ([]dto.OAuthConsentResponseDTO, error) {
+	consents, err := s.authRepo.ListOAuthConsents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.OAuthConsentResponseDTO, 0, len(consents))
+	for _, consent := range consents {
+		result = append(result, dto.OAuthConsentResponseDTO{
+			ClientID:   consent.ClientID,
+			ClientName: consent.ClientName,
+			Scopes:     consent.Scopes,
+			CreatedAt:  consent.CreatedAt,
+			UpdatedAt:  consent.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+// RevokeOAuthConsent mencabut akses aplikasi beserta semua token yang diterbitkan untuk
+// pengguna, sehingga layar consent ditampilkan lagi pada login berikutnya
+func (s *AuthService) RevokeOAuthConsent(ctx context.Context, userID, clientID string) error {
+	revoked, err := s.authRepo.RevokeOAuthConsent(ctx, userID, clientID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrOAuthConsentNotFound
+	}
+	log.Printf("SECURITY: Pengguna %s mencabut akses client OAuth %s", userID, clientID)
+	return nil
+}
+
+// validRedirectURI menerima URI absolut https, atau http hanya untuk localhost selama
+// pengembangan. Fragment dilarang karena code dikirim lewat query string (RFC 6749 bagian 3.1.2).
+func validRedirectURI(raw string) bool {
+	target, err := url.Parse(raw)
+	if err != nil || target.Host == "" || target.Fragment != "" {
+		return false
+	}
+	switch target.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := target.Hostname()
+		return host == "localhost" || host == "127.0.0.1" || host == "::1"
+	default:
+		return false
+	}
+}
+
+func newOAuthClientSecret() (string, error) {
+	secret, err := oidc.RandomString()
+	if err != nil {
+		return "", fmt.Errorf("gagal membuat secret client: %w", err)
+	}
+	return OAuthClientSecretPrefix + secret, nil
+}
+
+func oauthClientResponse(client *models.OAuthClient) dto.OAuthClientResponseDTO {
+	return dto.OAuthClientResponseDTO{
+		ClientID:     client.ID,
+		Name:         client.Name,
+		Public:       client.Public(),
+		RedirectURIs: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		CreatedAt:    client.CreatedAt,
+		UpdatedAt:    client.UpdatedAt,
+	}
+}
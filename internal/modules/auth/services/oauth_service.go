@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
+	profiles "github.com/jokosaputro95/cms-go/internal/modules/profile/models"
+	"github.com/jokosaputro95/cms-go/internal/pkg/oidc"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ErrOAuthProviderNotFound = AuthServiceError("penyedia login tidak dikenal")
+	ErrInvalidOAuthState     = AuthServiceError("state login OAuth tidak valid atau kedaluwarsa")
+	ErrOAuthEmailNotVerified = AuthServiceError("email dari penyedia login belum terverifikasi")
+	ErrOAuthLoginFailed      = AuthServiceError("login melalui penyedia gagal")
+	ErrAccountInactive       = AuthServiceError("akun tidak aktif")
+
+	// OAuthStateTTL adalah batas waktu antara memulai login dan menerima callback
+	OAuthStateTTL = 10 * time.Minute
+	// maxUsernameAttempts adalah jumlah percobaan mencari username yang belum dipakai
+	maxUsernameAttempts = 5
+)
+
+// OAuthProviders mengembalikan nama penyedia login yang dikonfigurasi
+func (s *AuthService) OAuthProviders() []string {
+	return s.oauthProviders.Names()
+}
+
+// StartOAuthLogin menyiapkan state, nonce dan code verifier PKCE lalu mengembalikan URL
+// otorisasi penyedia. Code verifier hanya disimpan di server.
+func (s *AuthService) StartOAuthLogin(ctx context.Context, providerName string) (*dto.OAuthLoginResponseDTO, error) {
+	provider := s.oauthProviders.Provider(providerName)
+	if provider == nil {
+		return nil, ErrOAuthProviderNotFound
+	}
+
+	loginState := &models.OAuthLoginState{
+		Provider:  provider.Name(),
+		ExpiresAt: time.Now().Add(OAuthStateTTL),
+	}
+	var err error
+	for _, value := range []*string{&loginState.State, &loginState.Nonce, &loginState.CodeVerifier} {
+		if *value, err = oidc.RandomString(); err != nil {
+			return nil, err
+		}
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, loginState.State, loginState.Nonce, oidc.CodeChallenge(loginState.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOAuthLoginFailed, err)
+	}
+	if err := s.authRepo.SaveOAuthLoginState(ctx, loginState); err != nil {
+		return nil, err
+	}
+
+	return &dto.OAuthLoginResponseDTO{AuthorizationURL: authURL, State: loginState.State}, nil
+}
+
+// CompleteOAuthLogin memproses callback penyedia: menukar code, memverifikasi identitas,
+// lalu login ke akun yang sudah terhubung, menghubungkan akun dengan email terverifikasi
+// yang sama, atau membuat akun baru. MFA tetap diminta seperti login dengan password.
+func (s *AuthService) CompleteOAuthLogin(ctx context.Context, providerName string, req *dto.OAuthCallbackRequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error) {
+	provider := s.oauthProviders.Provider(providerName)
+	if provider == nil {
+		return nil, ErrOAuthProviderNotFound
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, ErrInvalidOAuthState
+	}
+
+	// 1. State hanya dapat dipakai sekali dan harus dibuat untuk penyedia yang sama
+	loginState, err := s.authRepo.ConsumeOAuthLoginState(ctx, req.State, provider.Name())
+	if err != nil {
+		return nil, err
+	}
+	if loginState == nil || loginState.ExpiresAt.Before(time.Now()) {
+		return nil, ErrInvalidOAuthState
+	}
+
+	// 2. Tukar code dengan token lalu verifikasi identitas (termasuk nonce untuk OIDC)
+	token, err := provider.Exchange(ctx, req.Code, loginState.CodeVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOAuthLoginFailed, err)
+	}
+	identity, err := provider.Identity(ctx, token, loginState.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOAuthLoginFailed, err)
+	}
+
+	// 3. Temukan, hubungkan, atau buat pengguna
+	user, err := s.resolveOAuthUser(ctx, provider.Name(), identity)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != "active" {
+		return nil, ErrAccountInactive
+	}
+
+	// 4. Langkah MFA tetap berlaku untuk login lewat penyedia
+	challenge, err := s.mfaChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		log.Printf("Pengguna %s:%s login lewat %s dan membutuhkan verifikasi MFA.", user.Username, user.Email, provider.Name())
+		return &dto.AuthResponseDTO{ID: user.ID, MFA: challenge}, nil
+	}
+
+	if err := s.authRepo.UpdateUserLoginStatus(ctx, user.ID, ip, 0, nil); err != nil {
+		return nil, err
+	}
+	tokenPair, err := s.startSession(ctx, user, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Pengguna %s:%s berhasil login lewat %s.", user.Username, user.Email, provider.Name())
+	return &dto.AuthResponseDTO{
+		ID:           user.ID,
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		TokenType:    tokenPair.TokenType,
+		ExpiresIn:    tokenPair.ExpiresIn,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+	}, nil
+}
+
+// resolveOAuthUser mencari pengguna untuk identitas penyedia. Identitas yang sudah terhubung
+// selalu dipakai lebih dulu; penghubungan dan pembuatan akun baru membutuhkan email yang
+// sudah diverifikasi oleh penyedia.
+func (s *AuthService) resolveOAuthUser(ctx context.Context, providerName string, identity *oidc.Identity) (*models.User, error) {
+	linked, err := s.authRepo.FindUserIdentity(ctx, providerName, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if linked != nil {
+		user, err := s.authRepo.FindUserByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, ErrOAuthLoginFailed
+		}
+		var email *string
+		if identity.EmailVerified {
+			email = optionalString(identity.Email)
+		}
+		if err := s.authRepo.TouchUserIdentity(ctx, linked.ID, email); err != nil {
+			log.Printf("WARNING: %v", err)
+		}
+		return user, nil
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, ErrOAuthEmailNotVerified
+	}
+	newIdentity := &models.UserIdentity{
+		ID:       uuid.New().String(),
+		Provider: providerName,
+		Subject:  identity.Subject,
+		Email:    &identity.Email,
+	}
+
+	existing, err := s.authRepo.FindUserByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		// Akun yang di-suspend, di-banned atau dikunci admin tidak boleh dibuka lewat penyedia
+		if existing.Status != "active" && existing.Status != "pending" {
+			return nil, ErrAccountInactive
+		}
+		// Akun pending yang emailnya belum terverifikasi bisa saja didaftarkan orang lain dengan
+		// email korban; akun tersebut diambil alih oleh pemilik email dan password-nya dihapus
+		claim := !existing.EmailVerified && existing.Status == "pending"
+		newIdentity.UserID = existing.ID
+		if err := s.authRepo.LinkUserIdentity(ctx, newIdentity, claim); err != nil {
+			if errors.Is(err, repositories.ErrAccountNotLinkable) {
+				return nil, ErrAccountInactive
+			}
+			return nil, err
+		}
+		if claim {
+			existing.Status, existing.EmailVerified, existing.PasswordHash = "active", true, nil
+			log.Printf("SECURITY: Akun belum terverifikasi %s diambil alih lewat %s, password dihapus", existing.ID, providerName)
+		}
+		log.Printf("SECURITY: Identitas %s terhubung ke pengguna %s berdasarkan email terverifikasi", providerName, existing.ID)
+		return existing, nil
+	}
+
+	username, err := s.availableUsername(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	firstName, lastName := splitName(identity.Name)
+	user := &models.User{
+		Username:           username,
+		Email:              identity.Email,
+		RegistrationMethod: models.RegistrationMethodOAuth,
+		OAuthProvider:      &newIdentity.Provider,
+		Status:             "active",
+		EmailVerified:      true,
+	}
+	if err := s.authRepo.SaveUser(ctx, user, &profiles.UserProfile{FirstName: firstName, LastName: &lastName}); err != nil {
+		return nil, err
+	}
+
+	newIdentity.UserID = user.ID
+	if err := s.authRepo.LinkUserIdentity(ctx, newIdentity, false); err != nil {
+		return nil, err
+	}
+	log.Printf("Pengguna %s:%s dibuat lewat login %s", user.Username, user.Email, providerName)
+	return user, nil
+}
+
+// availableUsername membuat username dari username penyedia atau bagian lokal email,
+// ditambah akhiran acak jika sudah dipakai
+func (s *AuthService) availableUsername(ctx context.Context, identity *oidc.Identity) (string, error) {
+	base := identity.Username
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+	base = sanitizeUsername(base)
+
+	candidate := base
+	for attempt := 0; attempt < maxUsernameAttempts; attempt++ {
+		existing, err := s.authRepo.FindUserByUsername(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+		candidate = base + "-" + uuid.New().String()[:6]
+	}
+	return "", fmt.Errorf("gagal menemukan username yang tersedia untuk %s", base)
+}
+
+// sanitizeUsername menyisakan huruf kecil, angka, titik, garis bawah dan tanda hubung
+// dengan panjang 3 sampai 40 karakter
+func sanitizeUsername(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	username := b.String()
+	if len(username) > 40 {
+		username = username[:40]
+	}
+	if len(username) < 3 {
+		username = "user" + username
+	}
+	return username
+}
+
+// splitName memisahkan nama lengkap dari penyedia menjadi nama depan dan nama belakang
+func splitName(name string) (string, string) {
+	first, last, _ := strings.Cut(strings.TrimSpace(name), " ")
+	return first, strings.TrimSpace(last)
+}
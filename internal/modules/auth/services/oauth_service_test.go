@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
+	"github.com/jokosaputro95/cms-go/internal/pkg/oidc"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// stateDriver adalah driver database/sql minimal untuk test state OAuth. Setiap query
+// mengembalikan baris di rows (boleh kosong) dan dicatat di queries.
+type stateDriver struct {
+	mu      sync.Mutex
+	rows    [][]driver.Value
+	queries []string
+}
+
+func (d *stateDriver) Open(string) (driver.Conn, error) { return &stateConn{driver: d}, nil }
+
+type stateConn struct{ driver *stateDriver }
+
+func (c *stateConn) Prepare(query string) (driver.Stmt, error) {
+	return &stateStmt{driver: c.driver, query: query}, nil
+}
+func (c *stateConn) Close() error              { return nil }
+func (c *stateConn) Begin() (driver.Tx, error) { return nil, errors.New("transaksi tidak didukung") }
+
+type stateStmt struct {
+	driver *stateDriver
+	query  string
+}
+
+func (s *stateStmt) Close() error  { return nil }
+func (s *stateStmt) NumInput() int { return -1 }
+func (s *stateStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec tidak didukung")
+}
+func (s *stateStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+	s.driver.queries = append(s.driver.queries, s.query)
+	return &stateRows{values: s.driver.rows}, nil
+}
+
+type stateRows struct{ values [][]driver.Value }
+
+func (r *stateRows) Columns() []string {
+	return []string{"state", "provider", "nonce", "code_verifier", "created_at", "expires_at"}
+}
+func (r *stateRows) Close() error { return nil }
+func (r *stateRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+var registerStateDriver sync.Once
+var stateDrivers sync.Map
+
+// newOAuthStateTestService membuat AuthService dengan satu penyedia OIDC yang mengarah ke
+// IdP palsu. Semua permintaan ke IdP dihitung; callback dengan state tidak valid harus
+// ditolak sebelum penyedia dihubungi.
+func newOAuthStateTestService(t *testing.T, rows [][]driver.Value) (*AuthService, *stateDriver, *atomic.Int32) {
+	t.Helper()
+	registerStateDriver.Do(func() {
+		sql.Register("oauthstate", &stateDriverRouter{})
+	})
+	fake := &stateDriver{rows: rows}
+	stateDrivers.Store(t.Name(), fake)
+	t.Cleanup(func() { stateDrivers.Delete(t.Name()) })
+
+	db, err := sql.Open("oauthstate", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	var idpHits atomic.Int32
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idpHits.Add(1)
+		http.Error(w, "tidak boleh dipanggil", http.StatusTeapot)
+	}))
+	t.Cleanup(idp.Close)
+
+	registry, err := oidc.NewRegistry([]oidc.Config{{
+		Name:        "stub",
+		ClientID:    "cms-client",
+		Issuer:      idp.URL,
+		RedirectURL: "https://cms.example/callback",
+	}}, idp.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := &AuthService{
+		authRepo:       repositories.NewAuthRepository(db),
+		validate:       validator.New(),
+		oauthProviders: registry,
+	}
+	return service, fake, &idpHits
+}
+
+// stateDriverRouter memilih stateDriver milik test berdasarkan DSN
+type stateDriverRouter struct{}
+
+func (stateDriverRouter) Open(name string) (driver.Conn, error) {
+	fake, ok := stateDrivers.Load(name)
+	if !ok {
+		return nil, errors.New("driver test tidak ditemukan")
+	}
+	return fake.(*stateDriver).Open(name)
+}
+
+func TestCompleteOAuthLoginRejectsInvalidState(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		req         dto.OAuthCallbackRequestDTO
+		rows        [][]driver.Value
+		expectQuery bool
+	}{
+		{
+			name: "state kosong",
+			req:  dto.OAuthCallbackRequestDTO{Code: "code-123"},
+		},
+		{
+			name:        "state tidak dikenal",
+			req:         dto.OAuthCallbackRequestDTO{Code: "code-123", State: "state-palsu"},
+			expectQuery: true,
+		},
+		{
+			name: "state kedaluwarsa",
+			req:  dto.OAuthCallbackRequestDTO{Code: "code-123", State: "state-lama"},
+			rows: [][]driver.Value{{
+				"state-lama", "stub", "nonce", "verifier", now.Add(-time.Hour), now.Add(-time.Minute),
+			}},
+			expectQuery: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, fake, idpHits := newOAuthStateTestService(t, tt.rows)
+
+			_, err := service.CompleteOAuthLogin(context.Background(), "stub", &tt.req, "127.0.0.1", "test")
+			if !errors.Is(err, ErrInvalidOAuthState) {
+				t.Fatalf("err = %v, seharusnya ErrInvalidOAuthState", err)
+			}
+			if hits := idpHits.Load(); hits != 0 {
+				t.Errorf("IdP dihubungi %d kali, seharusnya tidak sama sekali", hits)
+			}
+			if queried := len(fake.queries) > 0; queried != tt.expectQuery {
+				t.Errorf("state diperiksa ke database = %v, seharusnya %v", queried, tt.expectQuery)
+			}
+		})
+	}
+}
+
+func TestCompleteOAuthLoginRejectsUnknownProvider(t *testing.T) {
+	service, _, _ := newOAuthStateTestService(t, nil)
+	req := &dto.OAuthCallbackRequestDTO{Code: "code-123", State: "state"}
+	if _, err := service.CompleteOAuthLogin(context.Background(), "tidak-ada", req, "127.0.0.1", "test"); !errors.Is(err, ErrOAuthProviderNotFound) {
+		t.Fatalf("err = %v, seharusnya ErrOAuthProviderNotFound", err)
+	}
+}
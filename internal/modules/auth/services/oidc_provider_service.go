@@ -0,0 +1,738 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+	"github.com/jokosaputro95/cms-go/internal/pkg/oidc"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	ErrOIDCProviderDisabled = AuthServiceError("penyedia OpenID Connect tidak diaktifkan")
+
+	// OAuthAccessTokenPrefix dan OAuthRefreshTokenPrefix menandai token opaque yang
+	// diterbitkan untuk client, berbeda dari JWT dan personal access token CMS
+	OAuthAccessTokenPrefix  = "cms_oat_"
+	OAuthRefreshTokenPrefix = "cms_ort_"
+
+	oauthCodeTTL         = 5 * time.Minute
+	oauthAccessTokenTTL  = time.Hour
+	oauthRefreshTokenTTL = 30 * 24 * time.Hour
+
+	// Panjang code verifier PKCE menurut RFC 7636 bagian 4.1; code challenge S256 selalu
+	// berupa 43 karakter base64url
+	minCodeVerifierLength = 43
+	maxCodeVerifierLength = 128
+	codeChallengeLength   = 43
+	maxNonceLength        = 255
+)
+
+// Kode error OAuth 2.0 (RFC 6749 bagian 4.1.2.1 dan 5.2, RFC 6750 bagian 3.1)
+const (
+	OAuth2InvalidRequest          = "invalid_request"
+	OAuth2InvalidClient           = "invalid_client"
+	OAuth2InvalidGrant            = "invalid_grant"
+	OAuth2UnauthorizedClient      = "unauthorized_client"
+	OAuth2UnsupportedGrantType    = "unsupported_grant_type"
+	OAuth2UnsupportedResponseType = "unsupported_response_type"
+	OAuth2InvalidScope            = "invalid_scope"
+	OAuth2AccessDenied            = "access_denied"
+	OAuth2InvalidToken            = "invalid_token"
+	OAuth2InsufficientScope       = "insufficient_scope"
+)
+
+// OAuth2Error adalah error protokol OAuth 2.0 yang dikirim ke client dengan kode standar.
+// RedirectTo terisi jika error pada permintaan otorisasi harus dikembalikan ke client lewat
+// redirect, yaitu setelah client dan redirect URI terbukti valid.
+type OAuth2Error struct {
+	Code        string
+	Description string
+	RedirectTo  string
+}
+
+func (e *OAuth2Error) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func oauth2Error(code, description string) *OAuth2Error {
+	return &OAuth2Error{Code: code, Description: description}
+}
+
+// oidcUserScopes adalah scope yang memberi akses ke data pengguna sehingga hanya dapat
+// diberikan lewat authorization code dengan persetujuan pengguna
+var oidcUserScopes = []string{models.ScopeOpenID, models.ScopeProfile, models.ScopeEmail, models.ScopeOfflineAccess}
+
+// oauthGrantTypes adalah grant type yang didukung endpoint token
+var oauthGrantTypes = []string{models.GrantTypeAuthorizationCode, models.GrantTypeRefreshToken, models.GrantTypeClientCredentials}
+
+// oidcEnabled melaporkan apakah CMS bertindak sebagai penyedia OIDC. ID token harus dapat
+// diverifikasi client lewat JWKS sehingga kunci penandatanganan asimetris wajib ada.
+func (s *AuthService) oidcEnabled() bool {
+	return s.oidcProvider.OIDCIssuer != "" && len(s.jwtSvc.JWKS().Keys) > 0
+}
+
+func (s *AuthService) oidcIssuer() string {
+	return strings.TrimSuffix(s.oidcProvider.OIDCIssuer, "/")
+}
+
+// OpenIDConfiguration mengembalikan dokumen discovery OpenID Connect
+func (s *AuthService) OpenIDConfiguration() (*dto.OpenIDConfigurationDTO, error) {
+	if !s.oidcEnabled() {
+		return nil, ErrOIDCProviderDisabled
+	}
+
+	issuer := s.oidcIssuer()
+	authorizationEndpoint := s.oidcProvider.OIDCConsentURL
+	if authorizationEndpoint == "" {
+		authorizationEndpoint = issuer + "/oauth2/authorize"
+	}
+
+	var algorithms []string
+	for _, key := range s.jwtSvc.JWKS().Keys {
+		if !containsScope(algorithms, key.Algorithm) {
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+
+	return &dto.OpenIDConfigurationDTO{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             authorizationEndpoint,
+		TokenEndpoint:                     issuer + "/oauth2/token",
+		UserInfoEndpoint:                  issuer + "/oauth2/userinfo",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		IntrospectionEndpoint:             issuer + "/oauth2/introspect",
+		RevocationEndpoint:                issuer + "/oauth2/revoke",
+		ScopesSupported:                   oidcUserScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               oauthGrantTypes,
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  algorithms,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "email", "email_verified",
+			"name", "given_name", "family_name", "preferred_username", "picture", "updated_at",
+		},
+		AuthorizationResponseIssParameterSupported: true,
+	}, nil
+}
+
+// GetOAuth2Authorization memvalidasi permintaan otorisasi dan mengembalikan data untuk
+// layar consent
+func (s *AuthService) GetOAuth2Authorization(ctx context.Context, userID string, req *dto.OAuth2AuthorizeRequestDTO) (*dto.OAuth2AuthorizationDTO, error) {
+	client, scopes, err := s.validateAuthorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	consent, err := s.authRepo.FindOAuthConsent(ctx, userID, client.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OAuth2AuthorizationDTO{
+		ClientID:       client.ID,
+		ClientName:     client.Name,
+		Scopes:         scopes,
+		ConsentGranted: consent != nil && consent.Covers(scopes),
+	}, nil
+}
+
+// DecideOAuth2Authorization memproses keputusan pengguna pada layar consent. Jika disetujui,
+// authorization code dibuat dan dikembalikan sebagai URL redirect ke client; auth_time
+// diambil dari waktu sesi login dibuat.
+func (s *AuthService) DecideOAuth2Authorization(ctx context.Context, userID, sessionID string, req *dto.OAuth2ConsentRequestDTO) (*dto.OAuth2RedirectDTO, error) {
+	client, scopes, err := s.validateAuthorizeRequest(ctx, &req.OAuth2AuthorizeRequestDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Approve {
+		log.Printf("Pengguna %s menolak akses untuk client OAuth %s", userID, client.ID)
+		return &dto.OAuth2RedirectDTO{
+			RedirectTo: s.authorizationRedirect(req.RedirectURI, req.State, url.Values{
+				"error":             {OAuth2AccessDenied},
+				"error_description": {"pengguna menolak memberikan akses"},
+			}),
+		}, nil
+	}
+
+	session, err := s.authRepo.FindSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.UserID != userID || !session.Active(time.Now()) {
+		return nil, ErrSessionNotFound
+	}
+	user, err := s.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != "active" {
+		return nil, ErrAccountInactive
+	}
+
+	if err := s.authRepo.SaveOAuthConsent(ctx, userID, client.ID, scopes); err != nil {
+		return nil, err
+	}
+
+	code, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	authCode := &models.OAuthAuthorizationCode{
+		CodeHash:      hashAPIToken(code),
+		ClientID:      client.ID,
+		UserID:        userID,
+		RedirectURI:   req.RedirectURI,
+		Scopes:        scopes,
+		Nonce:         optionalString(req.Nonce),
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      session.CreatedAt,
+		ExpiresAt:     time.Now().Add(oauthCodeTTL),
+	}
+	if err := s.authRepo.SaveOAuthAuthorizationCode(ctx, authCode); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Pengguna %s memberikan akses %v untuk client OAuth %s", userID, scopes, client.ID)
+	return &dto.OAuth2RedirectDTO{
+		RedirectTo: s.authorizationRedirect(req.RedirectURI, req.State, url.Values{"code": {code}}),
+	}, nil
+}
+
+// validateAuthorizeRequest memeriksa permintaan otorisasi. Selama client atau redirect URI
+// belum terbukti valid, error tidak boleh dikirim lewat redirect agar endpoint ini tidak
+// menjadi open redirector.
+func (s *AuthService) validateAuthorizeRequest(ctx context.Context, req *dto.OAuth2AuthorizeRequestDTO) (*models.OAuthClient, []string, error) {
+	if !s.oidcEnabled() {
+		return nil, nil, ErrOIDCProviderDisabled
+	}
+	if req.ClientID == "" {
+		return nil, nil, oauth2Error(OAuth2InvalidRequest, "client_id wajib diisi")
+	}
+
+	client, err := s.authRepo.FindOAuthClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if client == nil {
+		return nil, nil, oauth2Error(OAuth2InvalidRequest, "client_id tidak dikenal")
+	}
+	if req.RedirectURI == "" || !client.AllowsRedirectURI(req.RedirectURI) {
+		return nil, nil, oauth2Error(OAuth2InvalidRequest, "redirect_uri tidak terdaftar untuk client")
+	}
+
+	fail := func(code, description string) error {
+		return &OAuth2Error{
+			Code:        code,
+			Description: description,
+			RedirectTo: s.authorizationRedirect(req.RedirectURI, req.State, url.Values{
+				"error":             {code},
+				"error_description": {description},
+			}),
+		}
+	}
+
+	if req.ResponseType != "code" {
+		return nil, nil, fail(OAuth2UnsupportedResponseType, "hanya response_type=code yang didukung")
+	}
+	if !client.AllowsGrant(models.GrantTypeAuthorizationCode) {
+		return nil, nil, fail(OAuth2UnauthorizedClient, "client tidak diizinkan memakai authorization code")
+	}
+	if req.CodeChallengeMethod != "S256" || len(req.CodeChallenge) != codeChallengeLength {
+		return nil, nil, fail(OAuth2InvalidRequest, "PKCE dengan code_challenge_method=S256 wajib dipakai")
+	}
+	if len(req.Nonce) > maxNonceLength {
+		return nil, nil, fail(OAuth2InvalidRequest, "nonce terlalu panjang")
+	}
+
+	scopes := parseScopes(req.Scope)
+	if len(scopes) == 0 {
+		return nil, nil, fail(OAuth2InvalidScope, "scope wajib diisi")
+	}
+	for _, scope := range scopes {
+		if !containsScope(oidcUserScopes, scope) || !client.AllowsScope(scope) {
+			return nil, nil, fail(OAuth2InvalidScope, "scope tidak diizinkan: "+scope)
+		}
+	}
+	return client, scopes, nil
+}
+
+// authorizationRedirect menambahkan parameter respons otorisasi ke redirect URI client,
+// termasuk iss (RFC 9207) agar client dapat menolak respons dari penyedia lain
+func (s *AuthService) authorizationRedirect(redirectURI, state string, params url.Values) string {
+	target, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+	query := target.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	query.Set("iss", s.oidcIssuer())
+	target.RawQuery = query.Encode()
+	return target.String()
+}
+
+// ExchangeOAuth2Token menangani endpoint token untuk grant authorization_code, refresh_token
+// dan client_credentials
+func (s *AuthService) ExchangeOAuth2Token(ctx context.Context, auth *dto.OAuth2ClientAuthDTO, req *dto.OAuth2TokenRequestDTO) (*dto.OAuth2TokenResponseDTO, error) {
+	if !s.oidcEnabled() {
+		return nil, ErrOIDCProviderDisabled
+	}
+	client, err := s.authenticateOAuthClient(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GrantType == "" {
+		return nil, oauth2Error(OAuth2InvalidRequest, "grant_type wajib diisi")
+	}
+	if !containsScope(oauthGrantTypes, req.GrantType) {
+		return nil, oauth2Error(OAuth2UnsupportedGrantType, "grant_type tidak didukung")
+	}
+	if !client.AllowsGrant(req.GrantType) {
+		return nil, oauth2Error(OAuth2UnauthorizedClient, "client tidak diizinkan memakai grant_type ini")
+	}
+
+	switch req.GrantType {
+	case models.GrantTypeAuthorizationCode:
+		return s.exchangeAuthorizationCode(ctx, client, req)
+	case models.GrantTypeRefreshToken:
+		return s.exchangeOAuthRefreshToken(ctx, client, req)
+	default:
+		return s.exchangeClientCredentials(ctx, client, req)
+	}
+}
+
+// exchangeAuthorizationCode menukar authorization code setelah redirect URI dan code
+// verifier PKCE terbukti cocok
+func (s *AuthService) exchangeAuthorizationCode(ctx context.Context, client *models.OAuthClient, req *dto.OAuth2TokenRequestDTO) (*dto.OAuth2TokenResponseDTO, error) {
+	if req.Code == "" || req.CodeVerifier == "" {
+		return nil, oauth2Error(OAuth2InvalidRequest, "code dan code_verifier wajib diisi")
+	}
+
+	code, err := s.authRepo.ConsumeOAuthAuthorizationCode(ctx, hashAPIToken(req.Code))
+	if err != nil {
+		return nil, err
+	}
+	if code == nil || code.ClientID != client.ID || !code.ExpiresAt.After(time.Now()) {
+		return nil, oauth2Error(OAuth2InvalidGrant, "authorization code tidak valid atau kedaluwarsa")
+	}
+	if code.RedirectURI != req.RedirectURI {
+		return nil, oauth2Error(OAuth2InvalidGrant, "redirect_uri tidak cocok")
+	}
+	if len(req.CodeVerifier) < minCodeVerifierLength || len(req.CodeVerifier) > maxCodeVerifierLength ||
+		subtle.ConstantTimeCompare([]byte(oidc.CodeChallenge(req.CodeVerifier)), []byte(code.CodeChallenge)) != 1 {
+		return nil, oauth2Error(OAuth2InvalidGrant, "code_verifier tidak cocok")
+	}
+
+	user, err := s.authRepo.FindUserByID(ctx, code.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != "active" {
+		return nil, oauth2Error(OAuth2InvalidGrant, "pengguna tidak aktif")
+	}
+
+	nonce := ""
+	if code.Nonce != nil {
+		nonce = *code.Nonce
+	}
+	return s.issueOAuthTokens(ctx, client, user, code.Scopes, &code.AuthTime, nonce, nil)
+}
+
+// exchangeOAuthRefreshToken merotasi refresh token. Refresh token yang sudah dirotasi dan
+// dipakai lagi menandakan token bocor sehingga seluruh grant dicabut.
+func (s *AuthService) exchangeOAuthRefreshToken(ctx context.Context, client *models.OAuthClient, req *dto.OAuth2TokenRequestDTO) (*dto.OAuth2TokenResponseDTO, error) {
+	if req.RefreshToken == "" {
+		return nil, oauth2Error(OAuth2InvalidRequest, "refresh_token wajib diisi")
+	}
+
+	token, err := s.authRepo.FindOAuthTokenByHash(ctx, hashAPIToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.TokenType != models.OAuthTokenTypeRefresh || token.ClientID != client.ID || token.UserID == nil {
+		return nil, oauth2Error(OAuth2InvalidGrant, "refresh token tidak valid")
+	}
+	if token.RevokedAt != nil {
+		return nil, s.revokeReusedOAuthGrant(ctx, token)
+	}
+	if !token.Active(time.Now()) {
+		return nil, oauth2Error(OAuth2InvalidGrant, "refresh token kedaluwarsa")
+	}
+
+	// Scope access token boleh dipersempit, tetapi tidak melebihi scope otorisasi awal
+	scopes := token.Scopes
+	if req.Scope != "" {
+		scopes = parseScopes(req.Scope)
+		for _, scope := range scopes {
+			if !token.HasScope(scope) {
+				return nil, oauth2Error(OAuth2InvalidScope, "scope melebihi otorisasi awal: "+scope)
+			}
+		}
+	}
+
+	user, err := s.authRepo.FindUserByID(ctx, *token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != "active" {
+		return nil, oauth2Error(OAuth2InvalidGrant, "pengguna tidak aktif")
+	}
+
+	return s.issueOAuthTokens(ctx, client, user, scopes, token.AuthTime, "", token)
+}
+
+// exchangeClientCredentials menerbitkan access token untuk client itu sendiri tanpa pengguna.
+// Scope data pengguna tidak pernah diberikan lewat grant ini.
+func (s *AuthService) exchangeClientCredentials(ctx context.Context, client *models.OAuthClient, req *dto.OAuth2TokenRequestDTO) (*dto.OAuth2TokenResponseDTO, error) {
+	if client.Public() {
+		return nil, oauth2Error(OAuth2UnauthorizedClient, "public client tidak dapat memakai client_credentials")
+	}
+
+	available := make([]string, 0, len(client.Scopes))
+	for _, scope := range client.Scopes {
+		if !containsScope(oidcUserScopes, scope) {
+			available = append(available, scope)
+		}
+	}
+
+	scopes := available
+	if req.Scope != "" {
+		scopes = parseScopes(req.Scope)
+		for _, scope := range scopes {
+			if !containsScope(available, scope) {
+				return nil, oauth2Error(OAuth2InvalidScope, "scope tidak diizinkan: "+scope)
+			}
+		}
+	}
+
+	return s.issueOAuthTokens(ctx, client, nil, scopes, nil, "", nil)
+}
+
+// issueOAuthTokens menerbitkan access token, refresh token jika offline_access disetujui,
+// dan ID token jika scope openid diminta. previous adalah refresh token yang sedang dirotasi;
+// scope refresh token pengganti selalu sama dengan scope refresh token lama.
+func (s *AuthService) issueOAuthTokens(ctx context.Context, client *models.OAuthClient, user *models.User, scopes []string, authTime *time.Time, nonce string, previous *models.OAuthToken) (*dto.OAuth2TokenResponseDTO, error) {
+	now := time.Now()
+	grantID := uuid.New().String()
+	refreshScopes := scopes
+	if previous != nil {
+		grantID = previous.GrantID
+		refreshScopes = previous.Scopes
+	}
+	var userID *string
+	if user != nil {
+		userID = &user.ID
+	}
+
+	accessToken, access, err := newOAuthToken(OAuthAccessTokenPrefix, models.OAuthTokenTypeAccess, grantID, client.ID, userID, scopes, authTime, now.Add(oauthAccessTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+	tokens := []*models.OAuthToken{access}
+	response := &dto.OAuth2TokenResponseDTO{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(oauthAccessTokenTTL.Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}
+
+	if user != nil && containsScope(refreshScopes, models.ScopeOfflineAccess) && client.AllowsGrant(models.GrantTypeRefreshToken) {
+		refreshToken, refresh, err := newOAuthToken(OAuthRefreshTokenPrefix, models.OAuthTokenTypeRefresh, grantID, client.ID, userID, refreshScopes, authTime, now.Add(oauthRefreshTokenTTL))
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, refresh)
+		response.RefreshToken = refreshToken
+	}
+
+	if user != nil && containsScope(scopes, models.ScopeOpenID) {
+		claims, err := s.oidcUserClaims(ctx, user, scopes)
+		if err != nil {
+			return nil, err
+		}
+		claims["iss"] = s.oidcIssuer()
+		claims["aud"] = client.ID
+		claims["iat"] = now.Unix()
+		claims["exp"] = now.Add(oauthAccessTokenTTL).Unix()
+		if authTime != nil {
+			claims["auth_time"] = authTime.Unix()
+		}
+		if nonce != "" {
+			claims["nonce"] = nonce
+		}
+		if response.IDToken, err = s.jwtSvc.SignIDToken(claims); err != nil {
+			return nil, fmt.Errorf("gagal menandatangani ID token: %w", err)
+		}
+	}
+
+	if previous != nil {
+		rotated, err := s.authRepo.RotateOAuthRefreshToken(ctx, previous.ID, tokens...)
+		if err != nil {
+			return nil, err
+		}
+		if !rotated {
+			return nil, s.revokeReusedOAuthGrant(ctx, previous)
+		}
+		return response, nil
+	}
+
+	if err := s.authRepo.SaveOAuthTokens(ctx, tokens...); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// revokeReusedOAuthGrant mencabut semua token dari grant yang refresh token lamanya dipakai
+// ulang, lalu mengembalikan error invalid_grant
+func (s *AuthService) revokeReusedOAuthGrant(ctx context.Context, token *models.OAuthToken) error {
+	log.Printf("SECURITY: Refresh token OAuth lama dipakai ulang oleh client %s, grant %s dicabut", token.ClientID, token.GrantID)
+	if err := s.authRepo.RevokeOAuthGrant(ctx, token.GrantID); err != nil {
+		return err
+	}
+	return oauth2Error(OAuth2InvalidGrant, "refresh token sudah pernah dipakai")
+}
+
+// OAuth2UserInfo mengembalikan klaim pengguna untuk access token dengan scope openid
+func (s *AuthService) OAuth2UserInfo(ctx context.Context, accessToken string) (map[string]interface{}, error) {
+	if !s.oidcEnabled() {
+		return nil, ErrOIDCProviderDisabled
+	}
+
+	token, err := s.findActiveOAuthToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.TokenType != models.OAuthTokenTypeAccess || token.UserID == nil {
+		return nil, oauth2Error(OAuth2InvalidToken, "access token tidak valid atau kedaluwarsa")
+	}
+	if !token.HasScope(models.ScopeOpenID) {
+		return nil, oauth2Error(OAuth2InsufficientScope, "access token tidak memiliki scope openid")
+	}
+
+	user, err := s.authRepo.FindUserByID(ctx, *token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// Token milik akun yang tidak aktif tidak lagi berlaku meskipun belum kedaluwarsa
+	if user == nil || user.Status != "active" {
+		return nil, oauth2Error(OAuth2InvalidToken, "access token tidak valid atau kedaluwarsa")
+	}
+	return s.oidcUserClaims(ctx, user, token.Scopes)
+}
+
+// IntrospectOAuth2Token menjelaskan status token untuk resource server (RFC 7662). Hanya
+// client confidential yang boleh melakukan introspeksi; refresh token hanya terlihat aktif
+// bagi client pemiliknya.
+func (s *AuthService) IntrospectOAuth2Token(ctx context.Context, auth *dto.OAuth2ClientAuthDTO, tokenStr string) (*dto.OAuth2IntrospectionDTO, error) {
+	if !s.oidcEnabled() {
+		return nil, ErrOIDCProviderDisabled
+	}
+	client, err := s.authenticateOAuthClient(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	if client.Public() {
+		return nil, oauth2Error(OAuth2InvalidClient, "introspeksi membutuhkan client confidential")
+	}
+
+	token, err := s.findActiveOAuthToken(ctx, tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || (token.TokenType == models.OAuthTokenTypeRefresh && token.ClientID != client.ID) {
+		return &dto.OAuth2IntrospectionDTO{Active: false}, nil
+	}
+
+	result := &dto.OAuth2IntrospectionDTO{
+		Active:    true,
+		Scope:     strings.Join(token.Scopes, " "),
+		ClientID:  token.ClientID,
+		TokenType: "Bearer",
+		Exp:       token.ExpiresAt.Unix(),
+		Iat:       token.CreatedAt.Unix(),
+		Iss:       s.oidcIssuer(),
+	}
+	if token.TokenType == models.OAuthTokenTypeRefresh {
+		result.TokenType = "refresh_token"
+	}
+	if token.UserID != nil {
+		user, err := s.authRepo.FindUserByID(ctx, *token.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil || user.Status != "active" {
+			return &dto.OAuth2IntrospectionDTO{Active: false}, nil
+		}
+		result.Sub = user.ID
+		result.Username = user.Username
+	}
+	return result, nil
+}
+
+// RevokeOAuth2Token mencabut token milik client (RFC 7009). Token yang tidak dikenal atau
+// milik client lain diabaikan tanpa error. Mencabut refresh token juga mencabut access token
+// dari grant yang sama.
+func (s *AuthService) RevokeOAuth2Token(ctx context.Context, auth *dto.OAuth2ClientAuthDTO, tokenStr string) error {
+	if !s.oidcEnabled() {
+		return ErrOIDCProviderDisabled
+	}
+	client, err := s.authenticateOAuthClient(ctx, auth)
+	if err != nil {
+		return err
+	}
+	if tokenStr == "" {
+		return oauth2Error(OAuth2InvalidRequest, "token wajib diisi")
+	}
+
+	token, err := s.authRepo.FindOAuthTokenByHash(ctx, hashAPIToken(tokenStr))
+	if err != nil {
+		return err
+	}
+	if token == nil || token.ClientID != client.ID || token.RevokedAt != nil {
+		return nil
+	}
+
+	if token.TokenType == models.OAuthTokenTypeRefresh {
+		err = s.authRepo.RevokeOAuthGrant(ctx, token.GrantID)
+	} else {
+		err = s.authRepo.RevokeOAuthToken(ctx, token.ID)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("Token OAuth %s (%s) dicabut oleh client %s", token.ID, token.TokenType, client.ID)
+	return nil
+}
+
+// authenticateOAuthClient memverifikasi kredensial client. Public client hanya mengirim
+// client_id; secret client confidential dibandingkan lewat hash SHA-256 seperti API token.
+func (s *AuthService) authenticateOAuthClient(ctx context.Context, auth *dto.OAuth2ClientAuthDTO) (*models.OAuthClient, error) {
+	if auth.ClientID == "" {
+		return nil, oauth2Error(OAuth2InvalidClient, "autentikasi client gagal")
+	}
+	client, err := s.authRepo.FindOAuthClient(ctx, auth.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, oauth2Error(OAuth2InvalidClient, "autentikasi client gagal")
+	}
+
+	if client.Public() {
+		if auth.ClientSecret != "" {
+			return nil, oauth2Error(OAuth2InvalidClient, "autentikasi client gagal")
+		}
+		return client, nil
+	}
+	if auth.ClientSecret == "" ||
+		subtle.ConstantTimeCompare([]byte(hashAPIToken(auth.ClientSecret)), []byte(*client.SecretHash)) != 1 {
+		return nil, oauth2Error(OAuth2InvalidClient, "autentikasi client gagal")
+	}
+	return client, nil
+}
+
+// findActiveOAuthToken mengambil token yang belum dicabut dan belum kedaluwarsa, nil jika
+// token tidak dikenal atau sudah tidak berlaku
+func (s *AuthService) findActiveOAuthToken(ctx context.Context, tokenStr string) (*models.OAuthToken, error) {
+	if tokenStr == "" {
+		return nil, nil
+	}
+	token, err := s.authRepo.FindOAuthTokenByHash(ctx, hashAPIToken(tokenStr))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || !token.Active(time.Now()) {
+		return nil, nil
+	}
+	return token, nil
+}
+
+// oidcUserClaims menyusun klaim pengguna sesuai scope yang disetujui, dipakai oleh ID token
+// dan endpoint userinfo
+func (s *AuthService) oidcUserClaims(ctx context.Context, user *models.User, scopes []string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{"sub": user.ID}
+
+	if containsScope(scopes, models.ScopeEmail) {
+		claims["email"] = user.Email
+		claims["email_verified"] = user.EmailVerified
+	}
+
+	if containsScope(scopes, models.ScopeProfile) {
+		claims["preferred_username"] = user.Username
+		profile, err := s.authRepo.FindUserProfile(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if profile != nil {
+			name := profile.FirstName
+			if profile.FirstName != "" {
+				claims["given_name"] = profile.FirstName
+			}
+			if profile.LastName != nil && *profile.LastName != "" {
+				claims["family_name"] = *profile.LastName
+				name += " " + *profile.LastName
+			}
+			if name = strings.TrimSpace(name); name != "" {
+				claims["name"] = name
+			}
+			if profile.AvatarURL != nil && *profile.AvatarURL != "" {
+				claims["picture"] = *profile.AvatarURL
+			}
+			claims["updated_at"] = profile.UpdatedAt.Unix()
+		}
+	}
+	return claims, nil
+}
+
+// newOAuthToken membuat token opaque acak beserta barisnya; yang disimpan hanya hash-nya
+func newOAuthToken(prefix, tokenType, grantID, clientID string, userID *string, scopes []string, authTime *time.Time, expiresAt time.Time) (string, *models.OAuthToken, error) {
+	secret, err := oidc.RandomString()
+	if err != nil {
+		return "", nil, err
+	}
+	rawToken := prefix + secret
+	return rawToken, &models.OAuthToken{
+		ID:        uuid.New().String(),
+		GrantID:   grantID,
+		TokenType: tokenType,
+		TokenHash: hashAPIToken(rawToken),
+		ClientID:  clientID,
+		UserID:    userID,
+		Scopes:    scopes,
+		AuthTime:  authTime,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// parseScopes memecah parameter scope yang dipisah spasi dan membuang duplikat
+func parseScopes(scope string) []string {
+	return uniqueStrings(strings.Fields(scope))
+}
+
+func uniqueStrings(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !containsScope(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
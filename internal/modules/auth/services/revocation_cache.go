@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// revocationChannel adalah channel NOTIFY yang diisi oleh trigger revoked_tokens dan users
+	revocationChannel = "token_revocations"
+	// revocationSyncOverlap memundurkan batas sinkronisasi inkremental agar baris dari transaksi
+	// yang commit terlambat (revoked_at memakai waktu awal transaksi) tetap terbaca
+	revocationSyncOverlap = time.Minute
+)
+
+// revocationNotification adalah payload NOTIFY dari trigger pencabutan token
+type revocationNotification struct {
+	Kind       string    `json:"kind"`
+	TokenID    string    `json:"token_id"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	UserID     string    `json:"user_id"`
+	ValidAfter time.Time `json:"valid_after"`
+}
+
+// RevocationCache menyimpan salinan in-memory dari jti yang dicabut dan epoch token pengguna
+// sehingga AuthMiddleware tidak perlu query ke database pada setiap permintaan. Isinya
+// lengkap (bukan LRU) karena revoked_tokens hanya berisi token yang belum kedaluwarsa.
+// Cache dimuat penuh saat start, diperbarui lewat LISTEN/NOTIFY, dan disinkronkan ulang
+// secara inkremental setiap syncInterval sebagai jaring pengaman.
+type RevocationCache struct {
+	authRepo     repositories.AuthRepositoryInterface
+	dsn          string
+	syncInterval time.Duration
+	maxTokenTTL  time.Duration
+	queryTimeout time.Duration
+
+	mu       sync.RWMutex
+	tokens   map[string]time.Time
+	epochs   map[string]time.Time
+	ready    bool
+	lastSync time.Time
+
+	lifecycleMu sync.Mutex
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewRevocationCache membuat instance baru dari RevocationCache. dsn dipakai untuk koneksi
+// LISTEN terpisah; dsn kosong berarti cache hanya diperbarui lewat sinkronisasi berkala.
+// maxTokenTTL adalah masa berlaku token terpanjang, dipakai untuk membuang epoch yang usang.
+func NewRevocationCache(authRepo repositories.AuthRepositoryInterface, dsn string, syncInterval, maxTokenTTL, queryTimeout time.Duration) *RevocationCache {
+	return &RevocationCache{
+		authRepo:     authRepo,
+		dsn:          dsn,
+		syncInterval: syncInterval,
+		maxTokenTTL:  maxTokenTTL,
+		queryTimeout: queryTimeout,
+		tokens:       map[string]time.Time{},
+		epochs:       map[string]time.Time{},
+	}
+}
+
+// IsRevoked memeriksa token di cache. Nilai ok false berarti cache belum siap (misalnya
+// pemuatan awal gagal) sehingga pemanggil harus memeriksa ke database.
+func (c *RevocationCache) IsRevoked(tokenID, userID string, issuedAt time.Time) (revoked bool, ok bool) {
+	if c == nil {
+		return false, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.ready {
+		return false, false
+	}
+	if _, found := c.tokens[tokenID]; found {
+		return true, true
+	}
+	if epoch, found := c.epochs[userID]; found && issuedAt.Before(epoch) {
+		return true, true
+	}
+	return false, true
+}
+
+// AddToken mencatat jti yang baru dicabut oleh instance ini tanpa menunggu NOTIFY
+func (c *RevocationCache) AddToken(tokenID string, expiresAt time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addTokenLocked(tokenID, expiresAt)
+}
+
+// SetUserEpoch mencatat epoch token pengguna yang baru dimajukan oleh instance ini
+func (c *RevocationCache) SetUserEpoch(userID string, validAfter time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setEpochLocked(userID, validAfter)
+}
+
+// Start memuat seluruh data pencabutan lalu menjalankan listener dan sinkronisasi di
+// goroutine terpisah. Kegagalan pemuatan awal hanya dicatat; pemeriksaan tetap memakai
+// database sampai sinkronisasi berikutnya berhasil. Pemanggilan berulang diabaikan.
+func (c *RevocationCache) Start() {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	if c.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	if err := c.reload(ctx); err != nil {
+		log.Printf("Revocation cache gagal memuat data awal, memakai database: %v", err)
+	}
+
+	go c.loop(ctx, c.done)
+	log.Printf("Revocation cache berjalan, sinkronisasi setiap %s", c.syncInterval)
+}
+
+// Stop menghentikan listener dan sinkronisasi, lalu menunggu goroutine selesai
+// atau hingga ctx berakhir.
+func (c *RevocationCache) Stop(ctx context.Context) error {
+	c.lifecycleMu.Lock()
+	cancel, done := c.cancel, c.done
+	c.cancel, c.done = nil, nil
+	c.lifecycleMu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *RevocationCache) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	var notifications <-chan *pq.Notification
+	if c.dsn != "" {
+		listener := pq.NewListener(c.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Printf("Revocation cache listener error: %v", err)
+			}
+		})
+		defer listener.Close()
+
+		if err := listener.Listen(revocationChannel); err != nil {
+			log.Printf("Revocation cache gagal LISTEN %s: %v", revocationChannel, err)
+		} else {
+			notifications = listener.NotificationChannel()
+		}
+	}
+
+	ticker := time.NewTicker(c.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case notification := <-notifications:
+			// Notifikasi nil dikirim setelah koneksi listener tersambung ulang; notifikasi
+			// selama koneksi terputus hilang sehingga cache dimuat ulang penuh
+			if notification == nil {
+				if err := c.reload(ctx); err != nil && ctx.Err() == nil {
+					log.Printf("Revocation cache gagal memuat ulang data: %v", err)
+				}
+				continue
+			}
+			c.apply(notification.Extra)
+
+		case <-ticker.C:
+			if err := c.sync(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Revocation cache gagal sinkronisasi: %v", err)
+			}
+		}
+	}
+}
+
+// apply menerapkan satu payload NOTIFY ke cache
+func (c *RevocationCache) apply(payload string) {
+	var n revocationNotification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		log.Printf("Revocation cache menerima payload tidak valid: %v", err)
+		return
+	}
+
+	switch n.Kind {
+	case "token":
+		c.AddToken(n.TokenID, n.ExpiresAt)
+	case "user":
+		if !n.ValidAfter.IsZero() {
+			c.SetUserEpoch(n.UserID, n.ValidAfter)
+		}
+	}
+}
+
+// reload memuat ulang seluruh jti yang belum kedaluwarsa dan epoch yang masih relevan
+func (c *RevocationCache) reload(ctx context.Context) error {
+	now := time.Now()
+	tokens, epochs, err := c.fetch(ctx, time.Time{}, now)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens = make(map[string]time.Time, len(tokens))
+	c.epochs = make(map[string]time.Time, len(epochs))
+	for _, token := range tokens {
+		c.addTokenLocked(token.TokenID, token.ExpiresAt)
+	}
+	for _, epoch := range epochs {
+		c.setEpochLocked(epoch.UserID, epoch.ValidAfter)
+	}
+	c.ready = true
+	c.lastSync = now
+	return nil
+}
+
+// sync mengambil perubahan sejak sinkronisasi terakhir dan membuang entri yang sudah usang.
+// Jika cache belum pernah dimuat, seluruh data dimuat ulang.
+func (c *RevocationCache) sync(ctx context.Context) error {
+	c.mu.RLock()
+	ready, since := c.ready, c.lastSync.Add(-revocationSyncOverlap)
+	c.mu.RUnlock()
+	if !ready {
+		return c.reload(ctx)
+	}
+
+	now := time.Now()
+	tokens, epochs, err := c.fetch(ctx, since, now)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, token := range tokens {
+		c.addTokenLocked(token.TokenID, token.ExpiresAt)
+	}
+	for _, epoch := range epochs {
+		c.setEpochLocked(epoch.UserID, epoch.ValidAfter)
+	}
+	c.pruneLocked(now)
+	c.lastSync = now
+	return nil
+}
+
+func (c *RevocationCache) fetch(ctx context.Context, since, now time.Time) ([]models.RevokedToken, []models.UserTokenEpoch, error) {
+	queryCtx, cancel := c.queryContext(ctx)
+	defer cancel()
+
+	tokens, err := c.authRepo.ListRevokedTokensSince(queryCtx, since, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	epochs, err := c.authRepo.ListUserTokenEpochsSince(queryCtx, since, now.Add(-c.maxTokenTTL))
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, epochs, nil
+}
+
+func (c *RevocationCache) addTokenLocked(tokenID string, expiresAt time.Time) {
+	if tokenID == "" {
+		return
+	}
+	if current, found := c.tokens[tokenID]; !found || expiresAt.After(current) {
+		c.tokens[tokenID] = expiresAt
+	}
+}
+
+func (c *RevocationCache) setEpochLocked(userID string, validAfter time.Time) {
+	if userID == "" {
+		return
+	}
+	// Disamakan dengan presisi epoch di database; lihat repositories.TokenEpoch
+	validAfter = repositories.TokenEpoch(validAfter)
+	if current, found := c.epochs[userID]; !found || validAfter.After(current) {
+		c.epochs[userID] = validAfter
+	}
+}
+
+// pruneLocked membuang jti yang sudah kedaluwarsa dan epoch yang lebih lama dari masa
+// berlaku token terpanjang
+func (c *RevocationCache) pruneLocked(now time.Time) {
+	for tokenID, expiresAt := range c.tokens {
+		if expiresAt.Before(now) {
+			delete(c.tokens, tokenID)
+		}
+	}
+	oldest := now.Add(-c.maxTokenTTL)
+	for userID, validAfter := range c.epochs {
+		if validAfter.Before(oldest) {
+			delete(c.epochs, userID)
+		}
+	}
+}
+
+func (c *RevocationCache) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.queryTimeout)
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRevocationCacheEpochUsesSecondPrecision(t *testing.T) {
+	cache := NewRevocationCache(nil, "", time.Minute, time.Hour, time.Second)
+	cache.ready = true
+
+	// Pencabutan terjadi di pertengahan detik 10:00:05
+	revokedAt := time.Date(2026, 5, 1, 10, 0, 5, 600_000_000, time.UTC)
+	cache.SetUserEpoch("user-1", revokedAt)
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{"terbit detik sebelumnya", time.Date(2026, 5, 1, 10, 0, 4, 0, time.UTC), true},
+		{"terbit di detik pencabutan", time.Date(2026, 5, 1, 10, 0, 5, 0, time.UTC), false},
+		{"terbit setelah pencabutan", time.Date(2026, 5, 1, 10, 0, 6, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked, ok := cache.IsRevoked("token-lain", "user-1", tt.issuedAt)
+			if !ok {
+				t.Fatal("cache seharusnya siap")
+			}
+			if revoked != tt.revoked {
+				t.Fatalf("revoked = %v, seharusnya %v", revoked, tt.revoked)
+			}
+		})
+	}
+
+	if revoked, _ := cache.IsRevoked("token-lain", "user-2", revokedAt.Add(-time.Hour)); revoked {
+		t.Error("pengguna lain tidak boleh terpengaruh epoch user-1")
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
+)
+
+// defaultPurgeBatchSize adalah jumlah maksimum baris revoked_tokens yang dihapus per query
+const defaultPurgeBatchSize = 500
+
+// RevokedTokenPurger menghapus baris revoked_tokens yang tokennya sudah kedaluwarsa secara
+// berkala di background, begitu juga token OAuth milik client yang sudah kedaluwarsa.
+// Token kedaluwarsa sudah ditolak sehingga barisnya hanya membuat tabel dan indeks terus membesar.
+type RevokedTokenPurger struct {
+	authRepo     repositories.AuthRepositoryInterface
+	interval     time.Duration
+	batchSize    int
+	queryTimeout time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRevokedTokenPurger membuat instance baru dari RevokedTokenPurger
+func NewRevokedTokenPurger(authRepo repositories.AuthRepositoryInterface, interval time.Duration, batchSize int, queryTimeout time.Duration) *RevokedTokenPurger {
+	if batchSize <= 0 {
+		batchSize = defaultPurgeBatchSize
+	}
+	return &RevokedTokenPurger{
+		authRepo:     authRepo,
+		interval:     interval,
+		batchSize:    batchSize,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Start menjalankan pembersihan di goroutine terpisah. Pemanggilan berulang diabaikan.
+func (p *RevokedTokenPurger) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+
+	go p.loop(ctx, p.done)
+	log.Printf("Revoked token purger berjalan setiap %s", p.interval)
+}
+
+// Stop menghentikan pembersihan dan menunggu putaran yang sedang berjalan selesai
+// atau hingga ctx berakhir.
+func (p *RevokedTokenPurger) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.cancel, p.done = nil, nil
+	p.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *RevokedTokenPurger) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Revoked token purger gagal menghapus token kedaluwarsa: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce menghapus semua revoked token dan token OAuth yang sudah kedaluwarsa per batch
+// dan mengembalikan jumlah baris yang dihapus
+func (p *RevokedTokenPurger) RunOnce(ctx context.Context) (int64, error) {
+	var total int64
+	now := time.Now().UTC()
+
+	for _, purge := range []purgeFunc{p.authRepo.PurgeExpiredRevokedTokens, p.authRepo.PurgeExpiredOAuthTokens} {
+		for {
+			count, err := p.purgeBatch(ctx, now, purge)
+			total += count
+			if err != nil {
+				return total, err
+			}
+			if count < int64(p.batchSize) {
+				break
+			}
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Revoked token purger menghapus %d token kedaluwarsa", total)
+	}
+	return total, nil
+}
+
+// purgeFunc menghapus paling banyak limit baris yang kedaluwarsa sebelum now
+type purgeFunc func(ctx context.Context, now time.Time, limit int) (int64, error)
+
+func (p *RevokedTokenPurger) purgeBatch(ctx context.Context, now time.Time, purge purgeFunc) (int64, error) {
+	queryCtx, cancel := p.queryContext(ctx)
+	defer cancel()
+
+	return purge(queryCtx, now, p.batchSize)
+}
+
+func (p *RevokedTokenPurger) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.queryTimeout)
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+
+	"github.com/google/uuid"
+)
+
+// ListSessions mengembalikan sesi aktif milik pengguna. Sesi yang dipakai oleh permintaan
+// saat ini ditandai dengan Current.
+func (s *AuthService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]dto.SessionResponseDTO, error) {
+	sessions, err := s.authRepo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.SessionResponseDTO, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, dto.SessionResponseDTO{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return result, nil
+}
+
+// RevokeSession mencabut satu sesi milik pengguna. Refresh token sesi tersebut langsung
+// ditolak, sedangkan access token yang sudah terbit tetap berlaku sampai kedaluwarsa.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	revoked, err := s.authRepo.RevokeSession(ctx, userID, sessionID, models.SessionRevokedByUser)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+	log.Printf("SECURITY: Sesi %s milik pengguna %s dicabut", sessionID, userID)
+	return nil
+}
+
+// RevokeOtherSessions mencabut semua sesi pengguna selain sesi yang sedang dipakai
+func (s *AuthService) RevokeOtherSessions(ctx context.Context, userID, currentSessionID string) (int64, error) {
+	if currentSessionID == "" {
+		return 0, ErrInvalidToken
+	}
+
+	count, err := s.authRepo.RevokeOtherSessions(ctx, userID, currentSessionID, models.SessionRevokedByUser)
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("SECURITY: %d sesi lain milik pengguna %s dicabut", count, userID)
+	return count, nil
+}
+
+// startSession membuka sesi baru untuk perangkat yang baru login lalu menerbitkan token pair
+func (s *AuthService) startSession(ctx context.Context, user *models.User, ip, userAgent string) (*dto.AuthResponseDTO, error) {
+	session := &models.Session{
+		ID:             uuid.New().String(),
+		UserID:         user.ID,
+		RefreshTokenID: uuid.New().String(),
+		UserAgent:      optionalString(userAgent),
+		IPAddress:      optionalString(ip),
+		ExpiresAt:      time.Now().Add(s.jwtSvc.RefreshTokenTTL()),
+	}
+	if err := s.authRepo.SaveSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return s.generateTokenPair(ctx, user, session)
+}
+
+// revokeReusedSession mencabut seluruh keluarga refresh token ketika refresh token yang
+// sudah dirotasi dipakai lagi, lalu mengembalikan ErrRefreshTokenReused
+func (s *AuthService) revokeReusedSession(ctx context.Context, session *models.Session, ip string) error {
+	log.Printf("SECURITY: Refresh token lama dipakai ulang untuk sesi %s milik pengguna %s dari IP %s, sesi dicabut",
+		session.ID, session.UserID, ip)
+
+	if _, err := s.authRepo.RevokeSession(ctx, session.UserID, session.ID, models.SessionRevokedTokenReuse); err != nil {
+		return fmt.Errorf("gagal mencabut sesi: %w", err)
+	}
+	return ErrRefreshTokenReused
+}
+
+// optionalString mengubah string kosong menjadi nil untuk kolom yang boleh NULL
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,545 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
+	"github.com/jokosaputro95/cms-go/internal/pkg/webauthn"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ErrWebAuthnUnavailable        = AuthServiceError("login dengan passkey tidak dikonfigurasi")
+	ErrInvalidWebAuthnChallenge   = AuthServiceError("challenge WebAuthn tidak valid atau kedaluwarsa")
+	ErrInvalidWebAuthnResponse    = AuthServiceError("verifikasi passkey gagal")
+	ErrWebAuthnCredentialNotFound = AuthServiceError("kredensial WebAuthn tidak ditemukan")
+	ErrWebAuthnCredentialExists   = AuthServiceError("authenticator ini sudah terdaftar")
+	ErrWebAuthnNameTaken          = AuthServiceError("nama kredensial sudah dipakai")
+	ErrWebAuthnLimitReached       = AuthServiceError("jumlah kredensial WebAuthn sudah mencapai batas")
+	ErrWebAuthnNotRegistered      = AuthServiceError("belum ada passkey atau security key yang terdaftar")
+
+	// webAuthnChallengeTTL juga dikirim sebagai timeout ceremony di browser
+	webAuthnChallengeTTL   = 5 * time.Minute
+	maxWebAuthnCredentials = 10
+)
+
+// BeginWebAuthnRegistration membuat opsi navigator.credentials.create untuk mendaftarkan
+// passkey atau security key baru. Kredensial yang sudah terdaftar dikecualikan agar
+// authenticator yang sama tidak didaftarkan dua kali.
+func (s *AuthService) BeginWebAuthnRegistration(ctx context.Context, userID string) (*dto.WebAuthnCreationOptionsDTO, error) {
+	if s.webAuthn == nil {
+		return nil, ErrWebAuthnUnavailable
+	}
+
+	user, err := s.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidToken
+	}
+	credentials, err := s.authRepo.ListWebAuthnCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(credentials) >= maxWebAuthnCredentials {
+		return nil, ErrWebAuthnLimitReached
+	}
+
+	challenge, err := s.newWebAuthnChallenge(ctx, models.WebAuthnCeremonyRegistration, &user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]dto.WebAuthnCredentialParameterDTO, 0, len(webauthn.Algorithms))
+	for _, alg := range webauthn.Algorithms {
+		params = append(params, dto.WebAuthnCredentialParameterDTO{Type: "public-key", Alg: alg})
+	}
+	return &dto.WebAuthnCreationOptionsDTO{
+		Challenge: challenge,
+		RP:        dto.WebAuthnRelyingPartyDTO{ID: s.webAuthn.ID, Name: s.webAuthn.Name},
+		User: dto.WebAuthnUserDTO{
+			ID:          webauthn.EncodeBase64URL([]byte(user.ID)),
+			Name:        user.Email,
+			DisplayName: user.Username,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            webAuthnChallengeTTL.Milliseconds(),
+		Attestation:        "none",
+		ExcludeCredentials: webAuthnDescriptors(credentials),
+		// Passkey yang tersimpan di authenticator (resident key) dapat dipakai untuk login tanpa username
+		AuthenticatorSelection: dto.WebAuthnAuthenticatorSelectionDTO{
+			ResidentKey:      "preferred",
+			UserVerification: "preferred",
+		},
+	}, nil
+}
+
+// FinishWebAuthnRegistration memverifikasi respons navigator.credentials.create lalu
+// menyimpan kredensial dengan nama yang diberikan pengguna
+func (s *AuthService) FinishWebAuthnRegistration(ctx context.Context, userID string, req *dto.WebAuthnRegisterRequestDTO) (*dto.WebAuthnCredentialResponseDTO, error) {
+	if s.webAuthn == nil {
+		return nil, ErrWebAuthnUnavailable
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	rawID, err := webauthn.DecodeBase64URL(req.Credential.ID)
+	if err != nil {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	clientDataJSON, err := webauthn.DecodeBase64URL(req.Credential.Response.ClientDataJSON)
+	if err != nil {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	attestationObject, err := webauthn.DecodeBase64URL(req.Credential.Response.AttestationObject)
+	if err != nil {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+
+	challenge, err := s.consumeWebAuthnChallenge(ctx, clientDataJSON, models.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.UserID == nil || *challenge.UserID != userID {
+		return nil, ErrInvalidWebAuthnChallenge
+	}
+
+	registered, err := s.webAuthn.VerifyRegistration(&webauthn.RegistrationResponse{
+		ClientDataJSON:    clientDataJSON,
+		AttestationObject: attestationObject,
+	}, challengeFromClientData(clientDataJSON), false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebAuthnResponse, err)
+	}
+	if !bytes.Equal(registered.ID, rawID) {
+		return nil, fmt.Errorf("%w: credential ID tidak cocok", ErrInvalidWebAuthnResponse)
+	}
+
+	count, err := s.authRepo.CountWebAuthnCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxWebAuthnCredentials {
+		return nil, ErrWebAuthnLimitReached
+	}
+
+	aaguid, err := uuid.FromBytes(registered.AAGUID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: AAGUID tidak valid", ErrInvalidWebAuthnResponse)
+	}
+	credential := &models.WebAuthnCredential{
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		Name:           strings.TrimSpace(req.Name),
+		CredentialID:   webauthn.EncodeBase64URL(registered.ID),
+		PublicKey:      registered.PublicKey,
+		SignCount:      int64(registered.SignCount),
+		AAGUID:         aaguid.String(),
+		Transports:     uniqueStrings(req.Credential.Response.Transports),
+		BackupEligible: registered.BackupEligible,
+	}
+	if err := s.authRepo.SaveWebAuthnCredential(ctx, credential); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateWebAuthnCredential):
+			return nil, ErrWebAuthnCredentialExists
+		case errors.Is(err, repositories.ErrDuplicateWebAuthnName):
+			return nil, ErrWebAuthnNameTaken
+		}
+		return nil, err
+	}
+
+	log.Printf("SECURITY: Kredensial WebAuthn %s (%s) didaftarkan untuk pengguna %s", credential.ID, credential.Name, userID)
+	response := webAuthnCredentialResponse(credential)
+	return &response, nil
+}
+
+// ListWebAuthnCredentials mengembalikan passkey dan security key milik pengguna
+func (s *AuthService) ListWebAuthnCredentials(ctx context.Context, userID string) ([]dto.WebAuthnCredentialResponseDTO, error) {
+	credentials, err := s.authRepo.ListWebAuthnCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.WebAuthnCredentialResponseDTO, 0, len(credentials))
+	for i := range credentials {
+		result = append(result, webAuthnCredentialResponse(&credentials[i]))
+	}
+	return result, nil
+}
+
+// RenameWebAuthnCredential mengganti nama kredensial milik pengguna
+func (s *AuthService) RenameWebAuthnCredential(ctx context.Context, userID, credentialID string, req *dto.WebAuthnRenameRequestDTO) error {
+	if err := s.validate.Struct(req); err != nil {
+		return fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	renamed, err := s.authRepo.RenameWebAuthnCredential(ctx, userID, credentialID, strings.TrimSpace(req.Name))
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicateWebAuthnName) {
+			return ErrWebAuthnNameTaken
+		}
+		return err
+	}
+	if !renamed {
+		return ErrWebAuthnCredentialNotFound
+	}
+	return nil
+}
+
+// DeleteWebAuthnCredential menghapus kredensial milik pengguna. Kredensial terakhir tidak
+// dapat dihapus jika role mewajibkan MFA dan TOTP belum aktif.
+func (s *AuthService) DeleteWebAuthnCredential(ctx context.Context, userID, credentialID string) error {
+	authz, err := s.authRepo.FindUserAuthorization(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if authz != nil && authz.MFARequired {
+		mfa, err := s.authRepo.FindUserMFA(ctx, userID)
+		if err != nil {
+			return err
+		}
+		count, err := s.authRepo.CountWebAuthnCredentials(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !mfa.Enabled() && count <= 1 {
+			return ErrMFARequiredByRole
+		}
+	}
+
+	deleted, err := s.authRepo.DeleteWebAuthnCredential(ctx, userID, credentialID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrWebAuthnCredentialNotFound
+	}
+	log.Printf("SECURITY: Kredensial WebAuthn %s milik pengguna %s dihapus", credentialID, userID)
+	return nil
+}
+
+// BeginWebAuthnLogin membuat opsi navigator.credentials.get untuk login tanpa password.
+// Jika identifier diisi dan dikenal, hanya kredensial pengguna tersebut yang diizinkan;
+// selain itu browser menawarkan passkey yang tersimpan untuk RP ID.
+func (s *AuthService) BeginWebAuthnLogin(ctx context.Context, req *dto.WebAuthnLoginOptionsRequestDTO) (*dto.WebAuthnRequestOptionsDTO, error) {
+	if s.webAuthn == nil {
+		return nil, ErrWebAuthnUnavailable
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	var userID *string
+	credentials := []models.WebAuthnCredential{}
+	if identifier := strings.TrimSpace(req.Identifier); identifier != "" {
+		var user *models.User
+		var err error
+		if strings.Contains(identifier, "@") {
+			user, err = s.authRepo.FindUserByEmail(ctx, identifier)
+		} else {
+			user, err = s.authRepo.FindUserByUsername(ctx, identifier)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			if credentials, err = s.authRepo.ListWebAuthnCredentials(ctx, user.ID); err != nil {
+				return nil, err
+			}
+			if len(credentials) > 0 {
+				userID = &user.ID
+			}
+		}
+	}
+
+	challenge, err := s.newWebAuthnChallenge(ctx, models.WebAuthnCeremonyLogin, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Login tanpa password membutuhkan verifikasi pengguna (PIN atau biometrik) agar passkey
+	// memenuhi dua faktor sekaligus
+	return s.webAuthnRequestOptions(challenge, credentials, "required"), nil
+}
+
+// FinishWebAuthnLogin memverifikasi assertion passkey lalu menerbitkan token pair. Passkey
+// dengan verifikasi pengguna sudah mencakup dua faktor sehingga langkah MFA tidak diminta.
+// Status akun dan penguncian akun tetap berlaku, dan assertion yang gagal dihitung sebagai
+// login gagal.
+func (s *AuthService) FinishWebAuthnLogin(ctx context.Context, req *dto.WebAuthnLoginRequestDTO, ip, userAgent string) (*dto.AuthResponseDTO, error) {
+	if s.webAuthn == nil {
+		return nil, ErrWebAuthnUnavailable
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	assertion, err := decodeWebAuthnAssertion(&req.Credential)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := s.consumeWebAuthnChallenge(ctx, assertion.ClientDataJSON, models.WebAuthnCeremonyLogin)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := s.authRepo.FindWebAuthnCredential(ctx, req.Credential.ID)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil || (challenge.UserID != nil && *challenge.UserID != credential.UserID) {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+
+	user, err := s.authRepo.FindUserByID(ctx, credential.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	if user.LockedUntil != nil && user.LockedUntil.After(time.Now().UTC()) {
+		return nil, &LockoutError{
+			Message:  string(ErrUserLocked),
+			UnlockAt: *user.LockedUntil,
+		}
+	}
+
+	if err := s.verifyWebAuthnAssertion(ctx, credential, assertion, true); err != nil {
+		if !errors.Is(err, ErrInvalidWebAuthnResponse) {
+			return nil, err
+		}
+		if lockErr := s.recordFailedLogin(ctx, user, ip); lockErr != nil {
+			return nil, lockErr
+		}
+		return nil, err
+	}
+
+	if user.Status != "active" {
+		return nil, ErrAccountInactive
+	}
+	if err := s.authRepo.UpdateUserLoginStatus(ctx, user.ID, ip, 0, nil); err != nil {
+		return nil, err
+	}
+	tokenPair, err := s.startSession(ctx, user, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Pengguna %s:%s berhasil login dengan passkey %s.", user.Username, user.Email, credential.ID)
+	return &dto.AuthResponseDTO{
+		ID:           user.ID,
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		TokenType:    tokenPair.TokenType,
+		ExpiresIn:    tokenPair.ExpiresIn,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+	}, nil
+}
+
+// BeginWebAuthnMFA membuat opsi navigator.credentials.get untuk langkah kedua login dengan
+// token tantangan MFA. Assertion-nya dikirim ke VerifyMFA.
+func (s *AuthService) BeginWebAuthnMFA(ctx context.Context, req *dto.WebAuthnMFAOptionsRequestDTO) (*dto.WebAuthnRequestOptionsDTO, error) {
+	if s.webAuthn == nil {
+		return nil, ErrWebAuthnUnavailable
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	user, err := s.userFromMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := s.authRepo.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(credentials) == 0 {
+		return nil, ErrWebAuthnNotRegistered
+	}
+
+	challenge, err := s.newWebAuthnChallenge(ctx, models.WebAuthnCeremonyMFA, &user.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Password sudah menjadi faktor pertama, sehingga kehadiran pengguna sudah cukup
+	return s.webAuthnRequestOptions(challenge, credentials, "discouraged"), nil
+}
+
+// verifyWebAuthnSecondFactor memeriksa assertion WebAuthn sebagai langkah kedua login.
+// Kegagalan verifikasi dikembalikan sebagai ErrInvalidWebAuthnResponse.
+func (s *AuthService) verifyWebAuthnSecondFactor(ctx context.Context, userID string, req *dto.WebAuthnAssertionCredentialDTO) error {
+	if s.webAuthn == nil {
+		return ErrWebAuthnUnavailable
+	}
+
+	assertion, err := decodeWebAuthnAssertion(req)
+	if err != nil {
+		return err
+	}
+	challenge, err := s.consumeWebAuthnChallenge(ctx, assertion.ClientDataJSON, models.WebAuthnCeremonyMFA)
+	if err != nil {
+		return err
+	}
+	if challenge.UserID == nil || *challenge.UserID != userID {
+		return ErrInvalidWebAuthnChallenge
+	}
+
+	credential, err := s.authRepo.FindWebAuthnCredential(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if credential == nil || credential.UserID != userID {
+		return ErrInvalidWebAuthnResponse
+	}
+	return s.verifyWebAuthnAssertion(ctx, credential, assertion, false)
+}
+
+// verifyWebAuthnAssertion memverifikasi tanda tangan assertion dengan kunci kredensial lalu
+// menyimpan penghitung tanda tangan yang baru. Penghitung yang tidak naik berarti
+// authenticator mungkin diklon sehingga login ditolak.
+func (s *AuthService) verifyWebAuthnAssertion(ctx context.Context, credential *models.WebAuthnCredential, assertion *webauthn.AssertionResponse, requireUserVerification bool) error {
+	if len(assertion.UserHandle) > 0 && string(assertion.UserHandle) != credential.UserID {
+		return fmt.Errorf("%w: user handle tidak cocok", ErrInvalidWebAuthnResponse)
+	}
+
+	result, err := s.webAuthn.VerifyAssertion(assertion, challengeFromClientData(assertion.ClientDataJSON),
+		credential.PublicKey, uint32(credential.SignCount), requireUserVerification)
+	if errors.Is(err, webauthn.ErrSignCountRegression) {
+		log.Printf("SECURITY: Penghitung tanda tangan kredensial WebAuthn %s milik pengguna %s tidak naik, kemungkinan authenticator diklon",
+			credential.ID, credential.UserID)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebAuthnResponse, err)
+	}
+
+	updated, err := s.authRepo.UpdateWebAuthnSignCount(ctx, credential.ID, int64(result.SignCount))
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return fmt.Errorf("%w: penghitung tanda tangan sudah dipakai", ErrInvalidWebAuthnResponse)
+	}
+	return nil
+}
+
+// newWebAuthnChallenge membuat challenge acak dan menyimpan hash-nya untuk ceremony tertentu
+func (s *AuthService) newWebAuthnChallenge(ctx context.Context, ceremony string, userID *string) (string, error) {
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return "", err
+	}
+	err = s.authRepo.SaveWebAuthnChallenge(ctx, &models.WebAuthnChallenge{
+		ChallengeHash: hashAPIToken(challenge),
+		UserID:        userID,
+		Ceremony:      ceremony,
+		ExpiresAt:     time.Now().Add(webAuthnChallengeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return challenge, nil
+}
+
+// consumeWebAuthnChallenge mengambil challenge dari clientDataJSON lalu memakainya. Challenge
+// hanya dapat dipakai sekali, termasuk jika verifikasi berikutnya gagal.
+func (s *AuthService) consumeWebAuthnChallenge(ctx context.Context, clientDataJSON []byte, ceremony string) (*models.WebAuthnChallenge, error) {
+	challengeStr := challengeFromClientData(clientDataJSON)
+	if challengeStr == "" {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	challenge, err := s.authRepo.ConsumeWebAuthnChallenge(ctx, hashAPIToken(challengeStr), ceremony)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil || challenge.ExpiresAt.Before(time.Now()) {
+		return nil, ErrInvalidWebAuthnChallenge
+	}
+	return challenge, nil
+}
+
+// webAuthnRequestOptions menyusun opsi navigator.credentials.get
+func (s *AuthService) webAuthnRequestOptions(challenge string, credentials []models.WebAuthnCredential, userVerification string) *dto.WebAuthnRequestOptionsDTO {
+	return &dto.WebAuthnRequestOptionsDTO{
+		Challenge:        challenge,
+		Timeout:          webAuthnChallengeTTL.Milliseconds(),
+		RPID:             s.webAuthn.ID,
+		AllowCredentials: webAuthnDescriptors(credentials),
+		UserVerification: userVerification,
+	}
+}
+
+// hasWebAuthnCredentials melaporkan apakah pengguna memiliki kredensial WebAuthn
+func (s *AuthService) hasWebAuthnCredentials(ctx context.Context, userID string) (bool, error) {
+	count, err := s.authRepo.CountWebAuthnCredentials(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// decodeWebAuthnAssertion mengubah field base64url dari browser menjadi byte
+func decodeWebAuthnAssertion(req *dto.WebAuthnAssertionCredentialDTO) (*webauthn.AssertionResponse, error) {
+	assertion := &webauthn.AssertionResponse{}
+	fields := []struct {
+		value string
+		dest  *[]byte
+	}{
+		{req.Response.ClientDataJSON, &assertion.ClientDataJSON},
+		{req.Response.AuthenticatorData, &assertion.AuthenticatorData},
+		{req.Response.Signature, &assertion.Signature},
+		{req.Response.UserHandle, &assertion.UserHandle},
+	}
+	for _, field := range fields {
+		decoded, err := webauthn.DecodeBase64URL(field.value)
+		if err != nil {
+			return nil, ErrInvalidWebAuthnResponse
+		}
+		*field.dest = decoded
+	}
+	return assertion, nil
+}
+
+// challengeFromClientData mengembalikan challenge di clientDataJSON, kosong jika tidak terbaca
+func challengeFromClientData(clientDataJSON []byte) string {
+	challenge, err := webauthn.ChallengeFromClientData(clientDataJSON)
+	if err != nil {
+		return ""
+	}
+	return challenge
+}
+
+func webAuthnDescriptors(credentials []models.WebAuthnCredential) []dto.WebAuthnCredentialDescriptorDTO {
+	descriptors := make([]dto.WebAuthnCredentialDescriptorDTO, 0, len(credentials))
+	for _, credential := range credentials {
+		descriptors = append(descriptors, dto.WebAuthnCredentialDescriptorDTO{
+			Type:       "public-key",
+			ID:         credential.CredentialID,
+			Transports: credential.Transports,
+		})
+	}
+	return descriptors
+}
+
+func webAuthnCredentialResponse(credential *models.WebAuthnCredential) dto.WebAuthnCredentialResponseDTO {
+	return dto.WebAuthnCredentialResponseDTO{
+		ID:             credential.ID,
+		Name:           credential.Name,
+		AAGUID:         credential.AAGUID,
+		Transports:     credential.Transports,
+		BackupEligible: credential.BackupEligible,
+		CreatedAt:      credential.CreatedAt,
+		LastUsedAt:     credential.LastUsedAt,
+	}
+}
@@ -0,0 +1,16 @@
+package dto
+
+// UploadMediaRequestDTO berisi metadata editorial yang dikirim sebagai field form bersama file
+type UploadMediaRequestDTO struct {
+	AltText *string `json:"alt_text" validate:"omitempty,max=500"`
+	Caption *string `json:"caption" validate:"omitempty,max=2000"`
+	Credit  *string `json:"credit" validate:"omitempty,max=255"`
+}
+
+// UpdateMediaRequestDTO digunakan untuk mengubah metadata media.
+// Field yang bernilai nil tidak diubah.
+type UpdateMediaRequestDTO struct {
+	AltText *string `json:"alt_text" validate:"omitempty,max=500"`
+	Caption *string `json:"caption" validate:"omitempty,max=2000"`
+	Credit  *string `json:"credit" validate:"omitempty,max=255"`
+}
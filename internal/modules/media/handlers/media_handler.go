@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/media/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/media/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/media/services"
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// multipartMemoryLimit adalah batas data form yang disimpan di memori, sisanya ke file sementara
+	multipartMemoryLimit = 8 << 20
+	// multipartOverhead adalah ruang tambahan untuk field form selain file
+	multipartOverhead = 1 << 20
+	// fileCacheControl dipakai untuk file media; isi file pada satu URL tidak pernah berubah
+	fileCacheControl = "public, max-age=31536000, immutable"
+)
+
+// MediaHandler menangani permintaan HTTP untuk media library
+type MediaHandler struct {
+	mediaService  services.MediaServiceInterface
+	maxUploadSize int64
+}
+
+// NewMediaHandler membuat instance baru dari MediaHandler
+func NewMediaHandler(mediaService services.MediaServiceInterface, maxUploadSize int64) *MediaHandler {
+	return &MediaHandler{mediaService: mediaService, maxUploadSize: maxUploadSize}
+}
+
+// Media menangani daftar media (GET) dan unggahan multipart (POST). Unggahan memakai
+// field "file" serta field opsional alt_text, caption dan credit.
+func (h *MediaHandler) Media(w http.ResponseWriter, r *http.Request) {
+	actor := actorFromRequest(r)
+
+	switch r.Method {
+	case http.MethodGet:
+		page, perPage := api.ParsePagination(r)
+		filter := models.MediaFilter{
+			MimePrefix: r.URL.Query().Get("type"),
+			Search:     r.URL.Query().Get("q"),
+			Page:       page,
+			PerPage:    perPage,
+		}
+
+		assets, total, err := h.mediaService.ListMedia(r.Context(), actor, filter)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to list media")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Media fetched successfully", assets, api.NewPaginationMeta(page, perPage, total))
+
+	case http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize+multipartOverhead)
+		if err := r.ParseMultipartForm(multipartMemoryLimit); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				api.SendDetailedError(w, http.StatusRequestEntityTooLarge, services.ErrFileTooLarge.Error(), "file_too_large", nil)
+				return
+			}
+			api.SendError(w, http.StatusBadRequest, "Invalid multipart form")
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			api.SendError(w, http.StatusBadRequest, "Field file is required")
+			return
+		}
+		defer file.Close()
+
+		input := services.UploadInput{
+			Filename: header.Filename,
+			Content:  file,
+			Metadata: dto.UploadMediaRequestDTO{
+				AltText: formValue(r, "alt_text"),
+				Caption: formValue(r, "caption"),
+				Credit:  formValue(r, "credit"),
+			},
+		}
+
+		asset, err := h.mediaService.Upload(r.Context(), actor, input)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to upload media")
+			return
+		}
+		api.SendSuccess(w, http.StatusCreated, "Media uploaded successfully", asset, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// MediaItem menangani detail (GET), perubahan metadata (PATCH) dan penghapusan (DELETE) media
+func (h *MediaHandler) MediaItem(w http.ResponseWriter, r *http.Request) {
+	actor := actorFromRequest(r)
+	mediaID := r.PathValue("mediaID")
+
+	switch r.Method {
+	case http.MethodGet:
+		asset, err := h.mediaService.GetMedia(r.Context(), actor, mediaID)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to get media")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Media fetched successfully", asset, nil)
+
+	case http.MethodPatch:
+		var req dto.UpdateMediaRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		asset, err := h.mediaService.UpdateMetadata(r.Context(), actor, mediaID, &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to update media")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Media updated successfully", asset, nil)
+
+	case http.MethodDelete:
+		if err := h.mediaService.DeleteMedia(r.Context(), actor, mediaID); err != nil {
+			h.sendServiceError(w, err, "Failed to delete media")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Media deleted successfully", nil, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// ServeFile melayani file asli atau rendition media ke publik dengan header cache.
+// http.ServeContent menangani Range, If-None-Match dan If-Modified-Since.
+func (h *MediaHandler) ServeFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	object, info, err := h.mediaService.OpenFile(r.Context(), r.PathValue("mediaID"), r.PathValue("name"))
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to open media file")
+		return
+	}
+	defer object.Close()
+
+	w.Header().Set("Content-Type", info.MimeType)
+	w.Header().Set("Cache-Control", fileCacheControl)
+	w.Header().Set("ETag", fmt.Sprintf("%q", info.Checksum))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": info.Filename}))
+
+	http.ServeContent(w, r, info.Filename, info.ModTime, object)
+}
+
+// actorFromRequest membangun Actor dari data yang disimpan AuthMiddleware di context
+func actorFromRequest(r *http.Request) services.Actor {
+	userID, _ := r.Context().Value(middleware.UserIDContextKey).(string)
+	return services.Actor{
+		UserID:      userID,
+		Permissions: middleware.PermissionsFromContext(r.Context()),
+	}
+}
+
+// formValue mengembalikan nilai field form atau nil jika field tidak dikirim
+func formValue(r *http.Request, key string) *string {
+	values, ok := r.MultipartForm.Value[key]
+	if !ok || len(values) == 0 {
+		return nil
+	}
+	return &values[0]
+}
+
+// sendServiceError memetakan error dari MediaService ke respons HTTP
+func (h *MediaHandler) sendServiceError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.Is(err, services.ErrMediaNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	case errors.Is(err, services.ErrForbidden):
+		api.SendDetailedError(w, http.StatusForbidden, err.Error(), "forbidden", nil)
+	case errors.Is(err, services.ErrFileTooLarge):
+		api.SendDetailedError(w, http.StatusRequestEntityTooLarge, err.Error(), "file_too_large", nil)
+	case errors.Is(err, services.ErrImageTooLarge):
+		api.SendDetailedError(w, http.StatusRequestEntityTooLarge, err.Error(), "image_too_large", nil)
+	case errors.Is(err, services.ErrUnsupportedMediaType):
+		api.SendDetailedError(w, http.StatusUnsupportedMediaType, err.Error(), "unsupported_media_type", nil)
+	case errors.Is(err, services.ErrEmptyFile):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "validation_error", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/pkg/imaging"
+)
+
+// OriginalName adalah nama yang dipakai pada URL untuk file asli
+const OriginalName = "original"
+
+// MediaAsset merepresentasikan tabel 'media_assets' di database
+type MediaAsset struct {
+	ID         string           `json:"id"`
+	UploaderID string           `json:"uploader_id"`
+	Filename   string           `json:"filename"`
+	StorageKey string           `json:"-"`
+	MimeType   string           `json:"mime_type"`
+	SizeBytes  int64            `json:"size_bytes"`
+	Checksum   string           `json:"checksum"`
+	Width      *int             `json:"width"`
+	Height     *int             `json:"height"`
+	AltText    *string          `json:"alt_text"`
+	Caption    *string          `json:"caption"`
+	Credit     *string          `json:"credit"`
+	URL        string           `json:"url"`
+	Renditions []MediaRendition `json:"renditions"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// MediaRendition merepresentasikan tabel 'media_renditions' di database.
+// Rendition adalah versi gambar yang sudah diubah ukurannya.
+type MediaRendition struct {
+	ID         string    `json:"id"`
+	MediaID    string    `json:"media_id"`
+	Name       string    `json:"name"`
+	StorageKey string    `json:"-"`
+	MimeType   string    `json:"mime_type"`
+	SizeBytes  int64     `json:"size_bytes"`
+	Checksum   string    `json:"checksum"`
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RenditionSpec mendefinisikan ukuran rendition yang dibuat untuk setiap gambar
+type RenditionSpec struct {
+	Name   string
+	Width  int
+	Height int
+	Mode   imaging.Mode
+}
+
+// DefaultRenditions adalah rendition bawaan untuk gambar yang diunggah
+var DefaultRenditions = []RenditionSpec{
+	{Name: "thumbnail", Width: 150, Height: 150, Mode: imaging.ModeFill},
+	{Name: "medium", Width: 800, Height: 800, Mode: imaging.ModeFit},
+	{Name: "large", Width: 1600, Height: 1600, Mode: imaging.ModeFit},
+}
+
+// MediaFilter berisi kriteria pencarian daftar media
+type MediaFilter struct {
+	UploaderID string
+	// MimePrefix memfilter berdasarkan awalan mime type, contoh "image/"
+	MimePrefix string
+	Search     string
+	Page       int
+	PerPage    int
+}
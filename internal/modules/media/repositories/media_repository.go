@@ -0,0 +1,292 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/media/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// mediaColumns adalah daftar kolom yang dibaca oleh scanMedia
+const mediaColumns = `
+	id, uploader_id, filename, storage_key, mime_type, size_bytes, checksum,
+	width, height, alt_text, caption, credit, created_at, updated_at
+`
+
+// MediaRepositoryInterface mendefinisikan kontrak untuk interaksi database media
+type MediaRepositoryInterface interface {
+	CreateMedia(ctx context.Context, asset *models.MediaAsset) error
+	FindMediaByID(ctx context.Context, mediaID string) (*models.MediaAsset, error)
+	ListMedia(ctx context.Context, filter models.MediaFilter) ([]models.MediaAsset, int, error)
+	UpdateMediaMetadata(ctx context.Context, asset *models.MediaAsset) error
+	DeleteMedia(ctx context.Context, mediaID string) error
+}
+
+// MediaRepository adalah implementasi dari MediaRepositoryInterface
+type MediaRepository struct {
+	db *sql.DB
+}
+
+// NewMediaRepository membuat instance baru dari MediaRepository
+func NewMediaRepository(db *sql.DB) *MediaRepository {
+	return &MediaRepository{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMedia(row rowScanner) (*models.MediaAsset, error) {
+	asset := &models.MediaAsset{}
+	err := row.Scan(
+		&asset.ID,
+		&asset.UploaderID,
+		&asset.Filename,
+		&asset.StorageKey,
+		&asset.MimeType,
+		&asset.SizeBytes,
+		&asset.Checksum,
+		&asset.Width,
+		&asset.Height,
+		&asset.AltText,
+		&asset.Caption,
+		&asset.Credit,
+		&asset.CreatedAt,
+		&asset.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// CreateMedia menyimpan media beserta semua rendition-nya dalam satu transaksi.
+// asset.ID harus sudah diisi karena dipakai sebagai bagian dari storage key.
+func (r *MediaRepository) CreateMedia(ctx context.Context, asset *models.MediaAsset) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	asset.CreatedAt = time.Now().UTC()
+	asset.UpdatedAt = asset.CreatedAt
+
+	query := `
+		INSERT INTO media_assets (
+			id, uploader_id, filename, storage_key, mime_type, size_bytes, checksum,
+			width, height, alt_text, caption, credit, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		asset.ID,
+		asset.UploaderID,
+		asset.Filename,
+		asset.StorageKey,
+		asset.MimeType,
+		asset.SizeBytes,
+		asset.Checksum,
+		asset.Width,
+		asset.Height,
+		asset.AltText,
+		asset.Caption,
+		asset.Credit,
+		asset.CreatedAt,
+		asset.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan media: %w", err)
+	}
+
+	for i := range asset.Renditions {
+		rendition := &asset.Renditions[i]
+		rendition.ID = uuid.New().String()
+		rendition.MediaID = asset.ID
+		rendition.CreatedAt = asset.CreatedAt
+
+		query := `
+			INSERT INTO media_renditions (
+				id, media_id, name, storage_key, mime_type, size_bytes, checksum, width, height, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`
+		_, err := tx.ExecContext(ctx, query,
+			rendition.ID,
+			rendition.MediaID,
+			rendition.Name,
+			rendition.StorageKey,
+			rendition.MimeType,
+			rendition.SizeBytes,
+			rendition.Checksum,
+			rendition.Width,
+			rendition.Height,
+			rendition.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("gagal menyimpan rendition %s: %w", rendition.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return nil
+}
+
+// FindMediaByID mencari media beserta rendition-nya berdasarkan ID
+func (r *MediaRepository) FindMediaByID(ctx context.Context, mediaID string) (*models.MediaAsset, error) {
+	query := `SELECT ` + mediaColumns + ` FROM media_assets WHERE id = $1`
+	asset, err := scanMedia(r.db.QueryRowContext(ctx, query, mediaID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari media: %w", err)
+	}
+	if err := r.attachRenditions(ctx, asset); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// ListMedia mengambil daftar media sesuai filter beserta jumlah totalnya
+func (r *MediaRepository) ListMedia(ctx context.Context, filter models.MediaFilter) ([]models.MediaAsset, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.UploaderID != "" {
+		args = append(args, filter.UploaderID)
+		conditions = append(conditions, fmt.Sprintf("uploader_id = $%d", len(args)))
+	}
+	if filter.MimePrefix != "" {
+		args = append(args, filter.MimePrefix+"%")
+		conditions = append(conditions, fmt.Sprintf("mime_type LIKE $%d", len(args)))
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("(filename ILIKE $%[1]d OR alt_text ILIKE $%[1]d OR caption ILIKE $%[1]d)", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM media_assets ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal menghitung media: %w", err)
+	}
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	query := fmt.Sprintf(`SELECT %s FROM media_assets %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`,
+		mediaColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mengambil daftar media: %w", err)
+	}
+	defer rows.Close()
+
+	assets := []models.MediaAsset{}
+	for rows.Next() {
+		asset, err := scanMedia(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("gagal membaca data media: %w", err)
+		}
+		assets = append(assets, *asset)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("gagal membaca daftar media: %w", err)
+	}
+
+	pointers := make([]*models.MediaAsset, len(assets))
+	for i := range assets {
+		pointers[i] = &assets[i]
+	}
+	if err := r.attachRenditions(ctx, pointers...); err != nil {
+		return nil, 0, err
+	}
+	return assets, total, nil
+}
+
+// UpdateMediaMetadata menyimpan perubahan alt text, caption dan credit
+func (r *MediaRepository) UpdateMediaMetadata(ctx context.Context, asset *models.MediaAsset) error {
+	query := `
+		UPDATE media_assets
+		SET alt_text = $1, caption = $2, credit = $3
+		WHERE id = $4
+		RETURNING updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query, asset.AltText, asset.Caption, asset.Credit, asset.ID).Scan(&asset.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("gagal memperbarui metadata media: %w", err)
+	}
+	return nil
+}
+
+// DeleteMedia menghapus media beserta rendition-nya dari database
+func (r *MediaRepository) DeleteMedia(ctx context.Context, mediaID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM media_assets WHERE id = $1`, mediaID); err != nil {
+		return fmt.Errorf("gagal menghapus media: %w", err)
+	}
+	return nil
+}
+
+// attachRenditions mengisi daftar rendition untuk setiap media dengan satu query
+func (r *MediaRepository) attachRenditions(ctx context.Context, assets ...*models.MediaAsset) error {
+	if len(assets) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(assets))
+	byID := make(map[string]*models.MediaAsset, len(assets))
+	for i, asset := range assets {
+		asset.Renditions = []models.MediaRendition{}
+		ids[i] = asset.ID
+		byID[asset.ID] = asset
+	}
+
+	query := `
+		SELECT id, media_id, name, storage_key, mime_type, size_bytes, checksum, width, height, created_at
+		FROM media_renditions
+		WHERE media_id = ANY($1)
+		ORDER BY width
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("gagal mengambil rendition media: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rendition models.MediaRendition
+		err := rows.Scan(
+			&rendition.ID,
+			&rendition.MediaID,
+			&rendition.Name,
+			&rendition.StorageKey,
+			&rendition.MimeType,
+			&rendition.SizeBytes,
+			&rendition.Checksum,
+			&rendition.Width,
+			&rendition.Height,
+			&rendition.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("gagal membaca rendition media: %w", err)
+		}
+		if asset, ok := byID[rendition.MediaID]; ok {
+			asset.Renditions = append(asset.Renditions, rendition)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("gagal membaca rendition media: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/media/handlers"
+)
+
+// MediaRoutes mengelola pendaftaran rute untuk modul media
+type MediaRoutes struct {
+	mediaHandler *handlers.MediaHandler
+}
+
+// NewMediaRoutes membuat instance baru dari MediaRoutes
+func NewMediaRoutes(mediaHandler *handlers.MediaHandler) *MediaRoutes {
+	return &MediaRoutes{mediaHandler: mediaHandler}
+}
+
+// RegisterRoutes mendaftarkan rute media ke router. Pengelolaan media dibungkus dengan
+// authMiddleware, sedangkan file media dilayani secara publik.
+func (r *MediaRoutes) RegisterRoutes(router *http.ServeMux, authMiddleware func(http.Handler) http.Handler) {
+	router.Handle("/media", authMiddleware(http.HandlerFunc(r.mediaHandler.Media)))
+	router.Handle("/media/{mediaID}", authMiddleware(http.HandlerFunc(r.mediaHandler.MediaItem)))
+
+	router.HandleFunc("/media/files/{mediaID}/{name}", r.mediaHandler.ServeFile)
+}
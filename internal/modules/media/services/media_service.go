@@ -0,0 +1,470 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/media/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/media/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/media/repositories"
+	roles "github.com/jokosaputro95/cms-go/internal/modules/role/models"
+	"github.com/jokosaputro95/cms-go/internal/pkg/imaging"
+	"github.com/jokosaputro95/cms-go/internal/pkg/storage"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// MediaServiceError adalah tipe error kustom untuk service media
+type MediaServiceError string
+
+func (e MediaServiceError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrMediaNotFound        = MediaServiceError("media tidak ditemukan")
+	ErrForbidden            = MediaServiceError("anda tidak memiliki izin untuk melakukan aksi ini")
+	ErrEmptyFile            = MediaServiceError("file kosong")
+	ErrFileTooLarge         = MediaServiceError("ukuran file melebihi batas yang diizinkan")
+	ErrUnsupportedMediaType = MediaServiceError("tipe file tidak didukung")
+	ErrImageTooLarge        = MediaServiceError("dimensi gambar melebihi batas yang diizinkan")
+)
+
+// renditionJPEGQuality adalah kualitas encoding JPEG untuk rendition
+const renditionJPEGQuality = 85
+
+// allowedMimeTypes memetakan mime type yang boleh diunggah ke ekstensi file yang disimpan.
+// SVG dan HTML sengaja tidak diizinkan karena dapat berisi script.
+var allowedMimeTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	"video/mp4":       ".mp4",
+	"audio/mpeg":      ".mp3",
+}
+
+// Actor adalah pengguna yang melakukan aksi terhadap media
+type Actor struct {
+	UserID      string
+	Permissions []string
+}
+
+// Can memeriksa apakah actor memiliki permission tertentu
+func (a Actor) Can(permission string) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// UploadInput berisi file yang diunggah beserta metadata editorialnya
+type UploadInput struct {
+	Filename string
+	Content  io.Reader
+	Metadata dto.UploadMediaRequestDTO
+}
+
+// FileInfo berisi informasi yang dibutuhkan untuk melayani file media lewat HTTP
+type FileInfo struct {
+	Filename string
+	MimeType string
+	Checksum string
+	ModTime  time.Time
+}
+
+// MediaServiceInterface mendefinisikan kontrak untuk service media
+type MediaServiceInterface interface {
+	Upload(ctx context.Context, actor Actor, input UploadInput) (*models.MediaAsset, error)
+	GetMedia(ctx context.Context, actor Actor, mediaID string) (*models.MediaAsset, error)
+	ListMedia(ctx context.Context, actor Actor, filter models.MediaFilter) ([]models.MediaAsset, int, error)
+	UpdateMetadata(ctx context.Context, actor Actor, mediaID string, req *dto.UpdateMediaRequestDTO) (*models.MediaAsset, error)
+	DeleteMedia(ctx context.Context, actor Actor, mediaID string) error
+	OpenFile(ctx context.Context, mediaID, name string) (storage.Object, *FileInfo, error)
+}
+
+// MediaService adalah implementasi dari MediaServiceInterface
+type MediaService struct {
+	mediaRepo     repositories.MediaRepositoryInterface
+	storage       storage.Storage
+	validate      *validator.Validate
+	maxUploadSize int64
+	maxPixels     int64
+	publicBaseURL string
+}
+
+// NewMediaService membuat instance baru dari MediaService. publicBaseURL adalah awalan URL
+// tempat file media dilayani, contoh "/media/files". maxPixels membatasi lebar x tinggi gambar
+// yang boleh di-decode untuk membuat rendition.
+func NewMediaService(mediaRepo repositories.MediaRepositoryInterface, store storage.Storage, maxUploadSize, maxPixels int64, publicBaseURL string) *MediaService {
+	return &MediaService{
+		mediaRepo:     mediaRepo,
+		storage:       store,
+		validate:      validator.New(),
+		maxUploadSize: maxUploadSize,
+		maxPixels:     maxPixels,
+		publicBaseURL: strings.TrimSuffix(publicBaseURL, "/"),
+	}
+}
+
+// Upload memvalidasi isi file, menyimpannya ke storage, membuat rendition untuk gambar
+// dan mencatat media ke database. Tipe file ditentukan dari isi file, bukan dari nama
+// atau header Content-Type yang dikirim klien.
+func (s *MediaService) Upload(ctx context.Context, actor Actor, input UploadInput) (*models.MediaAsset, error) {
+	if !actor.Can(roles.PermMediaUpload) && !actor.Can(roles.PermMediaManage) {
+		return nil, ErrForbidden
+	}
+	if err := s.validate.Struct(input.Metadata); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	// Baca satu byte lebih dari batas untuk mendeteksi file yang terlalu besar
+	data, err := io.ReadAll(io.LimitReader(input.Content, s.maxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, ErrEmptyFile
+	}
+	if int64(len(data)) > s.maxUploadSize {
+		return nil, ErrFileTooLarge
+	}
+
+	mimeType := mimetype.Detect(data).String()
+	if i := strings.IndexByte(mimeType, ';'); i >= 0 {
+		mimeType = mimeType[:i]
+	}
+	ext, ok := allowedMimeTypes[mimeType]
+	if !ok {
+		return nil, ErrUnsupportedMediaType
+	}
+
+	asset := &models.MediaAsset{
+		ID:         uuid.New().String(),
+		UploaderID: actor.UserID,
+		Filename:   sanitizeFilename(input.Filename, ext),
+		MimeType:   mimeType,
+		SizeBytes:  int64(len(data)),
+		Checksum:   checksum(data),
+		AltText:    input.Metadata.AltText,
+		Caption:    input.Metadata.Caption,
+		Credit:     input.Metadata.Credit,
+	}
+	keyPrefix := fmt.Sprintf("%s/%s", time.Now().UTC().Format("2006/01"), asset.ID)
+	asset.StorageKey = keyPrefix + "/" + models.OriginalName + ext
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err == nil {
+		asset.Width, asset.Height = &config.Width, &config.Height
+		// Header gambar dibaca tanpa decode penuh. File kecil dapat mengklaim dimensi sangat besar
+		// sehingga decode untuk rendition menghabiskan memori; tolak sebelum decode dilakukan.
+		if int64(config.Width)*int64(config.Height) > s.maxPixels {
+			return nil, ErrImageTooLarge
+		}
+	} else if hasRenditions(mimeType) {
+		// Isi file tidak sesuai dengan signature-nya
+		return nil, ErrUnsupportedMediaType
+	}
+
+	storedKeys := []string{}
+	cleanup := func() {
+		for _, key := range storedKeys {
+			if err := s.storage.Delete(context.Background(), key); err != nil {
+				log.Printf("Gagal menghapus file media %s: %v", key, err)
+			}
+		}
+	}
+
+	if err := s.storage.Put(ctx, asset.StorageKey, bytes.NewReader(data), mimeType); err != nil {
+		return nil, err
+	}
+	storedKeys = append(storedKeys, asset.StorageKey)
+
+	renditions, err := s.createRenditions(ctx, data, mimeType, keyPrefix)
+	for _, rendition := range renditions {
+		storedKeys = append(storedKeys, rendition.StorageKey)
+	}
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	asset.Renditions = renditions
+
+	if err := s.mediaRepo.CreateMedia(ctx, asset); err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	log.Printf("Media %s (%s) diunggah oleh %s", asset.ID, asset.MimeType, actor.UserID)
+	return s.withURLs(asset), nil
+}
+
+// hasRenditions memeriksa apakah mime type dibuatkan rendition oleh createRenditions
+func hasRenditions(mimeType string) bool {
+	switch mimeType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// createRenditions membuat versi gambar dengan ukuran bawaan. Hanya format yang dapat
+// dibaca standard library (JPEG, PNG, GIF) yang dibuatkan rendition; GIF disimpan sebagai PNG.
+func (s *MediaService) createRenditions(ctx context.Context, data []byte, mimeType, keyPrefix string) ([]models.MediaRendition, error) {
+	var src image.Image
+	var err error
+	switch mimeType {
+	case "image/jpeg":
+		src, err = jpeg.Decode(bytes.NewReader(data))
+	case "image/png":
+		src, err = png.Decode(bytes.NewReader(data))
+	case "image/gif":
+		src, err = gif.Decode(bytes.NewReader(data))
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		// Isi file tidak sesuai dengan signature-nya
+		return nil, ErrUnsupportedMediaType
+	}
+
+	outputType, ext := "image/png", ".png"
+	if mimeType == "image/jpeg" {
+		outputType, ext = "image/jpeg", ".jpg"
+	}
+
+	renditions := []models.MediaRendition{}
+	for _, spec := range models.DefaultRenditions {
+		resized := imaging.Resize(src, spec.Width, spec.Height, spec.Mode)
+		// Gambar yang sudah lebih kecil dari ukuran fit tidak perlu rendition terpisah
+		if resized == src {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if outputType == "image/jpeg" {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: renditionJPEGQuality})
+		} else {
+			err = png.Encode(&buf, resized)
+		}
+		if err != nil {
+			return renditions, fmt.Errorf("gagal membuat rendition %s: %w", spec.Name, err)
+		}
+
+		bounds := resized.Bounds()
+		rendition := models.MediaRendition{
+			Name:       spec.Name,
+			StorageKey: keyPrefix + "/" + spec.Name + ext,
+			MimeType:   outputType,
+			SizeBytes:  int64(buf.Len()),
+			Checksum:   checksum(buf.Bytes()),
+			Width:      bounds.Dx(),
+			Height:     bounds.Dy(),
+		}
+		if err := s.storage.Put(ctx, rendition.StorageKey, &buf, outputType); err != nil {
+			return renditions, err
+		}
+		renditions = append(renditions, rendition)
+	}
+	return renditions, nil
+}
+
+// GetMedia mengambil detail media
+func (s *MediaService) GetMedia(ctx context.Context, actor Actor, mediaID string) (*models.MediaAsset, error) {
+	if !actor.Can(roles.PermMediaUpload) && !actor.Can(roles.PermMediaManage) {
+		return nil, ErrForbidden
+	}
+	asset, err := s.findMedia(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withURLs(asset), nil
+}
+
+// ListMedia mengambil daftar media. Actor tanpa media.manage hanya melihat unggahannya sendiri.
+func (s *MediaService) ListMedia(ctx context.Context, actor Actor, filter models.MediaFilter) ([]models.MediaAsset, int, error) {
+	if !actor.Can(roles.PermMediaManage) {
+		if !actor.Can(roles.PermMediaUpload) {
+			return nil, 0, ErrForbidden
+		}
+		filter.UploaderID = actor.UserID
+	}
+
+	assets, total, err := s.mediaRepo.ListMedia(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range assets {
+		s.withURLs(&assets[i])
+	}
+	return assets, total, nil
+}
+
+// UpdateMetadata mengubah alt text, caption dan credit media
+func (s *MediaService) UpdateMetadata(ctx context.Context, actor Actor, mediaID string, req *dto.UpdateMediaRequestDTO) (*models.MediaAsset, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	asset, err := s.findMedia(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if !canModify(actor, asset) {
+		return nil, ErrForbidden
+	}
+
+	if req.AltText != nil {
+		asset.AltText = req.AltText
+	}
+	if req.Caption != nil {
+		asset.Caption = req.Caption
+	}
+	if req.Credit != nil {
+		asset.Credit = req.Credit
+	}
+
+	if err := s.mediaRepo.UpdateMediaMetadata(ctx, asset); err != nil {
+		return nil, err
+	}
+	return s.withURLs(asset), nil
+}
+
+// DeleteMedia menghapus media dari database lalu menghapus file-filenya dari storage
+func (s *MediaService) DeleteMedia(ctx context.Context, actor Actor, mediaID string) error {
+	asset, err := s.findMedia(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if !canModify(actor, asset) {
+		return ErrForbidden
+	}
+
+	if err := s.mediaRepo.DeleteMedia(ctx, asset.ID); err != nil {
+		return err
+	}
+
+	// File yang gagal dihapus hanya dicatat karena data di database sudah terhapus
+	keys := []string{asset.StorageKey}
+	for _, rendition := range asset.Renditions {
+		keys = append(keys, rendition.StorageKey)
+	}
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("Gagal menghapus file media %s: %v", key, err)
+		}
+	}
+
+	log.Printf("Media %s dihapus oleh %s", asset.ID, actor.UserID)
+	return nil
+}
+
+// OpenFile membuka file asli (name "original") atau rendition media untuk dilayani ke publik
+func (s *MediaService) OpenFile(ctx context.Context, mediaID, name string) (storage.Object, *FileInfo, error) {
+	asset, err := s.findMedia(ctx, mediaID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := &FileInfo{
+		Filename: asset.Filename,
+		MimeType: asset.MimeType,
+		Checksum: asset.Checksum,
+	}
+	key := asset.StorageKey
+	if name != models.OriginalName {
+		found := false
+		for _, rendition := range asset.Renditions {
+			if rendition.Name == name {
+				key = rendition.StorageKey
+				info.MimeType = rendition.MimeType
+				info.Checksum = rendition.Checksum
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, ErrMediaNotFound
+		}
+	}
+
+	object, objectInfo, err := s.storage.Open(ctx, key)
+	if err != nil {
+		if err == storage.ErrObjectNotFound {
+			return nil, nil, ErrMediaNotFound
+		}
+		return nil, nil, err
+	}
+	info.ModTime = objectInfo.ModTime
+	return object, info, nil
+}
+
+func (s *MediaService) findMedia(ctx context.Context, mediaID string) (*models.MediaAsset, error) {
+	asset, err := s.mediaRepo.FindMediaByID(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, ErrMediaNotFound
+	}
+	return asset, nil
+}
+
+// withURLs mengisi URL publik untuk media dan semua rendition-nya
+func (s *MediaService) withURLs(asset *models.MediaAsset) *models.MediaAsset {
+	asset.URL = fmt.Sprintf("%s/%s/%s", s.publicBaseURL, asset.ID, models.OriginalName)
+	for i := range asset.Renditions {
+		asset.Renditions[i].URL = fmt.Sprintf("%s/%s/%s", s.publicBaseURL, asset.ID, asset.Renditions[i].Name)
+	}
+	return asset
+}
+
+// canModify menentukan apakah actor boleh mengubah atau menghapus media
+func canModify(actor Actor, asset *models.MediaAsset) bool {
+	if actor.Can(roles.PermMediaManage) {
+		return true
+	}
+	return asset.UploaderID == actor.UserID && actor.Can(roles.PermMediaUpload)
+}
+
+// sanitizeFilename mengambil nama dasar file dari klien dan menyesuaikan ekstensinya
+// dengan tipe file hasil deteksi
+func sanitizeFilename(filename, ext string) string {
+	base := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	base = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == '"' || r == '/' {
+			return -1
+		}
+		return r
+	}, base)
+	if base == "" || base == "." {
+		base = "file"
+	}
+	if len(base) > 200 {
+		base = base[:200]
+	}
+	return base + ext
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// UpdateProfileRequestDTO digunakan untuk PATCH /profile dengan semantik JSON merge patch:
+// field yang tidak dikirim tidak diubah, field bernilai null dikosongkan, dan field lain
+// diganti dengan nilai baru. UpdatedAt wajib berisi updated_at profil yang terakhir dibaca
+// klien agar perubahan dari permintaan lain tidak tertimpa diam-diam.
+type UpdateProfileRequestDTO struct {
+	UpdatedAt   *time.Time `json:"updated_at" validate:"required"`
+	FirstName   *string    `json:"first_name" validate:"omitnil,min=1,max=255"`
+	LastName    *string    `json:"last_name" validate:"omitempty,max=255"`
+	Phone       *string    `json:"phone" validate:"omitempty,max=25"`
+	Bio         *string    `json:"bio" validate:"omitempty,max=2000"`
+	NIK         *string    `json:"nik" validate:"omitempty,len=16,numeric"`
+	DateOfBirth *string    `json:"date_of_birth" validate:"omitempty,datetime=2006-01-02"`
+	Gender      *string    `json:"gender" validate:"omitempty,oneof=male female"`
+	Address     *string    `json:"address" validate:"omitempty,max=1000"`
+	Village     *string    `json:"village" validate:"omitempty,max=255"`
+	District    *string    `json:"district" validate:"omitempty,max=255"`
+	City        *string    `json:"city" validate:"omitempty,max=255"`
+	Province    *string    `json:"province" validate:"omitempty,max=255"`
+	PostalCode  *string    `json:"postal_code" validate:"omitempty,len=5,numeric"`
+
+	// Kode wilayah Kemendagri tanpa titik. Jika dikirim, nama province/city/district/village
+	// diisi dari data wilayah dan kombinasinya wajib membentuk hierarki yang valid.
+	ProvinceCode *string `json:"province_code" validate:"omitempty,len=2,numeric"`
+	CityCode     *string `json:"city_code" validate:"omitempty,len=4,numeric"`
+	DistrictCode *string `json:"district_code" validate:"omitempty,len=6,numeric"`
+	VillageCode  *string `json:"village_code" validate:"omitempty,len=10,numeric"`
+
+	// FillFromNIK mengisi tanggal lahir, jenis kelamin dan kode wilayah dari NIK
+	// untuk field yang tidak dikirim di request yang sama
+	FillFromNIK bool `json:"fill_from_nik"`
+
+	present map[string]bool
+}
+
+// UnmarshalJSON mencatat field yang dikirim klien sehingga null dapat dibedakan dari
+// field yang tidak dikirim. Field yang tidak dikenal ditolak.
+func (d *UpdateProfileRequestDTO) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	type plain UpdateProfileRequestDTO
+	var decoded plain
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&decoded); err != nil {
+		return err
+	}
+
+	*d = UpdateProfileRequestDTO(decoded)
+	d.present = make(map[string]bool, len(raw))
+	for field := range raw {
+		d.present[field] = true
+	}
+	return nil
+}
+
+// Has melaporkan apakah field JSON dengan nama tersebut dikirim di request
+func (d *UpdateProfileRequestDTO) Has(field string) bool {
+	return d.present[field]
+}
+
+// ConfirmPhoneVerificationRequestDTO digunakan untuk mengirim kode OTP yang diterima lewat SMS
+type ConfirmPhoneVerificationRequestDTO struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// PhoneVerificationResponseDTO dikembalikan setelah kode OTP dikirim
+type PhoneVerificationResponseDTO struct {
+	Phone     string    `json:"phone"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -1,11 +1,19 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"time"
 
+	"github.com/jokosaputro95/cms-go/internal/modules/profile/dto"
 	"github.com/jokosaputro95/cms-go/internal/modules/profile/services"
 	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
 	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+
+	"github.com/go-playground/validator/v10"
 )
 
 // ProfileHandler menangani permintaan HTTP untuk profil pengguna
@@ -18,6 +26,18 @@ func NewProfileHandler(profileService services.ProfileServiceInterface) *Profile
 	return &ProfileHandler{profileService: profileService}
 }
 
+// Profile menangani pembacaan (GET) dan perubahan sebagian (PATCH) profil pengguna yang login
+func (h *ProfileHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetProfile(w, r)
+	case http.MethodPatch:
+		h.UpdateProfile(w, r)
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
 // GetProfile menangani permintaan untuk mendapatkan data profil pengguna
 func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	// Ambil userID dari context
@@ -29,9 +49,130 @@ func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 
 	profile, err := h.profileService.GetProfile(r.Context(), userID)
 	if err != nil {
-		api.SendError(w, http.StatusInternalServerError, "Failed to get user profile")
+		h.sendServiceError(w, err, "Failed to get user profile")
 		return
 	}
 
 	api.SendSuccess(w, http.StatusOK, "Profile fetched successfully", profile, nil)
-}
\ No newline at end of file
+}
+
+// UpdateProfile menangani perubahan sebagian profil dengan semantik JSON merge patch
+func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	var req dto.UpdateProfileRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	profile, err := h.profileService.UpdateProfile(r.Context(), userID, &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to update user profile")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Profile updated successfully", profile, nil)
+}
+
+// RequestPhoneVerification mengirim kode OTP ke nomor telepon profil (POST)
+func (h *ProfileHandler) RequestPhoneVerification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	result, err := h.profileService.RequestPhoneVerification(r.Context(), userID)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to send phone verification code")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Verification code sent", result, nil)
+}
+
+// ConfirmPhoneVerification memeriksa kode OTP dan menandai nomor telepon terverifikasi (POST)
+func (h *ProfileHandler) ConfirmPhoneVerification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	var req dto.ConfirmPhoneVerificationRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	profile, err := h.profileService.ConfirmPhoneVerification(r.Context(), userID, &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to verify phone number")
+		return
+	}
+
+	api.SendSuccess(w, http.StatusOK, "Phone number verified successfully", profile, nil)
+}
+
+// sendServiceError memetakan error dari service ke response HTTP
+func (h *ProfileHandler) sendServiceError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var validationErrs validator.ValidationErrors
+	var throttleErr *services.ThrottleError
+	switch {
+	case errors.As(err, &validationErrs):
+		details := make(map[string]interface{}, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			details[fieldErr.Field()] = fieldErr.Tag()
+		}
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", details)
+	case errors.Is(err, services.ErrFirstNameRequired), errors.Is(err, services.ErrInvalidDateOfBirth),
+		errors.Is(err, services.ErrInvalidNIK), errors.Is(err, services.ErrNIKRequired),
+		errors.Is(err, services.ErrInvalidRegion), errors.Is(err, services.ErrInvalidPhone),
+		errors.Is(err, services.ErrPhoneMissing):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "validation_error", nil)
+	case errors.Is(err, services.ErrNIKBirthDateMismatch), errors.Is(err, services.ErrNIKGenderMismatch):
+		api.SendDetailedError(w, http.StatusUnprocessableEntity, err.Error(), "nik_mismatch", nil)
+	case errors.Is(err, services.ErrOTPInvalid), errors.Is(err, services.ErrOTPExpired),
+		errors.Is(err, services.ErrPhoneChangedSinceOTP):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "invalid_code", nil)
+	case errors.Is(err, services.ErrOTPTooManyAttempts):
+		api.SendDetailedError(w, http.StatusTooManyRequests, err.Error(), "too_many_attempts", nil)
+	case errors.As(err, &throttleErr):
+		retryAfter := int(time.Until(throttleErr.RetryAt).Seconds())
+		details := map[string]interface{}{
+			"retry_at":    throttleErr.RetryAt,
+			"retry_after": retryAfter,
+		}
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+		api.SendDetailedError(w, http.StatusTooManyRequests, throttleErr.Error(), "too_many_requests", details)
+	case errors.Is(err, services.ErrPhoneAlreadyVerified):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "already_verified", nil)
+	case errors.Is(err, services.ErrProfileNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	case errors.Is(err, services.ErrPhoneTaken):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "phone_exists", nil)
+	case errors.Is(err, services.ErrNIKTaken):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "nik_exists", nil)
+	case errors.Is(err, services.ErrProfileConflict):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "edit_conflict", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// PhoneVerification merepresentasikan tabel phone_verifications. Kode OTP hanya
+// disimpan dalam bentuk hash.
+type PhoneVerification struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	Phone      string     `json:"phone" db:"phone"`
+	CodeHash   string     `json:"-" db:"code_hash"`
+	Attempts   int        `json:"attempts" db:"attempts"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	ConsumedAt *time.Time `json:"consumed_at,omitempty" db:"consumed_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// PhoneVerificationStats merangkum OTP yang dikirim ke pengguna dalam suatu rentang waktu
+type PhoneVerificationStats struct {
+	Count int
+	// FirstSentAt dan LastSentAt bernilai nil jika belum ada OTP yang dikirim
+	FirstSentAt *time.Time
+	LastSentAt  *time.Time
+}
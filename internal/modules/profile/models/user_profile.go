@@ -11,6 +11,7 @@ type UserProfile struct {
     FirstName    string     `json:"first_name" db:"first_name"`
     LastName     *string    `json:"last_name,omitempty" db:"last_name"`
     Phone        *string    `json:"phone,omitempty" db:"phone"` // Now nullable
+    PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty" db:"phone_verified_at"`
     Bio          *string    `json:"bio,omitempty" db:"bio"`
     AvatarURL    *string    `json:"avatar_url,omitempty" db:"avatar_url"`
     NIK          *string    `json:"nik,omitempty" db:"nik"`
@@ -22,6 +23,10 @@ type UserProfile struct {
     City         *string    `json:"city,omitempty" db:"city"`
     Province     *string    `json:"province,omitempty" db:"province"`
     PostalCode   *string    `json:"postal_code,omitempty" db:"postal_code"`
+    ProvinceCode *string    `json:"province_code,omitempty" db:"province_code"`
+    CityCode     *string    `json:"city_code,omitempty" db:"city_code"`
+    DistrictCode *string    `json:"district_code,omitempty" db:"district_code"`
+    VillageCode  *string    `json:"village_code,omitempty" db:"village_code"`
     Country      string     `json:"country" db:"country"`
     CreatedAt    time.Time  `json:"created_at" db:"created_at"`
     UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
@@ -3,14 +3,36 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jokosaputro95/cms-go/internal/modules/profile/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrDuplicatePhone dikembalikan ketika nomor telepon sudah dipakai profil lain
+	ErrDuplicatePhone = errors.New("nomor telepon sudah digunakan")
+	// ErrDuplicateNIK dikembalikan ketika NIK sudah dipakai profil lain
+	ErrDuplicateNIK = errors.New("NIK sudah digunakan")
+	// ErrStaleProfile dikembalikan ketika profil sudah diubah sejak terakhir dibaca
+	ErrStaleProfile = errors.New("profil sudah diubah oleh permintaan lain")
+	// ErrPhoneChanged dikembalikan ketika nomor telepon profil berubah setelah OTP dikirim
+	ErrPhoneChanged = errors.New("nomor telepon sudah berubah sejak kode dikirim")
+	// ErrVerificationUsed dikembalikan ketika OTP sudah dipakai atau dibatalkan oleh OTP baru
+	ErrVerificationUsed = errors.New("kode verifikasi sudah tidak berlaku")
 )
 
 // ProfileRepositoryInterface mendefinisikan kontrak untuk interaksi database profil
 type ProfileRepositoryInterface interface {
 	FindProfileByUserID(ctx context.Context, userID string) (*models.UserProfile, error)
+	UpdateProfile(ctx context.Context, profile *models.UserProfile, expectedUpdatedAt time.Time) error
+	SavePhoneVerification(ctx context.Context, verification *models.PhoneVerification, limit OTPLimit) (*models.PhoneVerificationStats, bool, error)
+	FindActivePhoneVerification(ctx context.Context, userID string) (*models.PhoneVerification, error)
+	ReservePhoneVerificationAttempt(ctx context.Context, verificationID string, maxAttempts int) (int, bool, error)
+	MarkPhoneVerified(ctx context.Context, verification *models.PhoneVerification) error
 }
 
 // ProfileRepository adalah implementasi dari ProfileRepositoryInterface
@@ -27,7 +49,8 @@ func NewProfileRepository(db *sql.DB) *ProfileRepository {
 func (r *ProfileRepository) FindProfileByUserID(ctx context.Context, userID string) (*models.UserProfile, error) {
     query := `
         SELECT 
-            id, user_id, first_name, last_name, phone, bio, avatar_url, nik, date_of_birth, gender, address, village, district, city, province, postal_code, country, created_at, updated_at
+            id, user_id, first_name, last_name, phone, phone_verified_at, bio, avatar_url, nik, date_of_birth, gender, address, village, district, city, province, postal_code,
+            province_code, city_code, district_code, village_code, country, created_at, updated_at
         FROM user_profiles 
         WHERE user_id = $1
     `
@@ -38,6 +61,7 @@ func (r *ProfileRepository) FindProfileByUserID(ctx context.Context, userID stri
         &profile.FirstName,
         &profile.LastName,
         &profile.Phone,
+        &profile.PhoneVerifiedAt,
         &profile.Bio,
         &profile.AvatarURL,
         &profile.NIK,
@@ -49,6 +73,10 @@ func (r *ProfileRepository) FindProfileByUserID(ctx context.Context, userID stri
         &profile.City,
         &profile.Province,
         &profile.PostalCode,
+        &profile.ProvinceCode,
+        &profile.CityCode,
+        &profile.DistrictCode,
+        &profile.VillageCode,
         &profile.Country,
         &profile.CreatedAt,
         &profile.UpdatedAt,
@@ -60,4 +88,222 @@ func (r *ProfileRepository) FindProfileByUserID(ctx context.Context, userID stri
         return nil, fmt.Errorf("gagal mencari profil pengguna: %w", err)
     }
     return profile, nil
-}
\ No newline at end of file
+}
+
+// UpdateProfile menyimpan semua field profil yang dapat diubah pengguna. Perubahan hanya
+// diterapkan jika updated_at di database masih sama dengan expectedUpdatedAt; jika tidak,
+// ErrStaleProfile dikembalikan. updated_at baru diisi oleh trigger dan disalin ke profile.
+func (r *ProfileRepository) UpdateProfile(ctx context.Context, profile *models.UserProfile, expectedUpdatedAt time.Time) error {
+	query := `
+		UPDATE user_profiles
+		SET first_name = $1, last_name = $2, phone = $3, bio = $4, nik = $5, date_of_birth = $6,
+			gender = $7, address = $8, village = $9, district = $10, city = $11, province = $12,
+			postal_code = $13, province_code = $14, city_code = $15, district_code = $16,
+			village_code = $17, phone_verified_at = $18
+		WHERE user_id = $19 AND updated_at = $20
+		RETURNING updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		profile.FirstName,
+		profile.LastName,
+		profile.Phone,
+		profile.Bio,
+		profile.NIK,
+		profile.DateOfBirth,
+		profile.Gender,
+		profile.Address,
+		profile.Village,
+		profile.District,
+		profile.City,
+		profile.Province,
+		profile.PostalCode,
+		profile.ProvinceCode,
+		profile.CityCode,
+		profile.DistrictCode,
+		profile.VillageCode,
+		profile.PhoneVerifiedAt,
+		profile.UserID,
+		expectedUpdatedAt,
+	).Scan(&profile.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrStaleProfile
+		case isUniqueViolation(err, "user_profiles_phone_key"):
+			return ErrDuplicatePhone
+		case isUniqueViolation(err, "user_profiles_nik_key"):
+			return ErrDuplicateNIK
+		}
+		return fmt.Errorf("gagal memperbarui profil pengguna: %w", err)
+	}
+	return nil
+}
+
+// OTPLimit membatasi pengiriman OTP per pengguna: jeda minimal antar OTP dan jumlah OTP
+// maksimal dalam 24 jam terakhir
+type OTPLimit struct {
+	Cooldown  time.Duration
+	MaxPerDay int
+}
+
+// SavePhoneVerification menyimpan OTP baru dan membatalkan OTP lain yang masih aktif
+// sehingga hanya kode terakhir yang dapat dipakai. Baris profil dikunci dengan FOR UPDATE
+// selama riwayat pengiriman diperiksa, sehingga permintaan paralel tidak dapat melewati
+// cooldown maupun batas harian. Hasil false berarti OTP tidak disimpan karena dibatasi;
+// statistik yang dikembalikan dipakai untuk menghitung waktu coba lagi.
+func (r *ProfileRepository) SavePhoneVerification(ctx context.Context, verification *models.PhoneVerification, limit OTPLimit) (*models.PhoneVerificationStats, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked int
+	err = tx.QueryRowContext(ctx, `SELECT 1 FROM user_profiles WHERE user_id = $1 FOR UPDATE`, verification.UserID).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, fmt.Errorf("profil tidak ditemukan untuk pengguna: %s", verification.UserID)
+		}
+		return nil, false, fmt.Errorf("gagal mengunci profil: %w", err)
+	}
+
+	statsQuery := `
+		SELECT COUNT(*), MIN(created_at), MAX(created_at)
+		FROM phone_verifications
+		WHERE user_id = $1 AND created_at >= $2
+	`
+	stats := &models.PhoneVerificationStats{}
+	var firstSentAt, lastSentAt sql.NullTime
+	err = tx.QueryRowContext(ctx, statsQuery, verification.UserID, verification.CreatedAt.Add(-24*time.Hour)).Scan(&stats.Count, &firstSentAt, &lastSentAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal menghitung kode verifikasi telepon: %w", err)
+	}
+	if firstSentAt.Valid {
+		stats.FirstSentAt = &firstSentAt.Time
+	}
+	if lastSentAt.Valid {
+		stats.LastSentAt = &lastSentAt.Time
+	}
+
+	inCooldown := stats.LastSentAt != nil && verification.CreatedAt.Sub(*stats.LastSentAt) < limit.Cooldown
+	if inCooldown || stats.Count >= limit.MaxPerDay {
+		return stats, false, nil
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE phone_verifications SET consumed_at = NOW() WHERE user_id = $1 AND consumed_at IS NULL`,
+		verification.UserID,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal membatalkan kode verifikasi lama: %w", err)
+	}
+
+	query := `
+		INSERT INTO phone_verifications (id, user_id, phone, code_hash, attempts, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		verification.ID,
+		verification.UserID,
+		verification.Phone,
+		verification.CodeHash,
+		verification.Attempts,
+		verification.ExpiresAt,
+		verification.CreatedAt,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal menyimpan kode verifikasi telepon: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return stats, true, nil
+}
+
+// FindActivePhoneVerification mencari OTP terakhir yang belum dipakai untuk pengguna
+func (r *ProfileRepository) FindActivePhoneVerification(ctx context.Context, userID string) (*models.PhoneVerification, error) {
+	query := `
+		SELECT id, user_id, phone, code_hash, attempts, expires_at, consumed_at, created_at
+		FROM phone_verifications
+		WHERE user_id = $1 AND consumed_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	verification := &models.PhoneVerification{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&verification.ID,
+		&verification.UserID,
+		&verification.Phone,
+		&verification.CodeHash,
+		&verification.Attempts,
+		&verification.ExpiresAt,
+		&verification.ConsumedAt,
+		&verification.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari kode verifikasi telepon: %w", err)
+	}
+	return verification, nil
+}
+
+// ReservePhoneVerificationAttempt menambah jumlah percobaan secara atomik sebelum kode
+// dibandingkan, sehingga permintaan paralel tidak dapat melewati batas percobaan.
+// Hasil false berarti batas percobaan sudah tercapai.
+func (r *ProfileRepository) ReservePhoneVerificationAttempt(ctx context.Context, verificationID string, maxAttempts int) (int, bool, error) {
+	query := `
+		UPDATE phone_verifications
+		SET attempts = attempts + 1
+		WHERE id = $1 AND attempts < $2
+		RETURNING attempts
+	`
+	var attempts int
+	if err := r.db.QueryRowContext(ctx, query, verificationID, maxAttempts).Scan(&attempts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("gagal memperbarui percobaan verifikasi telepon: %w", err)
+	}
+	return attempts, true, nil
+}
+
+// MarkPhoneVerified menandai OTP sudah dipakai dan nomor telepon profil terverifikasi.
+// Nomor hanya ditandai jika masih sama dengan nomor tujuan OTP.
+func (r *ProfileRepository) MarkPhoneVerified(ctx context.Context, verification *models.PhoneVerification) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE phone_verifications SET consumed_at = NOW() WHERE id = $1 AND consumed_at IS NULL`,
+		verification.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menandai kode verifikasi telepon: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+		return ErrVerificationUsed
+	}
+
+	res, err = tx.ExecContext(ctx,
+		`UPDATE user_profiles SET phone_verified_at = NOW() WHERE user_id = $1 AND phone = $2`,
+		verification.UserID, verification.Phone,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menandai nomor telepon terverifikasi: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+		return ErrPhoneChanged
+	}
+	return tx.Commit()
+}
+
+// isUniqueViolation memeriksa apakah error adalah pelanggaran constraint unik tertentu
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/profile/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/profile/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/profile/repositories"
+	"github.com/jokosaputro95/cms-go/internal/pkg/phone"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	ErrPhoneMissing         = ProfileServiceError("nomor telepon belum diisi")
+	ErrPhoneAlreadyVerified = ProfileServiceError("nomor telepon sudah terverifikasi")
+	ErrOTPInvalid           = ProfileServiceError("kode verifikasi salah")
+	ErrOTPExpired           = ProfileServiceError("kode verifikasi tidak ditemukan atau kedaluwarsa, minta kode baru")
+	ErrOTPTooManyAttempts   = ProfileServiceError("terlalu banyak percobaan kode yang salah, minta kode baru")
+	ErrPhoneChangedSinceOTP = ProfileServiceError("nomor telepon sudah berubah sejak kode dikirim, minta kode baru")
+
+	otpLength      = 6
+	otpTTL         = 5 * time.Minute
+	otpMaxAttempts = 5
+	// Batas pengiriman SMS per pengguna agar endpoint tidak disalahgunakan
+	otpResendCooldown = time.Minute
+	maxOTPPerDay      = 5
+)
+
+// otpLimit adalah batas pengiriman SMS OTP yang diperiksa repository saat OTP disimpan
+var otpLimit = repositories.OTPLimit{Cooldown: otpResendCooldown, MaxPerDay: maxOTPPerDay}
+
+// ThrottleError dikembalikan ketika permintaan kode OTP terlalu sering
+type ThrottleError struct {
+	Message string
+	RetryAt time.Time
+}
+
+func (e ThrottleError) Error() string {
+	return e.Message
+}
+
+// RequestPhoneVerification membuat OTP 6 digit untuk nomor telepon profil dan mengirimkannya
+// lewat SMS. OTP sebelumnya yang belum dipakai otomatis dibatalkan.
+func (s *ProfileService) RequestPhoneVerification(ctx context.Context, userID string) (*dto.PhoneVerificationResponseDTO, error) {
+	profile, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Phone == nil {
+		return nil, ErrPhoneMissing
+	}
+	if profile.PhoneVerifiedAt != nil {
+		return nil, ErrPhoneAlreadyVerified
+	}
+
+	code, err := generateOTP()
+	if err != nil {
+		return nil, err
+	}
+	codeHash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("gagal melakukan hashing kode verifikasi: %w", err)
+	}
+
+	now := time.Now().UTC()
+	verification := &models.PhoneVerification{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Phone:     *profile.Phone,
+		CodeHash:  string(codeHash),
+		ExpiresAt: now.Add(otpTTL),
+		CreatedAt: now,
+	}
+	stats, saved, err := s.profileRepo.SavePhoneVerification(ctx, verification, otpLimit)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, otpThrottleError(stats, now)
+	}
+
+	message := fmt.Sprintf("Kode verifikasi Anda: %s. Berlaku %d menit. Jangan berikan kode ini kepada siapa pun.", code, int(otpTTL.Minutes()))
+	if err := s.smsSender.Send(ctx, verification.Phone, message); err != nil {
+		return nil, fmt.Errorf("gagal mengirim SMS verifikasi: %w", err)
+	}
+
+	log.Printf("SECURITY: Kode verifikasi telepon dikirim ke %s untuk pengguna %s", phone.Mask(verification.Phone), userID)
+	return &dto.PhoneVerificationResponseDTO{
+		Phone:     phone.Mask(verification.Phone),
+		ExpiresAt: verification.ExpiresAt,
+	}, nil
+}
+
+// ConfirmPhoneVerification memeriksa OTP dan menandai nomor telepon profil terverifikasi
+func (s *ProfileService) ConfirmPhoneVerification(ctx context.Context, userID string, req *dto.ConfirmPhoneVerificationRequestDTO) (*models.UserProfile, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	verification, err := s.profileRepo.FindActivePhoneVerification(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if verification == nil || time.Now().After(verification.ExpiresAt) {
+		return nil, ErrOTPExpired
+	}
+
+	// Percobaan dicatat sebelum kode dibandingkan agar permintaan paralel tidak dapat
+	// menebak lebih dari otpMaxAttempts kali
+	attempts, reserved, err := s.profileRepo.ReservePhoneVerificationAttempt(ctx, verification.ID, otpMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return nil, ErrOTPTooManyAttempts
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(verification.CodeHash), []byte(req.Code)); err != nil {
+		if attempts >= otpMaxAttempts {
+			return nil, ErrOTPTooManyAttempts
+		}
+		return nil, ErrOTPInvalid
+	}
+
+	if err := s.profileRepo.MarkPhoneVerified(ctx, verification); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrPhoneChanged):
+			return nil, ErrPhoneChangedSinceOTP
+		case errors.Is(err, repositories.ErrVerificationUsed):
+			return nil, ErrOTPExpired
+		}
+		return nil, err
+	}
+
+	log.Printf("SECURITY: Nomor telepon %s terverifikasi untuk pengguna %s", phone.Mask(verification.Phone), userID)
+	return s.GetProfile(ctx, userID)
+}
+
+// otpThrottleError menjelaskan batas yang membuat OTP tidak disimpan: jeda antar SMS atau
+// jumlah SMS per pengguna per hari
+func otpThrottleError(stats *models.PhoneVerificationStats, now time.Time) *ThrottleError {
+	if stats.LastSentAt != nil && now.Sub(*stats.LastSentAt) < otpResendCooldown {
+		return &ThrottleError{
+			Message: "Please wait before requesting another verification code",
+			RetryAt: stats.LastSentAt.Add(otpResendCooldown),
+		}
+	}
+	// Jendela 24 jam bergeser, sehingga kuota bertambah saat OTP tertua keluar dari jendela
+	retryAt := now.Add(24 * time.Hour)
+	if stats.FirstSentAt != nil {
+		retryAt = stats.FirstSentAt.Add(24 * time.Hour)
+	}
+	return &ThrottleError{
+		Message: "Daily verification code limit reached, please try again later",
+		RetryAt: retryAt,
+	}
+}
+
+// generateOTP membuat kode numerik acak sepanjang otpLength dengan crypto/rand
+func generateOTP() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < otpLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("gagal membuat kode verifikasi: %w", err)
+	}
+	return fmt.Sprintf("%0*d", otpLength, n.Int64()), nil
+}
@@ -2,25 +2,83 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/jokosaputro95/cms-go/internal/modules/profile/dto"
 	"github.com/jokosaputro95/cms-go/internal/modules/profile/models"
 	"github.com/jokosaputro95/cms-go/internal/modules/profile/repositories"
+	region_models "github.com/jokosaputro95/cms-go/internal/modules/region/models"
+	"github.com/jokosaputro95/cms-go/internal/pkg/nik"
+	"github.com/jokosaputro95/cms-go/internal/pkg/phone"
+	"github.com/jokosaputro95/cms-go/internal/pkg/sms"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ProfileServiceError adalah tipe error kustom untuk service profil
+type ProfileServiceError string
+
+func (e ProfileServiceError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrProfileNotFound      = ProfileServiceError("profil pengguna tidak ditemukan")
+	ErrProfileConflict      = ProfileServiceError("profil sudah diubah sejak terakhir dibaca, muat ulang profil lalu coba lagi")
+	ErrPhoneTaken           = ProfileServiceError("nomor telepon sudah digunakan pengguna lain")
+	ErrNIKTaken             = ProfileServiceError("NIK sudah digunakan pengguna lain")
+	ErrFirstNameRequired    = ProfileServiceError("first_name tidak boleh kosong")
+	ErrInvalidDateOfBirth   = ProfileServiceError("tanggal lahir tidak boleh di masa depan")
+	ErrInvalidNIK           = ProfileServiceError("NIK tidak valid")
+	ErrNIKRequired          = ProfileServiceError("fill_from_nik membutuhkan NIK pada profil")
+	ErrNIKBirthDateMismatch = ProfileServiceError("tanggal lahir tidak sesuai dengan NIK")
+	ErrNIKGenderMismatch    = ProfileServiceError("jenis kelamin tidak sesuai dengan NIK")
+	ErrInvalidRegion        = ProfileServiceError("kombinasi wilayah tidak valid")
+	ErrInvalidPhone         = ProfileServiceError("nomor telepon tidak valid")
 )
 
+// regionFields adalah field request yang mengubah kode wilayah profil
+var regionFields = []string{"province_code", "city_code", "district_code", "village_code"}
+
+// RegionResolver memvalidasi hierarki kode wilayah dan mengembalikan data wilayahnya
+type RegionResolver interface {
+	ResolvePath(provinceCode, regencyCode, districtCode, villageCode string) (*region_models.RegionPath, error)
+}
+
 // ProfileServiceInterface mendefinisikan kontrak untuk service profil
 type ProfileServiceInterface interface {
 	GetProfile(ctx context.Context, userID string) (*models.UserProfile, error)
+	UpdateProfile(ctx context.Context, userID string, req *dto.UpdateProfileRequestDTO) (*models.UserProfile, error)
+	RequestPhoneVerification(ctx context.Context, userID string) (*dto.PhoneVerificationResponseDTO, error)
+	ConfirmPhoneVerification(ctx context.Context, userID string, req *dto.ConfirmPhoneVerificationRequestDTO) (*models.UserProfile, error)
 }
 
 // ProfileService adalah implementasi dari ProfileServiceInterface
 type ProfileService struct {
 	profileRepo repositories.ProfileRepositoryInterface
+	regions     RegionResolver
+	smsSender   sms.SMSSender
+	validate    *validator.Validate
 }
 
 // NewProfileService membuat instance baru dari ProfileService
-func NewProfileService(profileRepo repositories.ProfileRepositoryInterface) *ProfileService {
-	return &ProfileService{profileRepo: profileRepo}
+func NewProfileService(profileRepo repositories.ProfileRepositoryInterface, regions RegionResolver, smsSender sms.SMSSender) *ProfileService {
+	validate := validator.New()
+	// Gunakan nama field JSON pada error validasi agar mudah dicocokkan oleh klien
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	})
+
+	return &ProfileService{
+		profileRepo: profileRepo,
+		regions:     regions,
+		smsSender:   smsSender,
+		validate:    validate,
+	}
 }
 
 // GetProfile mengambil data profil pengguna dari database
@@ -30,7 +88,245 @@ func (s *ProfileService) GetProfile(ctx context.Context, userID string) (*models
 		return nil, err
 	}
 	if profile == nil {
-		return nil, fmt.Errorf("profil pengguna tidak ditemukan")
+		return nil, ErrProfileNotFound
+	}
+	return profile, nil
+}
+
+// UpdateProfile menerapkan perubahan sebagian pada profil pengguna. Hanya field yang dikirim
+// yang diubah; null atau string kosong mengosongkan field opsional.
+func (s *ProfileService) UpdateProfile(ctx context.Context, userID string, req *dto.UpdateProfileRequestDTO) (*models.UserProfile, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	profile, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tolak lebih awal jika klien bekerja dengan salinan profil yang sudah usang.
+	// Repository tetap memeriksa ulang di query UPDATE untuk menangani balapan.
+	expectedUpdatedAt := req.UpdatedAt.Truncate(time.Microsecond)
+	if !profile.UpdatedAt.Equal(expectedUpdatedAt) {
+		return nil, ErrProfileConflict
+	}
+
+	if req.Has("first_name") {
+		if req.FirstName == nil || strings.TrimSpace(*req.FirstName) == "" {
+			return nil, ErrFirstNameRequired
+		}
+		profile.FirstName = strings.TrimSpace(*req.FirstName)
+	}
+
+	mergeOptional(req, "last_name", req.LastName, &profile.LastName)
+	if req.Has("phone") {
+		if err := applyPhone(req.Phone, profile); err != nil {
+			return nil, err
+		}
+	}
+	mergeOptional(req, "bio", req.Bio, &profile.Bio)
+	mergeOptional(req, "nik", req.NIK, &profile.NIK)
+	mergeOptional(req, "gender", req.Gender, &profile.Gender)
+	mergeOptional(req, "address", req.Address, &profile.Address)
+	mergeOptional(req, "village", req.Village, &profile.Village)
+	mergeOptional(req, "district", req.District, &profile.District)
+	mergeOptional(req, "city", req.City, &profile.City)
+	mergeOptional(req, "province", req.Province, &profile.Province)
+	mergeOptional(req, "postal_code", req.PostalCode, &profile.PostalCode)
+	mergeOptional(req, "province_code", req.ProvinceCode, &profile.ProvinceCode)
+	mergeOptional(req, "city_code", req.CityCode, &profile.CityCode)
+	mergeOptional(req, "district_code", req.DistrictCode, &profile.DistrictCode)
+	mergeOptional(req, "village_code", req.VillageCode, &profile.VillageCode)
+
+	if req.Has("date_of_birth") {
+		profile.DateOfBirth = nil
+		if req.DateOfBirth != nil && *req.DateOfBirth != "" {
+			dateOfBirth, err := time.Parse("2006-01-02", *req.DateOfBirth)
+			if err != nil {
+				return nil, fmt.Errorf("validasi input gagal: %w", err)
+			}
+			if dateOfBirth.After(time.Now().UTC()) {
+				return nil, ErrInvalidDateOfBirth
+			}
+			profile.DateOfBirth = &dateOfBirth
+		}
+	}
+
+	if req.FillFromNIK || req.Has("nik") || req.Has("date_of_birth") || req.Has("gender") {
+		if err := s.reconcileNIK(req, profile); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.FillFromNIK || hasAny(req, regionFields...) {
+		if err := s.applyRegion(req, profile); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.profileRepo.UpdateProfile(ctx, profile, expectedUpdatedAt); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrStaleProfile):
+			return nil, ErrProfileConflict
+		case errors.Is(err, repositories.ErrDuplicatePhone):
+			return nil, ErrPhoneTaken
+		case errors.Is(err, repositories.ErrDuplicateNIK):
+			return nil, ErrNIKTaken
+		}
+		return nil, err
 	}
 	return profile, nil
-}
\ No newline at end of file
+}
+
+// reconcileNIK memastikan tanggal lahir dan jenis kelamin profil sesuai dengan yang dikodekan
+// di NIK. Jika FillFromNIK aktif, field yang tidak dikirim di request diisi dari NIK terlebih dahulu.
+func (s *ProfileService) reconcileNIK(req *dto.UpdateProfileRequestDTO, profile *models.UserProfile) error {
+	if profile.NIK == nil {
+		if req.FillFromNIK {
+			return ErrNIKRequired
+		}
+		return nil
+	}
+
+	parsed, err := nik.Parse(*profile.NIK)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidNIK, err)
+	}
+
+	if req.FillFromNIK {
+		if !req.Has("date_of_birth") {
+			birthDate := parsed.BirthDate
+			profile.DateOfBirth = &birthDate
+		}
+		if !req.Has("gender") {
+			gender := string(parsed.Gender)
+			profile.Gender = &gender
+		}
+		if !hasAny(req, regionFields...) {
+			s.fillRegionFromNIK(profile, parsed)
+		}
+	}
+
+	if profile.DateOfBirth != nil && !parsed.MatchesBirthDate(*profile.DateOfBirth) {
+		return ErrNIKBirthDateMismatch
+	}
+	if profile.Gender != nil && *profile.Gender != string(parsed.Gender) {
+		return ErrNIKGenderMismatch
+	}
+	return nil
+}
+
+// fillRegionFromNIK mengisi kode wilayah dari NIK hingga tingkat terdalam yang dikenal data
+// wilayah. Kode pada NIK lama bisa merujuk wilayah yang sudah dimekarkan, sehingga tingkat
+// yang tidak dikenal dilewati alih-alih menolak permintaan.
+func (s *ProfileService) fillRegionFromNIK(profile *models.UserProfile, parsed *nik.NIK) {
+	candidates := [][3]string{
+		{parsed.ProvinceCode, parsed.RegencyCode, parsed.DistrictCode},
+		{parsed.ProvinceCode, parsed.RegencyCode, ""},
+		{parsed.ProvinceCode, "", ""},
+	}
+	for _, candidate := range candidates {
+		if _, err := s.regions.ResolvePath(candidate[0], candidate[1], candidate[2], ""); err != nil {
+			continue
+		}
+		profile.ProvinceCode = optionalString(candidate[0])
+		profile.CityCode = optionalString(candidate[1])
+		profile.DistrictCode = optionalString(candidate[2])
+		// Desa lama hanya dipertahankan jika masih berada di kecamatan yang sama
+		if profile.VillageCode != nil && (candidate[2] == "" || !strings.HasPrefix(*profile.VillageCode, candidate[2])) {
+			profile.VillageCode = nil
+		}
+		return
+	}
+}
+
+// applyRegion memvalidasi kode wilayah profil dan mengisi nama wilayah dari data Kemendagri.
+// Nama untuk tingkat yang tidak memiliki kode dikosongkan kecuali dikirim sebagai teks bebas.
+func (s *ProfileService) applyRegion(req *dto.UpdateProfileRequestDTO, profile *models.UserProfile) error {
+	path, err := s.regions.ResolvePath(
+		derefString(profile.ProvinceCode),
+		derefString(profile.CityCode),
+		derefString(profile.DistrictCode),
+		derefString(profile.VillageCode),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRegion, err)
+	}
+
+	levels := []struct {
+		field  string
+		region *region_models.Region
+		name   **string
+	}{
+		{"province", path.Province, &profile.Province},
+		{"city", path.Regency, &profile.City},
+		{"district", path.District, &profile.District},
+		{"village", path.Village, &profile.Village},
+	}
+	for _, level := range levels {
+		switch {
+		case level.region != nil:
+			name := level.region.Name
+			*level.name = &name
+		case !req.Has(level.field):
+			*level.name = nil
+		}
+	}
+	return nil
+}
+
+// applyPhone menormalkan nomor telepon ke E.164. Verifikasi nomor dibatalkan jika nomornya berubah.
+func applyPhone(value *string, profile *models.UserProfile) error {
+	var normalized *string
+	if value != nil && strings.TrimSpace(*value) != "" {
+		number, err := phone.Normalize(*value)
+		if err != nil {
+			return ErrInvalidPhone
+		}
+		normalized = &number
+	}
+
+	if derefString(normalized) != derefString(profile.Phone) {
+		profile.PhoneVerifiedAt = nil
+	}
+	profile.Phone = normalized
+	return nil
+}
+
+func hasAny(req *dto.UpdateProfileRequestDTO, fields ...string) bool {
+	for _, field := range fields {
+		if req.Has(field) {
+			return true
+		}
+	}
+	return false
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// mergeOptional menerapkan satu field opsional dari request ke target jika field tersebut dikirim.
+// Nilai null atau string kosong menghasilkan NULL di database.
+func mergeOptional(req *dto.UpdateProfileRequestDTO, field string, value *string, target **string) {
+	if !req.Has(field) {
+		return
+	}
+	if value == nil || strings.TrimSpace(*value) == "" {
+		*target = nil
+		return
+	}
+	trimmed := strings.TrimSpace(*value)
+	*target = &trimmed
+}
@@ -0,0 +1,9 @@
+// Package data menyimpan dataset wilayah administrasi bawaan
+package data
+
+import _ "embed"
+
+// RegionsCSV adalah contoh dataset wilayah dengan format kode,nama per baris, untuk development
+//
+//go:embed regions.csv
+var RegionsCSV []byte
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/region/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/region/services"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// regionCacheControl mengizinkan klien menyimpan data wilayah karena dataset jarang berubah
+const regionCacheControl = "public, max-age=86400"
+
+// RegionHandler menangani permintaan HTTP untuk data wilayah administrasi
+type RegionHandler struct {
+	regionService services.RegionServiceInterface
+}
+
+// NewRegionHandler membuat instance baru dari RegionHandler
+func NewRegionHandler(regionService services.RegionServiceInterface) *RegionHandler {
+	return &RegionHandler{regionService: regionService}
+}
+
+// Provinces menangani daftar provinsi. Query parameter q memfilter berdasarkan nama.
+func (h *RegionHandler) Provinces(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	provinces := h.regionService.ListProvinces(r.URL.Query().Get("q"))
+	w.Header().Set("Cache-Control", regionCacheControl)
+	api.SendSuccess(w, http.StatusOK, "Provinces fetched successfully", provinces, nil)
+}
+
+// Regencies menangani daftar kabupaten/kota dalam satu provinsi
+func (h *RegionHandler) Regencies(w http.ResponseWriter, r *http.Request) {
+	h.listChildren(w, r, models.LevelProvince, "Regencies fetched successfully")
+}
+
+// Districts menangani daftar kecamatan dalam satu kabupaten/kota
+func (h *RegionHandler) Districts(w http.ResponseWriter, r *http.Request) {
+	h.listChildren(w, r, models.LevelRegency, "Districts fetched successfully")
+}
+
+// Villages menangani daftar desa/kelurahan dalam satu kecamatan
+func (h *RegionHandler) Villages(w http.ResponseWriter, r *http.Request) {
+	h.listChildren(w, r, models.LevelDistrict, "Villages fetched successfully")
+}
+
+// Region menangani detail satu wilayah berdasarkan kode
+func (h *RegionHandler) Region(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	region, err := h.regionService.GetRegion(r.PathValue("code"))
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to get region")
+		return
+	}
+	w.Header().Set("Cache-Control", regionCacheControl)
+	api.SendSuccess(w, http.StatusOK, "Region fetched successfully", region, nil)
+}
+
+func (h *RegionHandler) listChildren(w http.ResponseWriter, r *http.Request, parentLevel models.Level, message string) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	regions, err := h.regionService.ListChildren(r.PathValue("code"), parentLevel, r.URL.Query().Get("q"))
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to list regions")
+		return
+	}
+	w.Header().Set("Cache-Control", regionCacheControl)
+	api.SendSuccess(w, http.StatusOK, message, regions, nil)
+}
+
+// sendServiceError memetakan error dari service ke response HTTP
+func (h *RegionHandler) sendServiceError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	switch {
+	case errors.Is(err, services.ErrRegionNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
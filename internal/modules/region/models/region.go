@@ -0,0 +1,66 @@
+package models
+
+import "strings"
+
+// Level adalah tingkat wilayah administrasi
+type Level string
+
+const (
+	LevelProvince Level = "province"
+	LevelRegency  Level = "regency"
+	LevelDistrict Level = "district"
+	LevelVillage  Level = "village"
+)
+
+// Region adalah satu wilayah administrasi Kemendagri. Code disimpan tanpa titik,
+// contoh "3273" untuk Kota Bandung, sehingga sama dengan potongan kode wilayah pada NIK.
+type Region struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Level      Level  `json:"level"`
+	ParentCode string `json:"parent_code,omitempty"`
+}
+
+// RegionPath adalah rantai wilayah dari provinsi hingga desa/kelurahan.
+// Tingkat yang tidak dipilih bernilai nil.
+type RegionPath struct {
+	Province *Region `json:"province,omitempty"`
+	Regency  *Region `json:"regency,omitempty"`
+	District *Region `json:"district,omitempty"`
+	Village  *Region `json:"village,omitempty"`
+}
+
+// codeLengths memetakan panjang kode tanpa titik ke tingkat wilayah
+var codeLengths = map[int]Level{
+	2:  LevelProvince,
+	4:  LevelRegency,
+	6:  LevelDistrict,
+	10: LevelVillage,
+}
+
+// NormalizeCode menghapus titik dari kode Kemendagri, contoh "32.73.01" menjadi "327301"
+func NormalizeCode(code string) string {
+	return strings.ReplaceAll(strings.TrimSpace(code), ".", "")
+}
+
+// LevelOf mengembalikan tingkat wilayah dari kode tanpa titik
+func LevelOf(code string) (Level, bool) {
+	for i := 0; i < len(code); i++ {
+		if code[i] < '0' || code[i] > '9' {
+			return "", false
+		}
+	}
+	level, ok := codeLengths[len(code)]
+	return level, ok
+}
+
+// ParentCodeOf mengembalikan kode induk dari kode tanpa titik, atau string kosong untuk provinsi
+func ParentCodeOf(code string) string {
+	switch len(code) {
+	case 4, 6:
+		return code[:len(code)-2]
+	case 10:
+		return code[:6]
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/region/models"
+)
+
+// RegionRepositoryInterface mendefinisikan kontrak untuk pencarian data wilayah
+type RegionRepositoryInterface interface {
+	FindByCode(code string) *models.Region
+	FindChildren(parentCode string) []models.Region
+	FindProvinces() []models.Region
+}
+
+// minimumRegionCounts adalah jumlah minimum wilayah per tingkat pada dataset Kemendagri
+// lengkap. Desa/kelurahan diberi sedikit kelonggaran karena jumlahnya berubah setiap pemutakhiran.
+var minimumRegionCounts = []struct {
+	level models.Level
+	count int
+}{
+	{models.LevelProvince, 38},
+	{models.LevelRegency, 514},
+	{models.LevelDistrict, 7000},
+	{models.LevelVillage, 80000},
+}
+
+// ErrIncompleteDataset dikembalikan ketika dataset wilayah tidak memuat seluruh wilayah Indonesia
+var ErrIncompleteDataset = errors.New("dataset wilayah tidak lengkap")
+
+// RegionRepository menyimpan seluruh data wilayah di memori. Dataset bersifat statis
+// sehingga dimuat sekali saat aplikasi dimulai dan aman dibaca dari banyak goroutine.
+type RegionRepository struct {
+	regions  map[string]*models.Region
+	children map[string][]models.Region
+}
+
+// NewRegionRepository memuat dataset wilayah berformat CSV kode,nama. Baris kosong dan
+// baris yang diawali '#' diabaikan. Setiap wilayah selain provinsi wajib memiliki induk.
+func NewRegionRepository(source io.Reader) (*RegionRepository, error) {
+	reader := csv.NewReader(source)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	repo := &RegionRepository{
+		regions:  map[string]*models.Region{},
+		children: map[string][]models.Region{},
+	}
+
+	var ordered []*models.Region
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca dataset wilayah: %w", err)
+		}
+		if len(record) < 2 {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("baris %d dataset wilayah harus berisi kode dan nama", line)
+		}
+
+		code := models.NormalizeCode(record[0])
+		level, ok := models.LevelOf(code)
+		if !ok {
+			return nil, fmt.Errorf("kode wilayah tidak valid: %s", record[0])
+		}
+		if _, exists := repo.regions[code]; exists {
+			return nil, fmt.Errorf("kode wilayah duplikat: %s", record[0])
+		}
+
+		region := &models.Region{
+			Code:       code,
+			Name:       strings.TrimSpace(record[1]),
+			Level:      level,
+			ParentCode: models.ParentCodeOf(code),
+		}
+		repo.regions[code] = region
+		ordered = append(ordered, region)
+	}
+
+	// Induk diperiksa setelah semua baris dibaca agar urutan baris di file tidak berpengaruh
+	for _, region := range ordered {
+		if region.ParentCode != "" {
+			if _, ok := repo.regions[region.ParentCode]; !ok {
+				return nil, fmt.Errorf("induk wilayah %s tidak ditemukan untuk %s", region.ParentCode, region.Code)
+			}
+		}
+		repo.children[region.ParentCode] = append(repo.children[region.ParentCode], *region)
+	}
+	for parent := range repo.children {
+		list := repo.children[parent]
+		sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	}
+	return repo, nil
+}
+
+// CheckComplete memastikan dataset memuat seluruh wilayah Indonesia. Dataset yang hanya berisi
+// sebagian wilayah membuat validasi alamat profil menolak kode wilayah yang sah.
+func (r *RegionRepository) CheckComplete() error {
+	counts := map[models.Level]int{}
+	for _, region := range r.regions {
+		counts[region.Level]++
+	}
+	for _, minimum := range minimumRegionCounts {
+		if counts[minimum.level] < minimum.count {
+			return fmt.Errorf("%w: %d wilayah tingkat %s, minimal %d",
+				ErrIncompleteDataset, counts[minimum.level], minimum.level, minimum.count)
+		}
+	}
+	return nil
+}
+
+// FindByCode mencari wilayah berdasarkan kode tanpa titik
+func (r *RegionRepository) FindByCode(code string) *models.Region {
+	region, ok := r.regions[code]
+	if !ok {
+		return nil
+	}
+	copied := *region
+	return &copied
+}
+
+// FindChildren mengembalikan wilayah langsung di bawah parentCode, terurut berdasarkan kode
+func (r *RegionRepository) FindChildren(parentCode string) []models.Region {
+	if parentCode == "" {
+		return []models.Region{}
+	}
+	return append([]models.Region{}, r.children[parentCode]...)
+}
+
+// FindProvinces mengembalikan semua provinsi terurut berdasarkan kode
+func (r *RegionRepository) FindProvinces() []models.Region {
+	return append([]models.Region{}, r.children[""]...)
+}
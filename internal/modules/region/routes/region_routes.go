@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/region/handlers"
+)
+
+// RegionRoutes mengelola pendaftaran rute untuk modul wilayah
+type RegionRoutes struct {
+	regionHandler *handlers.RegionHandler
+}
+
+// NewRegionRoutes membuat instance baru dari RegionRoutes
+func NewRegionRoutes(regionHandler *handlers.RegionHandler) *RegionRoutes {
+	return &RegionRoutes{regionHandler: regionHandler}
+}
+
+// RegisterRoutes mendaftarkan rute wilayah ke router. Semua rute terbuka untuk publik
+// karena dipakai untuk mengisi dropdown bertingkat di formulir.
+func (r *RegionRoutes) RegisterRoutes(router *http.ServeMux) {
+	router.HandleFunc("/regions/provinces", r.regionHandler.Provinces)
+	router.HandleFunc("/regions/provinces/{code}/regencies", r.regionHandler.Regencies)
+	router.HandleFunc("/regions/regencies/{code}/districts", r.regionHandler.Districts)
+	router.HandleFunc("/regions/districts/{code}/villages", r.regionHandler.Villages)
+	router.HandleFunc("/regions/{code}", r.regionHandler.Region)
+}
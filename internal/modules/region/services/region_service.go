@@ -0,0 +1,121 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/region/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/region/repositories"
+)
+
+// RegionServiceError adalah tipe error kustom untuk service wilayah
+type RegionServiceError string
+
+func (e RegionServiceError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrRegionNotFound = RegionServiceError("wilayah tidak ditemukan")
+	ErrRegionMismatch = RegionServiceError("wilayah yang dipilih tidak berada di bawah wilayah induknya")
+	ErrRegionGap      = RegionServiceError("wilayah induk harus dipilih sebelum wilayah di bawahnya")
+)
+
+// RegionServiceInterface mendefinisikan kontrak untuk service wilayah
+type RegionServiceInterface interface {
+	GetRegion(code string) (*models.Region, error)
+	ListProvinces(query string) []models.Region
+	ListChildren(parentCode string, parentLevel models.Level, query string) ([]models.Region, error)
+	ResolvePath(provinceCode, regencyCode, districtCode, villageCode string) (*models.RegionPath, error)
+}
+
+// RegionService adalah implementasi dari RegionServiceInterface
+type RegionService struct {
+	regionRepo repositories.RegionRepositoryInterface
+}
+
+// NewRegionService membuat instance baru dari RegionService
+func NewRegionService(regionRepo repositories.RegionRepositoryInterface) *RegionService {
+	return &RegionService{regionRepo: regionRepo}
+}
+
+// GetRegion mengambil satu wilayah; kode boleh ditulis dengan atau tanpa titik
+func (s *RegionService) GetRegion(code string) (*models.Region, error) {
+	region := s.regionRepo.FindByCode(models.NormalizeCode(code))
+	if region == nil {
+		return nil, ErrRegionNotFound
+	}
+	return region, nil
+}
+
+// ListProvinces mengembalikan semua provinsi, opsional difilter dengan potongan nama
+func (s *RegionService) ListProvinces(query string) []models.Region {
+	return filterByName(s.regionRepo.FindProvinces(), query)
+}
+
+// ListChildren mengembalikan wilayah di bawah parentCode. parentLevel memastikan endpoint
+// kabupaten hanya menerima kode provinsi, endpoint kecamatan hanya kode kabupaten, dan seterusnya.
+func (s *RegionService) ListChildren(parentCode string, parentLevel models.Level, query string) ([]models.Region, error) {
+	parent, err := s.GetRegion(parentCode)
+	if err != nil {
+		return nil, err
+	}
+	if parent.Level != parentLevel {
+		return nil, ErrRegionNotFound
+	}
+	return filterByName(s.regionRepo.FindChildren(parent.Code), query), nil
+}
+
+// ResolvePath memvalidasi kombinasi kode wilayah dan mengembalikan data lengkapnya.
+// Kode kosong berarti tingkat tersebut tidak dipilih, tetapi tingkat di bawahnya hanya boleh
+// dipilih jika semua tingkat di atasnya juga dipilih dan saling berhubungan.
+func (s *RegionService) ResolvePath(provinceCode, regencyCode, districtCode, villageCode string) (*models.RegionPath, error) {
+	codes := []string{
+		models.NormalizeCode(provinceCode),
+		models.NormalizeCode(regencyCode),
+		models.NormalizeCode(districtCode),
+		models.NormalizeCode(villageCode),
+	}
+	levels := []models.Level{models.LevelProvince, models.LevelRegency, models.LevelDistrict, models.LevelVillage}
+
+	path := &models.RegionPath{}
+	targets := []**models.Region{&path.Province, &path.Regency, &path.District, &path.Village}
+
+	var parent *models.Region
+	for i, code := range codes {
+		if code == "" {
+			// Tingkat yang kosong tidak boleh diikuti tingkat yang terisi
+			for _, lower := range codes[i+1:] {
+				if lower != "" {
+					return nil, ErrRegionGap
+				}
+			}
+			break
+		}
+
+		region := s.regionRepo.FindByCode(code)
+		if region == nil || region.Level != levels[i] {
+			return nil, ErrRegionNotFound
+		}
+		if parent != nil && region.ParentCode != parent.Code {
+			return nil, ErrRegionMismatch
+		}
+		*targets[i] = region
+		parent = region
+	}
+	return path, nil
+}
+
+func filterByName(regions []models.Region, query string) []models.Region {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return regions
+	}
+
+	filtered := make([]models.Region, 0, len(regions))
+	for _, region := range regions {
+		if strings.Contains(strings.ToLower(region.Name), query) {
+			filtered = append(filtered, region)
+		}
+	}
+	return filtered
+}
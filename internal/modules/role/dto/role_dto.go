@@ -0,0 +1,25 @@
+package dto
+
+// CreateRoleRequestDTO digunakan untuk membuat role baru
+type CreateRoleRequestDTO struct {
+	Name        string  `json:"name" validate:"required,min=3,max=50"`
+	Description *string `json:"description" validate:"omitempty,max=500"`
+	RequireMFA  bool    `json:"require_mfa"`
+}
+
+// UpdateRoleRequestDTO digunakan untuk mengubah deskripsi role dan kewajiban MFA.
+// Field yang bernilai nil tidak diubah.
+type UpdateRoleRequestDTO struct {
+	Description *string `json:"description" validate:"omitempty,max=500"`
+	RequireMFA  *bool   `json:"require_mfa"`
+}
+
+// GrantPermissionRequestDTO digunakan untuk memberikan permission ke sebuah role
+type GrantPermissionRequestDTO struct {
+	Permission string `json:"permission" validate:"required"`
+}
+
+// AssignRoleRequestDTO digunakan untuk memberikan role ke seorang pengguna
+type AssignRoleRequestDTO struct {
+	RoleID string `json:"role_id" validate:"required"`
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/role/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/role/services"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RoleHandler menangani permintaan HTTP untuk manajemen role dan permission
+type RoleHandler struct {
+	roleService services.RoleServiceInterface
+}
+
+// NewRoleHandler membuat instance baru dari RoleHandler
+func NewRoleHandler(roleService services.RoleServiceInterface) *RoleHandler {
+	return &RoleHandler{roleService: roleService}
+}
+
+// Roles menangani daftar role (GET) dan pembuatan role baru (POST)
+func (h *RoleHandler) Roles(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		roles, err := h.roleService.ListRoles(r.Context())
+		if err != nil {
+			log.Printf("Gagal mengambil daftar role: %v", err)
+			api.SendError(w, http.StatusInternalServerError, "Failed to list roles")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Roles fetched successfully", roles, nil)
+
+	case http.MethodPost:
+		var req dto.CreateRoleRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		role, err := h.roleService.CreateRole(r.Context(), &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to create role")
+			return
+		}
+		api.SendSuccess(w, http.StatusCreated, "Role created successfully", role, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// Role menangani detail (GET) dan perubahan (PATCH) satu role
+func (h *RoleHandler) Role(w http.ResponseWriter, r *http.Request) {
+	roleID := r.PathValue("roleID")
+
+	switch r.Method {
+	case http.MethodGet:
+		role, err := h.roleService.GetRole(r.Context(), roleID)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to get role")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Role fetched successfully", role, nil)
+
+	case http.MethodPatch:
+		var req dto.UpdateRoleRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		role, err := h.roleService.UpdateRole(r.Context(), roleID, &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to update role")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Role updated successfully", role, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// Permissions menangani daftar permission yang tersedia
+func (h *RoleHandler) Permissions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	permissions, err := h.roleService.ListPermissions(r.Context())
+	if err != nil {
+		log.Printf("Gagal mengambil daftar permission: %v", err)
+		api.SendError(w, http.StatusInternalServerError, "Failed to list permissions")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Permissions fetched successfully", permissions, nil)
+}
+
+// GrantPermission menangani pemberian permission ke role
+func (h *RoleHandler) GrantPermission(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.GrantPermissionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	role, err := h.roleService.GrantPermission(r.Context(), r.PathValue("roleID"), &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to grant permission")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Permission granted successfully", role, nil)
+}
+
+// RevokePermission menangani pencabutan permission dari role
+func (h *RoleHandler) RevokePermission(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	role, err := h.roleService.RevokePermission(r.Context(), r.PathValue("roleID"), r.PathValue("permission"))
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to revoke permission")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Permission revoked successfully", role, nil)
+}
+
+// UserRoles menangani daftar role pengguna (GET) dan pemberian role ke pengguna (POST)
+func (h *RoleHandler) UserRoles(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("userID")
+
+	switch r.Method {
+	case http.MethodGet:
+		roles, err := h.roleService.GetUserRoles(r.Context(), userID)
+		if err != nil {
+			log.Printf("Gagal mengambil role pengguna: %v", err)
+			api.SendError(w, http.StatusInternalServerError, "Failed to get user roles")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "User roles fetched successfully", roles, nil)
+
+	case http.MethodPost:
+		var req dto.AssignRoleRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		roles, err := h.roleService.AssignRole(r.Context(), userID, &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to assign role")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Role assigned successfully", roles, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// UnassignRole menangani pencabutan role dari pengguna
+func (h *RoleHandler) UnassignRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roles, err := h.roleService.UnassignRole(r.Context(), r.PathValue("userID"), r.PathValue("roleID"))
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to unassign role")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Role unassigned successfully", roles, nil)
+}
+
+// sendServiceError memetakan error dari RoleService ke respons HTTP
+func (h *RoleHandler) sendServiceError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.Is(err, services.ErrRoleAlreadyExists):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "role_exists", nil)
+	case errors.Is(err, services.ErrRoleNotFound),
+		errors.Is(err, services.ErrPermissionNotFound),
+		errors.Is(err, services.ErrUserNotFound),
+		errors.Is(err, services.ErrRoleNotAssigned):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
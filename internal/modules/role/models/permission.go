@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+// Nama permission yang diperiksa oleh aplikasi
+const (
+	PermArticlesCreate     = "articles.create"
+	PermArticlesEditOwn    = "articles.edit_own"
+	PermArticlesEditAny    = "articles.edit_any"
+	PermArticlesReview     = "articles.review"
+	PermArticlesPublish    = "articles.publish"
+	PermArticlesDelete     = "articles.delete"
+	PermMediaUpload        = "media.upload"
+	PermMediaManage        = "media.manage"
+	PermTaxonomyManage     = "taxonomy.manage"
+	PermUsersManage        = "users.manage"
+	PermRolesManage        = "roles.manage"
+	PermOAuthClientsManage = "oauth_clients.manage"
+)
+
+// Permission merepresentasikan tabel 'permissions' di database
+type Permission struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DefaultPermissions berisi semua permission bawaan beserta deskripsinya
+var DefaultPermissions = map[string]string{
+	PermArticlesCreate:     "Create new article drafts",
+	PermArticlesEditOwn:    "Edit own articles while they are not published",
+	PermArticlesEditAny:    "Edit articles written by anyone",
+	PermArticlesReview:     "Review submitted articles and send them back to draft",
+	PermArticlesPublish:    "Schedule, publish and archive articles",
+	PermArticlesDelete:     "Delete articles",
+	PermMediaUpload:        "Upload files to the media library",
+	PermMediaManage:        "Edit and delete any file in the media library",
+	PermTaxonomyManage:     "Manage categories and tags",
+	PermUsersManage:        "Manage user accounts",
+	PermRolesManage:        "Manage roles, permissions and role assignments",
+	PermOAuthClientsManage: "Register and manage applications that sign in with CMS accounts",
+}
+
+// AllPermissionNames mengembalikan nama semua permission bawaan
+func AllPermissionNames() []string {
+	names := make([]string, 0, len(DefaultPermissions))
+	for name := range DefaultPermissions {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+)
+
+// Nama role bawaan CMS
+const (
+	RoleAdmin       = "admin"
+	RoleEditor      = "editor"
+	RoleAuthor      = "author"
+	RoleContributor = "contributor"
+	RoleSubscriber  = "subscriber"
+
+	// DefaultRoleName adalah role yang otomatis diberikan kepada pengguna baru
+	DefaultRoleName = RoleSubscriber
+)
+
+// Role merepresentasikan tabel 'roles' di database
+type Role struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	RequireMFA  bool      `json:"require_mfa"`
+	Permissions []string  `json:"permissions,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UserRole merepresentasikan tabel 'user_roles' di database
+type UserRole struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	RoleID    string    `json:"role_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DefaultRole mendeskripsikan role bawaan beserta permission-nya untuk proses seeding
+type DefaultRole struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// DefaultRoles adalah daftar role bawaan CMS
+var DefaultRoles = []DefaultRole{
+	{
+		Name:        RoleAdmin,
+		Description: "Full access to every feature, including user and role management",
+		Permissions: AllPermissionNames(),
+	},
+	{
+		Name:        RoleEditor,
+		Description: "Reviews, edits and publishes articles from any author",
+		Permissions: []string{
+			PermArticlesCreate, PermArticlesEditOwn, PermArticlesEditAny, PermArticlesReview,
+			PermArticlesPublish, PermArticlesDelete, PermMediaUpload, PermMediaManage, PermTaxonomyManage,
+		},
+	},
+	{
+		Name:        RoleAuthor,
+		Description: "Writes articles and uploads media, publishing requires an editor",
+		Permissions: []string{PermArticlesCreate, PermArticlesEditOwn, PermMediaUpload},
+	},
+	{
+		Name:        RoleContributor,
+		Description: "Writes article drafts without media uploads",
+		Permissions: []string{PermArticlesCreate, PermArticlesEditOwn},
+	},
+	{
+		Name:        RoleSubscriber,
+		Description: "Registered reader without editorial access",
+		Permissions: []string{},
+	},
+}
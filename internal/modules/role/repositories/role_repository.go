@@ -0,0 +1,332 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/role/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Error yang dikembalikan repository untuk pelanggaran constraint
+var (
+	ErrDuplicateRole = errors.New("role dengan nama tersebut sudah ada")
+	ErrUserNotFound  = errors.New("pengguna tidak ditemukan")
+)
+
+// RoleRepositoryInterface mendefinisikan kontrak untuk interaksi database role dan permission
+type RoleRepositoryInterface interface {
+	CreateRole(ctx context.Context, role *models.Role) error
+	UpdateRole(ctx context.Context, role *models.Role) error
+	EnsureRole(ctx context.Context, name, description string) (*models.Role, error)
+	FindRoleByID(ctx context.Context, roleID string) (*models.Role, error)
+	FindRoleByName(ctx context.Context, name string) (*models.Role, error)
+	ListRoles(ctx context.Context) ([]models.Role, error)
+	EnsurePermission(ctx context.Context, name, description string) (*models.Permission, error)
+	FindPermissionByName(ctx context.Context, name string) (*models.Permission, error)
+	ListPermissions(ctx context.Context) ([]models.Permission, error)
+	GrantPermission(ctx context.Context, roleID, permissionID string) error
+	RevokePermission(ctx context.Context, roleID, permissionID string) (bool, error)
+	AssignRole(ctx context.Context, userID, roleID string) error
+	UnassignRole(ctx context.Context, userID, roleID string) (bool, error)
+	FindRolesByUserID(ctx context.Context, userID string) ([]models.Role, error)
+	UserHasRole(ctx context.Context, userID string, roleNames ...string) (bool, error)
+}
+
+// RoleRepository adalah implementasi dari RoleRepositoryInterface
+type RoleRepository struct {
+	db *sql.DB
+}
+
+// NewRoleRepository membuat instance baru dari RoleRepository
+func NewRoleRepository(db *sql.DB) *RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+// CreateRole menyimpan role baru
+func (r *RoleRepository) CreateRole(ctx context.Context, role *models.Role) error {
+	role.ID = uuid.New().String()
+	role.CreatedAt = time.Now().UTC()
+	role.UpdatedAt = role.CreatedAt
+
+	query := `
+		INSERT INTO roles (id, name, description, require_mfa, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.ExecContext(ctx, query, role.ID, role.Name, role.Description, role.RequireMFA, role.CreatedAt, role.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrDuplicateRole
+		}
+		return fmt.Errorf("gagal menyimpan role: %w", err)
+	}
+	return nil
+}
+
+// UpdateRole menyimpan deskripsi dan kebijakan MFA role
+func (r *RoleRepository) UpdateRole(ctx context.Context, role *models.Role) error {
+	query := `
+		UPDATE roles SET description = $1, require_mfa = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query, role.Description, role.RequireMFA, role.ID).Scan(&role.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("gagal memperbarui role: %w", err)
+	}
+	return nil
+}
+
+// EnsureRole membuat role jika belum ada lalu mengembalikannya
+func (r *RoleRepository) EnsureRole(ctx context.Context, name, description string) (*models.Role, error) {
+	query := `
+		INSERT INTO roles (id, name, description)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query, uuid.New().String(), name, description); err != nil {
+		return nil, fmt.Errorf("gagal membuat role %s: %w", name, err)
+	}
+	return r.FindRoleByName(ctx, name)
+}
+
+// FindRoleByID mencari role berdasarkan ID beserta permission-nya
+func (r *RoleRepository) FindRoleByID(ctx context.Context, roleID string) (*models.Role, error) {
+	return r.findRole(ctx, "id", roleID)
+}
+
+// FindRoleByName mencari role berdasarkan nama beserta permission-nya
+func (r *RoleRepository) FindRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	return r.findRole(ctx, "name", name)
+}
+
+func (r *RoleRepository) findRole(ctx context.Context, column, value string) (*models.Role, error) {
+	query := fmt.Sprintf(`
+		SELECT r.id, r.name, r.description, r.require_mfa, r.created_at, r.updated_at,
+			COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		WHERE r.%s = $1
+		GROUP BY r.id
+	`, column)
+	role := &models.Role{}
+	err := r.db.QueryRowContext(ctx, query, value).Scan(
+		&role.ID,
+		&role.Name,
+		&role.Description,
+		&role.RequireMFA,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+		pq.Array(&role.Permissions),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari role: %w", err)
+	}
+	return role, nil
+}
+
+// ListRoles mengambil semua role beserta permission-nya
+func (r *RoleRepository) ListRoles(ctx context.Context) ([]models.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.description, r.require_mfa, r.created_at, r.updated_at,
+			COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		GROUP BY r.id
+		ORDER BY r.name
+	`
+	return r.queryRoles(ctx, query)
+}
+
+// FindRolesByUserID mengambil semua role yang dimiliki pengguna
+func (r *RoleRepository) FindRolesByUserID(ctx context.Context, userID string) ([]models.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.description, r.require_mfa, r.created_at, r.updated_at,
+			COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')
+		FROM user_roles ur
+		JOIN roles r ON r.id = ur.role_id
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		WHERE ur.user_id = $1
+		GROUP BY r.id
+		ORDER BY r.name
+	`
+	return r.queryRoles(ctx, query, userID)
+}
+
+func (r *RoleRepository) queryRoles(ctx context.Context, query string, args ...interface{}) ([]models.Role, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar role: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []models.Role{}
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(
+			&role.ID,
+			&role.Name,
+			&role.Description,
+			&role.RequireMFA,
+			&role.CreatedAt,
+			&role.UpdatedAt,
+			pq.Array(&role.Permissions),
+		); err != nil {
+			return nil, fmt.Errorf("gagal membaca data role: %w", err)
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar role: %w", err)
+	}
+	return roles, nil
+}
+
+// EnsurePermission membuat permission jika belum ada lalu mengembalikannya
+func (r *RoleRepository) EnsurePermission(ctx context.Context, name, description string) (*models.Permission, error) {
+	query := `
+		INSERT INTO permissions (id, name, description)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query, uuid.New().String(), name, description); err != nil {
+		return nil, fmt.Errorf("gagal membuat permission %s: %w", name, err)
+	}
+	return r.FindPermissionByName(ctx, name)
+}
+
+// FindPermissionByName mencari permission berdasarkan nama
+func (r *RoleRepository) FindPermissionByName(ctx context.Context, name string) (*models.Permission, error) {
+	query := `SELECT id, name, description, created_at, updated_at FROM permissions WHERE name = $1`
+	permission := &models.Permission{}
+	err := r.db.QueryRowContext(ctx, query, name).Scan(
+		&permission.ID,
+		&permission.Name,
+		&permission.Description,
+		&permission.CreatedAt,
+		&permission.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari permission: %w", err)
+	}
+	return permission, nil
+}
+
+// ListPermissions mengambil semua permission
+func (r *RoleRepository) ListPermissions(ctx context.Context) ([]models.Permission, error) {
+	query := `SELECT id, name, description, created_at, updated_at FROM permissions ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar permission: %w", err)
+	}
+	defer rows.Close()
+
+	permissions := []models.Permission{}
+	for rows.Next() {
+		var permission models.Permission
+		if err := rows.Scan(
+			&permission.ID,
+			&permission.Name,
+			&permission.Description,
+			&permission.CreatedAt,
+			&permission.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("gagal membaca data permission: %w", err)
+		}
+		permissions = append(permissions, permission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar permission: %w", err)
+	}
+	return permissions, nil
+}
+
+// GrantPermission memberikan permission ke role, tidak melakukan apa pun jika sudah diberikan
+func (r *RoleRepository) GrantPermission(ctx context.Context, roleID, permissionID string) error {
+	query := `
+		INSERT INTO role_permissions (id, role_id, permission_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (role_id, permission_id) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query, uuid.New().String(), roleID, permissionID); err != nil {
+		return fmt.Errorf("gagal memberikan permission: %w", err)
+	}
+	return nil
+}
+
+// RevokePermission mencabut permission dari role
+func (r *RoleRepository) RevokePermission(ctx context.Context, roleID, permissionID string) (bool, error) {
+	query := `DELETE FROM role_permissions WHERE role_id = $1 AND permission_id = $2`
+	res, err := r.db.ExecContext(ctx, query, roleID, permissionID)
+	if err != nil {
+		return false, fmt.Errorf("gagal mencabut permission: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// AssignRole memberikan role ke pengguna, tidak melakukan apa pun jika sudah diberikan
+func (r *RoleRepository) AssignRole(ctx context.Context, userID, roleID string) error {
+	query := `
+		INSERT INTO user_roles (id, user_id, role_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, role_id) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, uuid.New().String(), userID, roleID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "fk_user_roles_user" {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("gagal memberikan role: %w", err)
+	}
+	return nil
+}
+
+// UnassignRole mencabut role dari pengguna
+func (r *RoleRepository) UnassignRole(ctx context.Context, userID, roleID string) (bool, error) {
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2`
+	res, err := r.db.ExecContext(ctx, query, userID, roleID)
+	if err != nil {
+		return false, fmt.Errorf("gagal mencabut role: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// UserHasRole memeriksa apakah pengguna memiliki salah satu dari role yang diberikan
+func (r *RoleRepository) UserHasRole(ctx context.Context, userID string, roleNames ...string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM user_roles ur
+			JOIN roles r ON r.id = ur.role_id
+			WHERE ur.user_id = $1 AND r.name = ANY($2)
+		)
+	`
+	var hasRole bool
+	if err := r.db.QueryRowContext(ctx, query, userID, pq.Array(roleNames)).Scan(&hasRole); err != nil {
+		return false, fmt.Errorf("gagal memeriksa role pengguna: %w", err)
+	}
+	return hasRole, nil
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/role/handlers"
+)
+
+// RoleRoutes mengelola pendaftaran rute untuk modul role
+type RoleRoutes struct {
+	roleHandler *handlers.RoleHandler
+}
+
+// NewRoleRoutes membuat instance baru dari RoleRoutes
+func NewRoleRoutes(roleHandler *handlers.RoleHandler) *RoleRoutes {
+	return &RoleRoutes{roleHandler: roleHandler}
+}
+
+// RegisterRoutes mendaftarkan rute admin role ke router.
+// Semua rute dibungkus dengan middleware guard yang memeriksa otentikasi dan permission.
+func (r *RoleRoutes) RegisterRoutes(router *http.ServeMux, guard func(http.Handler) http.Handler) {
+	router.Handle("/admin/roles", guard(http.HandlerFunc(r.roleHandler.Roles)))
+	router.Handle("/admin/roles/{roleID}", guard(http.HandlerFunc(r.roleHandler.Role)))
+	router.Handle("/admin/roles/{roleID}/permissions", guard(http.HandlerFunc(r.roleHandler.GrantPermission)))
+	router.Handle("/admin/roles/{roleID}/permissions/{permission}", guard(http.HandlerFunc(r.roleHandler.RevokePermission)))
+	router.Handle("/admin/permissions", guard(http.HandlerFunc(r.roleHandler.Permissions)))
+	router.Handle("/admin/users/{userID}/roles", guard(http.HandlerFunc(r.roleHandler.UserRoles)))
+	router.Handle("/admin/users/{userID}/roles/{roleID}", guard(http.HandlerFunc(r.roleHandler.UnassignRole)))
+}
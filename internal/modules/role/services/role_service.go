@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/role/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/role/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/role/repositories"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RoleServiceError adalah tipe error kustom untuk service role
+type RoleServiceError string
+
+func (e RoleServiceError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrRoleNotFound       = RoleServiceError("role tidak ditemukan")
+	ErrRoleAlreadyExists  = RoleServiceError("role dengan nama tersebut sudah ada")
+	ErrPermissionNotFound = RoleServiceError("permission tidak ditemukan")
+	ErrUserNotFound       = RoleServiceError("pengguna tidak ditemukan")
+	ErrRoleNotAssigned    = RoleServiceError("pengguna tidak memiliki role tersebut")
+)
+
+// RoleServiceInterface mendefinisikan kontrak untuk service role
+type RoleServiceInterface interface {
+	SeedDefaults(ctx context.Context) error
+	CreateRole(ctx context.Context, req *dto.CreateRoleRequestDTO) (*models.Role, error)
+	GetRole(ctx context.Context, roleID string) (*models.Role, error)
+	UpdateRole(ctx context.Context, roleID string, req *dto.UpdateRoleRequestDTO) (*models.Role, error)
+	ListRoles(ctx context.Context) ([]models.Role, error)
+	ListPermissions(ctx context.Context) ([]models.Permission, error)
+	GrantPermission(ctx context.Context, roleID string, req *dto.GrantPermissionRequestDTO) (*models.Role, error)
+	RevokePermission(ctx context.Context, roleID, permissionName string) (*models.Role, error)
+	AssignRole(ctx context.Context, userID string, req *dto.AssignRoleRequestDTO) ([]models.Role, error)
+	UnassignRole(ctx context.Context, userID, roleID string) ([]models.Role, error)
+	GetUserRoles(ctx context.Context, userID string) ([]models.Role, error)
+	UserHasRole(ctx context.Context, userID string, roleNames ...string) (bool, error)
+}
+
+// RoleService adalah implementasi dari RoleServiceInterface
+type RoleService struct {
+	roleRepo repositories.RoleRepositoryInterface
+	validate *validator.Validate
+}
+
+// NewRoleService membuat instance baru dari RoleService
+func NewRoleService(roleRepo repositories.RoleRepositoryInterface) *RoleService {
+	return &RoleService{
+		roleRepo: roleRepo,
+		validate: validator.New(),
+	}
+}
+
+// SeedDefaults memastikan permission dan role bawaan CMS tersedia.
+// Aman dipanggil berulang kali karena hanya menambahkan data yang belum ada.
+func (s *RoleService) SeedDefaults(ctx context.Context) error {
+	permissionIDs := make(map[string]string, len(models.DefaultPermissions))
+	for name, description := range models.DefaultPermissions {
+		permission, err := s.roleRepo.EnsurePermission(ctx, name, description)
+		if err != nil {
+			return err
+		}
+		permissionIDs[name] = permission.ID
+	}
+
+	for _, defaultRole := range models.DefaultRoles {
+		role, err := s.roleRepo.EnsureRole(ctx, defaultRole.Name, defaultRole.Description)
+		if err != nil {
+			return err
+		}
+		for _, permissionName := range defaultRole.Permissions {
+			if err := s.roleRepo.GrantPermission(ctx, role.ID, permissionIDs[permissionName]); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("Seeding role bawaan selesai: %d role, %d permission", len(models.DefaultRoles), len(permissionIDs))
+	return nil
+}
+
+// CreateRole membuat role baru tanpa permission
+func (s *RoleService) CreateRole(ctx context.Context, req *dto.CreateRoleRequestDTO) (*models.Role, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	role := &models.Role{
+		Name:        strings.ToLower(strings.TrimSpace(req.Name)),
+		Description: req.Description,
+		RequireMFA:  req.RequireMFA,
+		Permissions: []string{},
+	}
+	if err := s.roleRepo.CreateRole(ctx, role); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateRole) {
+			return nil, ErrRoleAlreadyExists
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+// GetRole mengambil satu role beserta permission-nya
+func (s *RoleService) GetRole(ctx context.Context, roleID string) (*models.Role, error) {
+	role, err := s.roleRepo.FindRoleByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+// UpdateRole mengubah deskripsi role dan kewajiban MFA bagi pemegangnya
+func (s *RoleService) UpdateRole(ctx context.Context, roleID string, req *dto.UpdateRoleRequestDTO) (*models.Role, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	role, err := s.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Description != nil {
+		role.Description = req.Description
+	}
+	if req.RequireMFA != nil {
+		role.RequireMFA = *req.RequireMFA
+	}
+
+	if err := s.roleRepo.UpdateRole(ctx, role); err != nil {
+		return nil, err
+	}
+	if req.RequireMFA != nil {
+		log.Printf("SECURITY: Kewajiban MFA role %s diubah menjadi %t", role.Name, role.RequireMFA)
+	}
+	return role, nil
+}
+
+// ListRoles mengambil semua role beserta permission-nya
+func (s *RoleService) ListRoles(ctx context.Context) ([]models.Role, error) {
+	return s.roleRepo.ListRoles(ctx)
+}
+
+// ListPermissions mengambil semua permission yang tersedia
+func (s *RoleService) ListPermissions(ctx context.Context) ([]models.Permission, error) {
+	return s.roleRepo.ListPermissions(ctx)
+}
+
+// GrantPermission memberikan permission ke role dan mengembalikan role terbaru
+func (s *RoleService) GrantPermission(ctx context.Context, roleID string, req *dto.GrantPermissionRequestDTO) (*models.Role, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	role, permission, err := s.findRoleAndPermission(ctx, roleID, req.Permission)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.roleRepo.GrantPermission(ctx, role.ID, permission.ID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("SECURITY: Permission %s diberikan ke role %s", permission.Name, role.Name)
+	return s.roleRepo.FindRoleByID(ctx, role.ID)
+}
+
+// RevokePermission mencabut permission dari role dan mengembalikan role terbaru
+func (s *RoleService) RevokePermission(ctx context.Context, roleID, permissionName string) (*models.Role, error) {
+	role, permission, err := s.findRoleAndPermission(ctx, roleID, permissionName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.roleRepo.RevokePermission(ctx, role.ID, permission.ID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("SECURITY: Permission %s dicabut dari role %s", permission.Name, role.Name)
+	return s.roleRepo.FindRoleByID(ctx, role.ID)
+}
+
+func (s *RoleService) findRoleAndPermission(ctx context.Context, roleID, permissionName string) (*models.Role, *models.Permission, error) {
+	role, err := s.roleRepo.FindRoleByID(ctx, roleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if role == nil {
+		return nil, nil, ErrRoleNotFound
+	}
+
+	permission, err := s.roleRepo.FindPermissionByName(ctx, permissionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if permission == nil {
+		return nil, nil, ErrPermissionNotFound
+	}
+	return role, permission, nil
+}
+
+// AssignRole memberikan role ke pengguna dan mengembalikan daftar role pengguna terbaru
+func (s *RoleService) AssignRole(ctx context.Context, userID string, req *dto.AssignRoleRequestDTO) ([]models.Role, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	role, err := s.roleRepo.FindRoleByID(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	if err := s.roleRepo.AssignRole(ctx, userID, role.ID); err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("SECURITY: Role %s diberikan ke pengguna %s", role.Name, userID)
+	return s.roleRepo.FindRolesByUserID(ctx, userID)
+}
+
+// AssignRoleByName memberikan role berdasarkan nama, dipakai oleh perintah CLI yang belum mengetahui ID role
+func (s *RoleService) AssignRoleByName(ctx context.Context, userID, roleName string) ([]models.Role, error) {
+	role, err := s.roleRepo.FindRoleByName(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	return s.AssignRole(ctx, userID, &dto.AssignRoleRequestDTO{RoleID: role.ID})
+}
+
+// UnassignRole mencabut role dari pengguna dan mengembalikan daftar role pengguna terbaru
+func (s *RoleService) UnassignRole(ctx context.Context, userID, roleID string) ([]models.Role, error) {
+	removed, err := s.roleRepo.UnassignRole(ctx, userID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, ErrRoleNotAssigned
+	}
+
+	log.Printf("SECURITY: Role %s dicabut dari pengguna %s", roleID, userID)
+	return s.roleRepo.FindRolesByUserID(ctx, userID)
+}
+
+// GetUserRoles mengambil semua role milik pengguna
+func (s *RoleService) GetUserRoles(ctx context.Context, userID string) ([]models.Role, error) {
+	return s.roleRepo.FindRolesByUserID(ctx, userID)
+}
+
+// UserHasRole memeriksa apakah pengguna memiliki salah satu role yang diberikan
+func (s *RoleService) UserHasRole(ctx context.Context, userID string, roleNames ...string) (bool, error) {
+	return s.roleRepo.UserHasRole(ctx, userID, roleNames...)
+}
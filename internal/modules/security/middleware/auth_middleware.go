@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"log"
+	"net"
 	"net/http"
 	"strings"
 
@@ -14,9 +16,24 @@ import (
 // key untuk menyimpan UserID di context
 type contextKey string
 
-const UserIDContextKey contextKey = "userID"
+const (
+	UserIDContextKey       contextKey = "userID"
+	RolesContextKey        contextKey = "roles"
+	PermissionsContextKey  contextKey = "permissions"
+	AuthzVersionContextKey contextKey = "authzVersion"
+	SessionIDContextKey    contextKey = "sessionID"
+	AuthMethodContextKey   contextKey = "authMethod"
+	APITokenIDContextKey   contextKey = "apiTokenID"
+)
+
+// Nilai AuthMethodContextKey
+const (
+	AuthMethodJWT      = "jwt"
+	AuthMethodAPIToken = "api_token"
+)
 
-// AuthMiddleware adalah middleware untuk memvalidasi JWT
+// AuthMiddleware adalah middleware untuk memvalidasi JWT atau personal access token.
+// Keduanya dikirim sebagai Bearer token; personal access token dikenali dari prefiksnya.
 func AuthMiddleware(jwtService services.JWTService, authService services.AuthServiceInterface) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -33,16 +50,8 @@ func AuthMiddleware(jwtService services.JWTService, authService services.AuthSer
 				return
 			}
 
-			// Periksa apakah token sudak dicabut (di-blacklist)
-			isRevoked, err := authService.IsTokenRevoked(r.Context(), tokenStr)
-			if err != nil {
-				log.Printf("Gagal memeriksa apakah token sudah dicabut: %v", err)
-				api.SendError(w, http.StatusInternalServerError, "Failed to check token revocation")
-				return
-			}
-
-			if isRevoked {
-				api.SendError(w, http.StatusUnauthorized, "Token has been logged out or revoked")
+			if services.IsAPIToken(tokenStr) {
+				authenticateAPIToken(w, r, next, authService, tokenStr)
 				return
 			}
 
@@ -67,17 +76,102 @@ func AuthMiddleware(jwtService services.JWTService, authService services.AuthSer
 				return
 			}
 
+			// Hanya access token yang boleh dipakai untuk mengakses rute terproteksi
+			if tokenType, _ := claims["token_type"].(string); tokenType != "access" {
+				api.SendError(w, http.StatusUnauthorized, "Invalid token type")
+				return
+			}
+
 			userID, ok := claims["user_id"].(string)
 			if !ok {
 				api.SendError(w, http.StatusUnauthorized, "User ID not found in token")
 				return
 			}
+
+			// Periksa apakah token sudah dicabut lewat jti atau epoch token pengguna.
+			// Pemeriksaan dilakukan setelah tanda tangan valid agar token palsu tidak menyentuh database.
+			tokenID, _ := claims["jti"].(string)
+			issuedAt, err := claims.GetIssuedAt()
+			if tokenID == "" || err != nil || issuedAt == nil {
+				api.SendError(w, http.StatusUnauthorized, "Invalid token claims")
+				return
+			}
+
+			isRevoked, err := authService.IsTokenRevoked(r.Context(), tokenID, userID, issuedAt.Time)
+			if err != nil {
+				log.Printf("Gagal memeriksa apakah token sudah dicabut: %v", err)
+				api.SendError(w, http.StatusInternalServerError, "Failed to check token revocation")
+				return
+			}
+
+			if isRevoked {
+				api.SendError(w, http.StatusUnauthorized, "Token has been logged out or revoked")
+				return
+			}
 			
-			// Tambahkan UserID ke context permintaan
+			// Tambahkan UserID, role, permission dan versi otorisasi ke context permintaan
 			ctx := context.WithValue(r.Context(), UserIDContextKey, userID)
+			ctx = context.WithValue(ctx, AuthMethodContextKey, AuthMethodJWT)
+			ctx = context.WithValue(ctx, RolesContextKey, stringSliceClaim(claims, "roles"))
+			ctx = context.WithValue(ctx, PermissionsContextKey, stringSliceClaim(claims, "permissions"))
+			if version, ok := claims["authz_ver"].(float64); ok {
+				ctx = context.WithValue(ctx, AuthzVersionContextKey, int64(version))
+			}
+			if sessionID, ok := claims["sid"].(string); ok {
+				ctx = context.WithValue(ctx, SessionIDContextKey, sessionID)
+			}
 			
 			// Lanjutkan ke handler berikutnya dengan context yang baru
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
-}
\ No newline at end of file
+}
+
+// authenticateAPIToken memvalidasi personal access token ke database. Token hanya membawa
+// permission sesuai scope-nya, tanpa role, sehingga rute yang memeriksa role tidak dapat
+// diakses dengan token ini.
+func authenticateAPIToken(w http.ResponseWriter, r *http.Request, next http.Handler, authService services.AuthServiceInterface, tokenStr string) {
+	principal, err := authService.AuthenticateAPIToken(r.Context(), tokenStr, remoteIP(r))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAPIToken) {
+			api.SendError(w, http.StatusUnauthorized, "Invalid, expired or revoked API token")
+			return
+		}
+		log.Printf("Gagal memvalidasi API token: %v", err)
+		api.SendError(w, http.StatusInternalServerError, "Failed to validate API token")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), UserIDContextKey, principal.UserID)
+	ctx = context.WithValue(ctx, AuthMethodContextKey, AuthMethodAPIToken)
+	ctx = context.WithValue(ctx, APITokenIDContextKey, principal.TokenID)
+	ctx = context.WithValue(ctx, RolesContextKey, []string{})
+	ctx = context.WithValue(ctx, PermissionsContextKey, principal.Permissions)
+	ctx = context.WithValue(ctx, AuthzVersionContextKey, principal.AuthzVersion)
+
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// remoteIP mengambil alamat IP klien dari RemoteAddr tanpa nomor port
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// stringSliceClaim mengambil klaim berupa array string dari MapClaims
+func stringSliceClaim(claims jwt.MapClaims, key string) []string {
+	raw, ok := claims[key].([]interface{})
+	if !ok {
+		return []string{}
+	}
+	values := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
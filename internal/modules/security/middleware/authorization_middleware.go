@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/auth/services"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+)
+
+// Chain menggabungkan beberapa middleware menjadi satu. Middleware pertama
+// adalah yang paling luar, sehingga Chain(a, b)(h) sama dengan a(b(h)).
+func Chain(middlewares ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// RequireRole memastikan pengguna memiliki salah satu role yang diberikan.
+// Harus dipasang setelah AuthMiddleware.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userRoles := RolesFromContext(r.Context())
+			for _, required := range roles {
+				if containsString(userRoles, required) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			api.SendDetailedError(w, http.StatusForbidden,
+				"You do not have the required role to access this resource",
+				"insufficient_role",
+				map[string]interface{}{"required_roles": roles},
+			)
+		})
+	}
+}
+
+// RequirePermission memastikan pengguna memiliki semua permission yang diberikan.
+// Harus dipasang setelah AuthMiddleware.
+func RequirePermission(permissions ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userPermissions := PermissionsFromContext(r.Context())
+
+			var missing []string
+			for _, required := range permissions {
+				if !containsString(userPermissions, required) {
+					missing = append(missing, required)
+				}
+			}
+
+			if len(missing) > 0 {
+				api.SendDetailedError(w, http.StatusForbidden,
+					"You do not have permission to perform this action",
+					"insufficient_permission",
+					map[string]interface{}{
+						"required_permissions": permissions,
+						"missing_permissions":  missing,
+					},
+				)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireFreshAuthz menolak access token yang dibuat sebelum role atau permission
+// pengguna berubah. Middleware ini melakukan query ke database, jadi gunakan hanya
+// pada rute yang sensitif. Harus dipasang setelah AuthMiddleware.
+func RequireFreshAuthz(authService services.AuthServiceInterface) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, _ := r.Context().Value(UserIDContextKey).(string)
+			tokenVersion, _ := r.Context().Value(AuthzVersionContextKey).(int64)
+
+			currentVersion, err := authService.GetAuthzVersion(r.Context(), userID)
+			if err != nil {
+				log.Printf("Gagal memeriksa versi otorisasi: %v", err)
+				api.SendError(w, http.StatusInternalServerError, "Failed to check token authorization")
+				return
+			}
+
+			if tokenVersion != currentVersion {
+				api.SendDetailedError(w, http.StatusUnauthorized,
+					"Your roles or permissions have changed, please refresh your token",
+					"stale_token",
+					map[string]interface{}{
+						"token_version":   tokenVersion,
+						"current_version": currentVersion,
+					},
+				)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSessionAuth menolak permintaan yang diotentikasi dengan personal access token.
+// Dipakai pada rute pengelolaan akun (sesi, MFA, API token) agar token integrasi tidak
+// dapat membuat token baru atau mengubah keamanan akun. Harus dipasang setelah AuthMiddleware.
+func RequireSessionAuth() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if method, _ := r.Context().Value(AuthMethodContextKey).(string); method != AuthMethodJWT {
+				api.SendDetailedError(w, http.StatusForbidden,
+					"This endpoint requires a user session, API tokens are not accepted",
+					"session_required",
+					nil,
+				)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RolesFromContext mengambil role pengguna yang disimpan oleh AuthMiddleware
+func RolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(RolesContextKey).([]string)
+	return roles
+}
+
+// PermissionsFromContext mengambil permission pengguna yang disimpan oleh AuthMiddleware
+func PermissionsFromContext(ctx context.Context) []string {
+	permissions, _ := ctx.Value(PermissionsContextKey).([]string)
+	return permissions
+}
+
+// HasPermission memeriksa apakah pengguna pada context memiliki permission tertentu
+func HasPermission(ctx context.Context, permission string) bool {
+	return containsString(PermissionsFromContext(ctx), permission)
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package dto
+
+// CreateCategoryRequestDTO digunakan untuk membuat kategori baru.
+// ParentID kosong berarti kategori berada di root.
+type CreateCategoryRequestDTO struct {
+	ParentID    *string `json:"parent_id"`
+	Name        string  `json:"name" validate:"required,min=2,max=100"`
+	Slug        string  `json:"slug" validate:"omitempty,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+// UpdateCategoryRequestDTO digunakan untuk mengubah data kategori.
+// Field yang bernilai nil tidak diubah.
+type UpdateCategoryRequestDTO struct {
+	Name        *string `json:"name" validate:"omitempty,min=2,max=100"`
+	Slug        *string `json:"slug" validate:"omitempty,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+// MoveCategoryRequestDTO digunakan untuk memindahkan kategori ke induk lain.
+// Position opsional; jika kosong kategori diletakkan di urutan terakhir.
+type MoveCategoryRequestDTO struct {
+	ParentID *string `json:"parent_id"`
+	Position *int    `json:"position" validate:"omitempty,min=0"`
+}
+
+// ReorderCategoriesRequestDTO digunakan untuk mengurutkan ulang semua anak dari satu induk
+type ReorderCategoriesRequestDTO struct {
+	ParentID    *string  `json:"parent_id"`
+	CategoryIDs []string `json:"category_ids" validate:"required,min=1,dive,required"`
+}
+
+// CreateTagRequestDTO digunakan untuk membuat tag baru
+type CreateTagRequestDTO struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+	Slug string `json:"slug" validate:"omitempty,max=100"`
+}
+
+// UpdateTagRequestDTO digunakan untuk mengubah nama atau slug tag
+type UpdateTagRequestDTO struct {
+	Name *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Slug *string `json:"slug" validate:"omitempty,max=100"`
+}
+
+// MergeTagsRequestDTO digunakan untuk menggabungkan tag sumber ke tag tujuan
+type MergeTagsRequestDTO struct {
+	TargetTagID string `json:"target_tag_id" validate:"required"`
+}
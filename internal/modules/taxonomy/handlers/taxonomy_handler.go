@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/taxonomy/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/taxonomy/services"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// TaxonomyHandler menangani permintaan HTTP untuk kategori dan tag
+type TaxonomyHandler struct {
+	taxonomyService services.TaxonomyServiceInterface
+}
+
+// NewTaxonomyHandler membuat instance baru dari TaxonomyHandler
+func NewTaxonomyHandler(taxonomyService services.TaxonomyServiceInterface) *TaxonomyHandler {
+	return &TaxonomyHandler{taxonomyService: taxonomyService}
+}
+
+// Categories menangani daftar kategori (GET) dan pembuatan kategori baru (POST)
+func (h *TaxonomyHandler) Categories(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		categories, err := h.taxonomyService.ListCategories(r.Context())
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to list categories")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Categories fetched successfully", categories, nil)
+
+	case http.MethodPost:
+		var req dto.CreateCategoryRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		category, err := h.taxonomyService.CreateCategory(r.Context(), &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to create category")
+			return
+		}
+		api.SendSuccess(w, http.StatusCreated, "Category created successfully", category, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// Category menangani detail (GET), perubahan (PATCH) dan penghapusan (DELETE) kategori.
+// Penghapusan menerima query parameter reassign_to untuk memindahkan artikel dan anak kategori.
+func (h *TaxonomyHandler) Category(w http.ResponseWriter, r *http.Request) {
+	categoryID := r.PathValue("categoryID")
+
+	switch r.Method {
+	case http.MethodGet:
+		category, err := h.taxonomyService.GetCategory(r.Context(), categoryID)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to get category")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Category fetched successfully", category, nil)
+
+	case http.MethodPatch:
+		var req dto.UpdateCategoryRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		category, err := h.taxonomyService.UpdateCategory(r.Context(), categoryID, &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to update category")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Category updated successfully", category, nil)
+
+	case http.MethodDelete:
+		reassignTo := r.URL.Query().Get("reassign_to")
+		if err := h.taxonomyService.DeleteCategory(r.Context(), categoryID, reassignTo); err != nil {
+			h.sendServiceError(w, err, "Failed to delete category")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Category deleted successfully", nil, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// MoveCategory menangani pemindahan kategori ke induk lain
+func (h *TaxonomyHandler) MoveCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.MoveCategoryRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	category, err := h.taxonomyService.MoveCategory(r.Context(), r.PathValue("categoryID"), &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to move category")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Category moved successfully", category, nil)
+}
+
+// ReorderCategories menangani pengurutan ulang anak dari satu induk kategori
+func (h *TaxonomyHandler) ReorderCategories(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.ReorderCategoriesRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	categories, err := h.taxonomyService.ReorderCategories(r.Context(), &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to reorder categories")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Categories reordered successfully", categories, nil)
+}
+
+// CategoryTree menangani pembacaan pohon kategori oleh publik
+func (h *TaxonomyHandler) CategoryTree(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	tree, err := h.taxonomyService.CategoryTree(r.Context())
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to get category tree")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Category tree fetched successfully", tree, nil)
+}
+
+// Tags menangani daftar tag (GET) dan pembuatan tag baru (POST)
+func (h *TaxonomyHandler) Tags(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		tags, err := h.taxonomyService.ListTags(r.Context())
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to list tags")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Tags fetched successfully", tags, nil)
+
+	case http.MethodPost:
+		var req dto.CreateTagRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		tag, err := h.taxonomyService.CreateTag(r.Context(), &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to create tag")
+			return
+		}
+		api.SendSuccess(w, http.StatusCreated, "Tag created successfully", tag, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// Tag menangani perubahan (PATCH) dan penghapusan (DELETE) tag
+func (h *TaxonomyHandler) Tag(w http.ResponseWriter, r *http.Request) {
+	tagID := r.PathValue("tagID")
+
+	switch r.Method {
+	case http.MethodPatch:
+		var req dto.UpdateTagRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.SendError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		tag, err := h.taxonomyService.UpdateTag(r.Context(), tagID, &req)
+		if err != nil {
+			h.sendServiceError(w, err, "Failed to update tag")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Tag updated successfully", tag, nil)
+
+	case http.MethodDelete:
+		if err := h.taxonomyService.DeleteTag(r.Context(), tagID); err != nil {
+			h.sendServiceError(w, err, "Failed to delete tag")
+			return
+		}
+		api.SendSuccess(w, http.StatusOK, "Tag deleted successfully", nil, nil)
+
+	default:
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// MergeTags menangani penggabungan tag sumber ke tag tujuan
+func (h *TaxonomyHandler) MergeTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req dto.MergeTagsRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tag, err := h.taxonomyService.MergeTags(r.Context(), r.PathValue("tagID"), &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to merge tags")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Tags merged successfully", tag, nil)
+}
+
+// PublicTags menangani pembacaan daftar tag oleh publik
+func (h *TaxonomyHandler) PublicTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	tags, err := h.taxonomyService.ListTags(r.Context())
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to list tags")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Tags fetched successfully", tags, nil)
+}
+
+// sendServiceError memetakan error dari TaxonomyService ke respons HTTP
+func (h *TaxonomyHandler) sendServiceError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.Is(err, services.ErrCategoryNotFound), errors.Is(err, services.ErrTagNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	case errors.Is(err, services.ErrParentNotFound), errors.Is(err, services.ErrReassignTargetNotFound),
+		errors.Is(err, services.ErrInvalidSlug), errors.Is(err, services.ErrMergeSameTag):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "validation_error", nil)
+	case errors.Is(err, services.ErrCategoryExists), errors.Is(err, services.ErrTagExists):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "slug_exists", nil)
+	case errors.Is(err, services.ErrInvalidCategoryMove), errors.Is(err, services.ErrInvalidReassignment),
+		errors.Is(err, services.ErrCategoryHasChildren), errors.Is(err, services.ErrInvalidCategoryOrder),
+		errors.Is(err, services.ErrCategoryTreeChanged):
+		api.SendDetailedError(w, http.StatusConflict, err.Error(), "invalid_tree_operation", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
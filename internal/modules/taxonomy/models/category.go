@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// Category merepresentasikan tabel 'categories' di database.
+// Path berisi gabungan slug dari root hingga kategori ini, contoh "news/national/politics".
+type Category struct {
+	ID          string    `json:"id"`
+	ParentID    *string   `json:"parent_id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Path        string    `json:"path"`
+	Description *string   `json:"description"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CategoryNode adalah kategori beserta anak-anaknya untuk ditampilkan sebagai pohon
+type CategoryNode struct {
+	Category
+	Children []*CategoryNode `json:"children"`
+}
+
+// BuildTree menyusun daftar kategori datar menjadi pohon. Urutan anak mengikuti
+// urutan pada input, sehingga input sebaiknya sudah diurutkan berdasarkan position.
+func BuildTree(categories []Category) []*CategoryNode {
+	nodes := make(map[string]*CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryNode{Category: category, Children: []*CategoryNode{}}
+	}
+
+	roots := []*CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// Tag merepresentasikan tabel 'tags' di database
+type Tag struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	ArticleCount int       `json:"article_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
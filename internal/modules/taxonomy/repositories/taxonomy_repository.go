@@ -0,0 +1,676 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/taxonomy/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrDuplicateCategory dikembalikan ketika path kategori sudah dipakai
+	ErrDuplicateCategory = errors.New("kategori dengan slug tersebut sudah ada di induk yang sama")
+	// ErrDuplicateTag dikembalikan ketika slug tag sudah dipakai
+	ErrDuplicateTag = errors.New("tag dengan slug tersebut sudah ada")
+	// ErrSiblingsChanged dikembalikan ketika daftar urutan tidak sama dengan anak kategori di database
+	ErrSiblingsChanged = errors.New("daftar kategori tidak sesuai dengan anak kategori saat ini")
+	// ErrInvalidMove dikembalikan ketika induk baru adalah kategori itu sendiri atau turunannya
+	ErrInvalidMove = errors.New("kategori tidak dapat dipindahkan ke dirinya sendiri atau turunannya")
+	// ErrTreeChanged dikembalikan ketika kategori atau induk barunya berubah sejak dibaca
+	ErrTreeChanged = errors.New("struktur kategori telah diubah oleh proses lain")
+	// ErrInvalidReassignment dikembalikan ketika kategori pengganti adalah kategori yang dihapus
+	// atau turunannya
+	ErrInvalidReassignment = errors.New("kategori pengganti tidak boleh kategori yang dihapus atau turunannya")
+	// ErrCategoryHasChildren dikembalikan ketika kategori yang dihapus tanpa pengganti masih memiliki anak
+	ErrCategoryHasChildren = errors.New("kategori masih memiliki anak")
+)
+
+// categoryTreeLockID adalah kunci pg_advisory_xact_lock yang menyerialkan perubahan path
+// kategori: penggantian slug, pemindahan dan penghapusan
+const categoryTreeLockID int64 = 7260418
+
+// categoryColumns adalah daftar kolom yang dibaca oleh scanCategory
+const categoryColumns = `id, parent_id, name, slug, path, description, position, created_at, updated_at`
+
+// TaxonomyRepositoryInterface mendefinisikan kontrak untuk interaksi database kategori dan tag
+type TaxonomyRepositoryInterface interface {
+	ListCategories(ctx context.Context) ([]models.Category, error)
+	FindCategoryByID(ctx context.Context, categoryID string) (*models.Category, error)
+	CreateCategory(ctx context.Context, category *models.Category) error
+	UpdateCategory(ctx context.Context, category *models.Category, oldPath string) error
+	MoveCategory(ctx context.Context, category *models.Category, oldParentID *string, oldPath string, position *int) error
+	ReorderCategories(ctx context.Context, parentID *string, categoryIDs []string) error
+	DeleteCategory(ctx context.Context, category *models.Category, reassignTo *models.Category) error
+	ListTags(ctx context.Context) ([]models.Tag, error)
+	FindTagByID(ctx context.Context, tagID string) (*models.Tag, error)
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	UpdateTag(ctx context.Context, tag *models.Tag) error
+	DeleteTag(ctx context.Context, tagID string) error
+	MergeTags(ctx context.Context, sourceID, targetID string) error
+}
+
+// TaxonomyRepository adalah implementasi dari TaxonomyRepositoryInterface
+type TaxonomyRepository struct {
+	db *sql.DB
+}
+
+// NewTaxonomyRepository membuat instance baru dari TaxonomyRepository
+func NewTaxonomyRepository(db *sql.DB) *TaxonomyRepository {
+	return &TaxonomyRepository{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCategory(row rowScanner) (*models.Category, error) {
+	category := &models.Category{}
+	err := row.Scan(
+		&category.ID,
+		&category.ParentID,
+		&category.Name,
+		&category.Slug,
+		&category.Path,
+		&category.Description,
+		&category.Position,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// ListCategories mengambil semua kategori, diurutkan berdasarkan posisi di antara saudaranya
+func (r *TaxonomyRepository) ListCategories(ctx context.Context) ([]models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories ORDER BY position, name`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar kategori: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []models.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca data kategori: %w", err)
+		}
+		categories = append(categories, *category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar kategori: %w", err)
+	}
+	return categories, nil
+}
+
+// FindCategoryByID mencari kategori berdasarkan ID
+func (r *TaxonomyRepository) FindCategoryByID(ctx context.Context, categoryID string) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1`
+	category, err := scanCategory(r.db.QueryRowContext(ctx, query, categoryID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari kategori: %w", err)
+	}
+	return category, nil
+}
+
+// CreateCategory menyimpan kategori baru di urutan terakhir di antara saudaranya
+func (r *TaxonomyRepository) CreateCategory(ctx context.Context, category *models.Category) error {
+	category.ID = uuid.New().String()
+	category.CreatedAt = time.Now().UTC()
+	category.UpdatedAt = category.CreatedAt
+
+	query := `
+		INSERT INTO categories (id, parent_id, name, slug, path, description, position, created_at, updated_at)
+		SELECT $1, $2, $3, $4, $5, $6, COALESCE(MAX(position) + 1, 0), $7, $8
+		FROM categories WHERE parent_id IS NOT DISTINCT FROM $2
+		RETURNING position
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		category.ID,
+		category.ParentID,
+		category.Name,
+		category.Slug,
+		category.Path,
+		category.Description,
+		category.CreatedAt,
+		category.UpdatedAt,
+	).Scan(&category.Position)
+	if err != nil {
+		if isUniqueViolation(err, "categories_path_key") {
+			return ErrDuplicateCategory
+		}
+		return fmt.Errorf("gagal menyimpan kategori: %w", err)
+	}
+	return nil
+}
+
+// UpdateCategory menyimpan perubahan nama, slug dan deskripsi kategori. Jika slug berubah,
+// path seluruh turunannya ikut diperbarui dalam transaksi yang sama. ErrTreeChanged
+// dikembalikan jika path kategori sudah berbeda dari oldPath, misalnya karena dipindahkan.
+func (r *TaxonomyRepository) UpdateCategory(ctx context.Context, category *models.Category, oldPath string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockCategoryTree(ctx, tx); err != nil {
+		return err
+	}
+	if err := verifyPath(ctx, tx, category.ID, oldPath); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE categories
+		SET name = $1, slug = $2, description = $3
+		WHERE id = $4
+		RETURNING updated_at
+	`
+	err = tx.QueryRowContext(ctx, query,
+		category.Name,
+		category.Slug,
+		category.Description,
+		category.ID,
+	).Scan(&category.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("gagal memperbarui kategori: %w", err)
+	}
+
+	if err := rewritePaths(ctx, tx, oldPath, category.Path); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return nil
+}
+
+// MoveCategory memindahkan kategori beserta turunannya ke induk baru pada posisi tertentu.
+// Posisi saudara di induk lama dirapatkan kembali dan saudara di induk baru digeser.
+func (r *TaxonomyRepository) MoveCategory(ctx context.Context, category *models.Category, oldParentID *string, oldPath string, position *int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Dua pemindahan yang berjalan bersamaan (misalnya A ke bawah B dan B ke bawah A) masing-masing
+	// lolos pemeriksaan di service. Kunci pohon menyerialkan keduanya dan pemeriksaan diulang di
+	// sini terhadap data yang sudah dikunci.
+	if err := lockCategoryTree(ctx, tx); err != nil {
+		return err
+	}
+	if err := verifyMove(ctx, tx, category, oldPath); err != nil {
+		return err
+	}
+
+	siblings, err := lockSiblings(ctx, tx, category.ParentID)
+	if err != nil {
+		return err
+	}
+	siblings = removeID(siblings, category.ID)
+
+	index := len(siblings)
+	if position != nil && *position < index {
+		index = *position
+	}
+	siblings = append(siblings[:index], append([]string{category.ID}, siblings[index:]...)...)
+
+	query := `UPDATE categories SET parent_id = $1 WHERE id = $2`
+	if _, err := tx.ExecContext(ctx, query, category.ParentID, category.ID); err != nil {
+		return fmt.Errorf("gagal memindahkan kategori: %w", err)
+	}
+	if err := rewritePaths(ctx, tx, oldPath, category.Path); err != nil {
+		return err
+	}
+	if err := applyPositions(ctx, tx, siblings); err != nil {
+		return err
+	}
+
+	if !sameParent(oldParentID, category.ParentID) {
+		oldSiblings, err := lockSiblings(ctx, tx, oldParentID)
+		if err != nil {
+			return err
+		}
+		if err := applyPositions(ctx, tx, oldSiblings); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	category.Position = index
+	return nil
+}
+
+// ReorderCategories menyusun ulang urutan semua anak dari satu induk. categoryIDs harus
+// berisi tepat seluruh anak induk tersebut.
+func (r *TaxonomyRepository) ReorderCategories(ctx context.Context, parentID *string, categoryIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	siblings, err := lockSiblings(ctx, tx, parentID)
+	if err != nil {
+		return err
+	}
+	if !sameSet(siblings, categoryIDs) {
+		return ErrSiblingsChanged
+	}
+	if err := applyPositions(ctx, tx, categoryIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return nil
+}
+
+// DeleteCategory menghapus kategori. Jika reassignTo diisi, artikel dan anak kategori
+// dipindahkan ke kategori tersebut; jika tidak, artikel kehilangan kategorinya dan kategori
+// yang masih memiliki anak tidak dihapus (ErrCategoryHasChildren). Path kategori dan kategori
+// pengganti diperiksa ulang pada baris yang sudah dikunci.
+func (r *TaxonomyRepository) DeleteCategory(ctx context.Context, category *models.Category, reassignTo *models.Category) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Baris kategori dikunci FOR UPDATE sehingga anak baru yang disisipkan bersamaan menunggu
+	// transaksi ini dan tidak terlewat oleh pemeriksaan anak di bawah
+	if err := lockCategoryTree(ctx, tx); err != nil {
+		return err
+	}
+	if err := verifyPath(ctx, tx, category.ID, category.Path); err != nil {
+		return err
+	}
+
+	var targetID *string
+	if reassignTo != nil {
+		targetPath, err := lockCategoryPath(ctx, tx, reassignTo.ID)
+		if err != nil {
+			return err
+		}
+		if targetPath != reassignTo.Path {
+			return ErrTreeChanged
+		}
+		if targetPath == category.Path || strings.HasPrefix(targetPath, category.Path+"/") {
+			return ErrInvalidReassignment
+		}
+		targetID = &reassignTo.ID
+	} else {
+		var hasChildren bool
+		query := `SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id = $1)`
+		if err := tx.QueryRowContext(ctx, query, category.ID).Scan(&hasChildren); err != nil {
+			return fmt.Errorf("gagal memeriksa anak kategori: %w", err)
+		}
+		if hasChildren {
+			return ErrCategoryHasChildren
+		}
+	}
+
+	query := `UPDATE articles SET category_id = $1 WHERE category_id = $2`
+	if _, err := tx.ExecContext(ctx, query, targetID, category.ID); err != nil {
+		return fmt.Errorf("gagal memindahkan artikel kategori: %w", err)
+	}
+
+	if reassignTo != nil {
+		children, err := findChildren(ctx, tx, category.ID)
+		if err != nil {
+			return err
+		}
+		targetSiblings, err := lockSiblings(ctx, tx, targetID)
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children {
+			query := `UPDATE categories SET parent_id = $1 WHERE id = $2`
+			if _, err := tx.ExecContext(ctx, query, reassignTo.ID, child.ID); err != nil {
+				return fmt.Errorf("gagal memindahkan anak kategori: %w", err)
+			}
+			if err := rewritePaths(ctx, tx, child.Path, reassignTo.Path+"/"+child.Slug); err != nil {
+				return err
+			}
+			targetSiblings = append(targetSiblings, child.ID)
+		}
+		if err := applyPositions(ctx, tx, targetSiblings); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, category.ID); err != nil {
+		return fmt.Errorf("gagal menghapus kategori: %w", err)
+	}
+
+	siblings, err := lockSiblings(ctx, tx, category.ParentID)
+	if err != nil {
+		return err
+	}
+	if err := applyPositions(ctx, tx, siblings); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return nil
+}
+
+// rewritePaths mengganti awalan path kategori beserta seluruh turunannya
+func rewritePaths(ctx context.Context, tx *sql.Tx, oldPath, newPath string) error {
+	if oldPath == newPath {
+		return nil
+	}
+
+	// Slug hanya berisi huruf, angka dan tanda hubung sehingga aman dipakai dalam pola LIKE
+	query := `
+		UPDATE categories
+		SET path = $2 || substr(path, length($1) + 1)
+		WHERE path = $1 OR path LIKE $1 || '/%'
+	`
+	if _, err := tx.ExecContext(ctx, query, oldPath, newPath); err != nil {
+		if isUniqueViolation(err, "categories_path_key") {
+			return ErrDuplicateCategory
+		}
+		return fmt.Errorf("gagal memperbarui path kategori: %w", err)
+	}
+	return nil
+}
+
+// lockSiblings mengunci dan mengambil ID anak dari satu induk sesuai urutannya
+func lockSiblings(ctx context.Context, tx *sql.Tx, parentID *string) ([]string, error) {
+	query := `
+		SELECT id FROM categories
+		WHERE parent_id IS NOT DISTINCT FROM $1
+		ORDER BY position, name
+		FOR UPDATE
+	`
+	rows, err := tx.QueryContext(ctx, query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil saudara kategori: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("gagal membaca saudara kategori: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca saudara kategori: %w", err)
+	}
+	return ids, nil
+}
+
+// lockCategoryTree mengambil kunci pohon kategori hingga transaksi selesai
+func lockCategoryTree(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, categoryTreeLockID); err != nil {
+		return fmt.Errorf("gagal mengunci pohon kategori: %w", err)
+	}
+	return nil
+}
+
+// lockCategoryPath mengunci baris kategori dan mengembalikan path-nya. Kategori yang sudah
+// dihapus dianggap sebagai perubahan pohon.
+func lockCategoryPath(ctx context.Context, tx *sql.Tx, categoryID string) (string, error) {
+	var path string
+	err := tx.QueryRowContext(ctx, `SELECT path FROM categories WHERE id = $1 FOR UPDATE`, categoryID).Scan(&path)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrTreeChanged
+		}
+		return "", fmt.Errorf("gagal mengunci kategori: %w", err)
+	}
+	return path, nil
+}
+
+// verifyPath mengunci kategori dan memastikan path-nya masih sama dengan yang dibaca service
+func verifyPath(ctx context.Context, tx *sql.Tx, categoryID, expectedPath string) error {
+	currentPath, err := lockCategoryPath(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+	if currentPath != expectedPath {
+		return ErrTreeChanged
+	}
+	return nil
+}
+
+// verifyMove mengunci kategori dan induk barunya lalu memastikan keduanya belum berubah sejak
+// dibaca oleh service dan induk baru bukan kategori itu sendiri atau turunannya
+func verifyMove(ctx context.Context, tx *sql.Tx, category *models.Category, oldPath string) error {
+	if err := verifyPath(ctx, tx, category.ID, oldPath); err != nil {
+		return err
+	}
+	if category.ParentID == nil {
+		return nil
+	}
+
+	parentPath, err := lockCategoryPath(ctx, tx, *category.ParentID)
+	if err != nil {
+		return err
+	}
+	if parentPath == oldPath || strings.HasPrefix(parentPath, oldPath+"/") {
+		return ErrInvalidMove
+	}
+	if parentPath+"/"+category.Slug != category.Path {
+		return ErrTreeChanged
+	}
+	return nil
+}
+
+// findChildren mengambil anak langsung dari sebuah kategori
+func findChildren(ctx context.Context, tx *sql.Tx, parentID string) ([]models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE parent_id = $1 ORDER BY position, name`
+	rows, err := tx.QueryContext(ctx, query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil anak kategori: %w", err)
+	}
+	defer rows.Close()
+
+	children := []models.Category{}
+	for rows.Next() {
+		child, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca anak kategori: %w", err)
+		}
+		children = append(children, *child)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca anak kategori: %w", err)
+	}
+	return children, nil
+}
+
+// applyPositions menyimpan urutan kategori sesuai indeks pada ids
+func applyPositions(ctx context.Context, tx *sql.Tx, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := `
+		UPDATE categories AS c
+		SET position = o.position - 1
+		FROM unnest($1::text[]) WITH ORDINALITY AS o(id, position)
+		WHERE c.id = o.id AND c.position <> o.position - 1
+	`
+	if _, err := tx.ExecContext(ctx, query, pq.Array(ids)); err != nil {
+		return fmt.Errorf("gagal menyimpan urutan kategori: %w", err)
+	}
+	return nil
+}
+
+// ListTags mengambil semua tag beserta jumlah artikel yang memakainya
+func (r *TaxonomyRepository) ListTags(ctx context.Context) ([]models.Tag, error) {
+	query := `
+		SELECT t.id, t.name, t.slug, COUNT(at.article_id), t.created_at, t.updated_at
+		FROM tags t
+		LEFT JOIN article_tags at ON at.tag_id = t.id
+		GROUP BY t.id
+		ORDER BY t.name
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar tag: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []models.Tag{}
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.ArticleCount, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("gagal membaca data tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar tag: %w", err)
+	}
+	return tags, nil
+}
+
+// FindTagByID mencari tag berdasarkan ID
+func (r *TaxonomyRepository) FindTagByID(ctx context.Context, tagID string) (*models.Tag, error) {
+	query := `
+		SELECT t.id, t.name, t.slug,
+			(SELECT COUNT(*) FROM article_tags WHERE tag_id = t.id),
+			t.created_at, t.updated_at
+		FROM tags t
+		WHERE t.id = $1
+	`
+	var tag models.Tag
+	err := r.db.QueryRowContext(ctx, query, tagID).Scan(
+		&tag.ID, &tag.Name, &tag.Slug, &tag.ArticleCount, &tag.CreatedAt, &tag.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mencari tag: %w", err)
+	}
+	return &tag, nil
+}
+
+// CreateTag menyimpan tag baru
+func (r *TaxonomyRepository) CreateTag(ctx context.Context, tag *models.Tag) error {
+	tag.ID = uuid.New().String()
+	tag.CreatedAt = time.Now().UTC()
+	tag.UpdatedAt = tag.CreatedAt
+
+	query := `INSERT INTO tags (id, name, slug, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)`
+	if _, err := r.db.ExecContext(ctx, query, tag.ID, tag.Name, tag.Slug, tag.CreatedAt, tag.UpdatedAt); err != nil {
+		if isUniqueViolation(err, "tags_slug_key") {
+			return ErrDuplicateTag
+		}
+		return fmt.Errorf("gagal menyimpan tag: %w", err)
+	}
+	return nil
+}
+
+// UpdateTag menyimpan perubahan nama dan slug tag
+func (r *TaxonomyRepository) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	query := `UPDATE tags SET name = $1, slug = $2 WHERE id = $3 RETURNING updated_at`
+	if err := r.db.QueryRowContext(ctx, query, tag.Name, tag.Slug, tag.ID).Scan(&tag.UpdatedAt); err != nil {
+		if isUniqueViolation(err, "tags_slug_key") {
+			return ErrDuplicateTag
+		}
+		return fmt.Errorf("gagal memperbarui tag: %w", err)
+	}
+	return nil
+}
+
+// DeleteTag menghapus tag beserta penandaannya pada artikel
+func (r *TaxonomyRepository) DeleteTag(ctx context.Context, tagID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM tags WHERE id = $1`, tagID); err != nil {
+		return fmt.Errorf("gagal menghapus tag: %w", err)
+	}
+	return nil
+}
+
+// MergeTags memindahkan semua artikel dari tag sumber ke tag tujuan lalu menghapus tag sumber
+func (r *TaxonomyRepository) MergeTags(ctx context.Context, sourceID, targetID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO article_tags (article_id, tag_id)
+		SELECT article_id, $2 FROM article_tags WHERE tag_id = $1
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, query, sourceID, targetID); err != nil {
+		return fmt.Errorf("gagal memindahkan artikel tag: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE id = $1`, sourceID); err != nil {
+		return fmt.Errorf("gagal menghapus tag sumber: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return nil
+}
+
+func removeID(ids []string, id string) []string {
+	result := make([]string, 0, len(ids))
+	for _, existing := range ids {
+		if existing != id {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]bool, len(a))
+	for _, id := range a {
+		seen[id] = true
+	}
+	for _, id := range b {
+		if !seen[id] {
+			return false
+		}
+		delete(seen, id)
+	}
+	return len(seen) == 0
+}
+
+func sameParent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// isUniqueViolation memeriksa apakah error adalah pelanggaran constraint unik tertentu
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
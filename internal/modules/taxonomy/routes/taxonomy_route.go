@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/taxonomy/handlers"
+)
+
+// TaxonomyRoutes mengelola pendaftaran rute untuk modul kategori dan tag
+type TaxonomyRoutes struct {
+	taxonomyHandler *handlers.TaxonomyHandler
+}
+
+// NewTaxonomyRoutes membuat instance baru dari TaxonomyRoutes
+func NewTaxonomyRoutes(taxonomyHandler *handlers.TaxonomyHandler) *TaxonomyRoutes {
+	return &TaxonomyRoutes{taxonomyHandler: taxonomyHandler}
+}
+
+// RegisterRoutes mendaftarkan rute taksonomi ke router. Rute pengelolaan dibungkus
+// dengan guard, sedangkan pohon kategori dan daftar tag terbuka untuk publik.
+func (r *TaxonomyRoutes) RegisterRoutes(router *http.ServeMux, guard func(http.Handler) http.Handler) {
+	router.Handle("/taxonomy/categories", guard(http.HandlerFunc(r.taxonomyHandler.Categories)))
+	router.Handle("/taxonomy/categories/reorder", guard(http.HandlerFunc(r.taxonomyHandler.ReorderCategories)))
+	router.Handle("/taxonomy/categories/{categoryID}", guard(http.HandlerFunc(r.taxonomyHandler.Category)))
+	router.Handle("/taxonomy/categories/{categoryID}/move", guard(http.HandlerFunc(r.taxonomyHandler.MoveCategory)))
+	router.Handle("/taxonomy/tags", guard(http.HandlerFunc(r.taxonomyHandler.Tags)))
+	router.Handle("/taxonomy/tags/{tagID}", guard(http.HandlerFunc(r.taxonomyHandler.Tag)))
+	router.Handle("/taxonomy/tags/{tagID}/merge", guard(http.HandlerFunc(r.taxonomyHandler.MergeTags)))
+
+	router.HandleFunc("/public/categories", r.taxonomyHandler.CategoryTree)
+	router.HandleFunc("/public/tags", r.taxonomyHandler.PublicTags)
+}
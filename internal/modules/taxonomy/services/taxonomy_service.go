@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/taxonomy/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/taxonomy/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/taxonomy/repositories"
+	"github.com/jokosaputro95/cms-go/internal/pkg/slug"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// TaxonomyServiceError adalah tipe error kustom untuk service taksonomi
+type TaxonomyServiceError string
+
+func (e TaxonomyServiceError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrCategoryNotFound       = TaxonomyServiceError("kategori tidak ditemukan")
+	ErrParentNotFound         = TaxonomyServiceError("kategori induk tidak ditemukan")
+	ErrCategoryExists         = TaxonomyServiceError("kategori dengan slug tersebut sudah ada di induk yang sama")
+	ErrInvalidCategoryMove    = TaxonomyServiceError("kategori tidak dapat dipindahkan ke dirinya sendiri atau turunannya")
+	ErrInvalidCategoryOrder   = TaxonomyServiceError("category_ids harus berisi tepat seluruh anak dari induk tersebut")
+	ErrCategoryHasChildren    = TaxonomyServiceError("kategori masih memiliki anak, tentukan reassign_to untuk memindahkannya")
+	ErrInvalidReassignment    = TaxonomyServiceError("kategori pengganti tidak boleh kategori yang dihapus atau turunannya")
+	ErrReassignTargetNotFound = TaxonomyServiceError("kategori pengganti tidak ditemukan")
+	ErrTagNotFound            = TaxonomyServiceError("tag tidak ditemukan")
+	ErrTagExists              = TaxonomyServiceError("tag dengan slug tersebut sudah ada")
+	ErrMergeSameTag           = TaxonomyServiceError("tag sumber dan tujuan tidak boleh sama")
+	ErrInvalidSlug            = TaxonomyServiceError("slug tidak valid")
+	ErrCategoryTreeChanged    = TaxonomyServiceError("struktur kategori telah diubah oleh proses lain, muat ulang kategori")
+)
+
+// TaxonomyServiceInterface mendefinisikan kontrak untuk service kategori dan tag
+type TaxonomyServiceInterface interface {
+	ListCategories(ctx context.Context) ([]models.Category, error)
+	CategoryTree(ctx context.Context) ([]*models.CategoryNode, error)
+	GetCategory(ctx context.Context, categoryID string) (*models.Category, error)
+	CreateCategory(ctx context.Context, req *dto.CreateCategoryRequestDTO) (*models.Category, error)
+	UpdateCategory(ctx context.Context, categoryID string, req *dto.UpdateCategoryRequestDTO) (*models.Category, error)
+	MoveCategory(ctx context.Context, categoryID string, req *dto.MoveCategoryRequestDTO) (*models.Category, error)
+	ReorderCategories(ctx context.Context, req *dto.ReorderCategoriesRequestDTO) ([]models.Category, error)
+	DeleteCategory(ctx context.Context, categoryID string, reassignTo string) error
+	ListTags(ctx context.Context) ([]models.Tag, error)
+	CreateTag(ctx context.Context, req *dto.CreateTagRequestDTO) (*models.Tag, error)
+	UpdateTag(ctx context.Context, tagID string, req *dto.UpdateTagRequestDTO) (*models.Tag, error)
+	DeleteTag(ctx context.Context, tagID string) error
+	MergeTags(ctx context.Context, sourceID string, req *dto.MergeTagsRequestDTO) (*models.Tag, error)
+}
+
+// TaxonomyService adalah implementasi dari TaxonomyServiceInterface
+type TaxonomyService struct {
+	taxonomyRepo repositories.TaxonomyRepositoryInterface
+	validate     *validator.Validate
+}
+
+// NewTaxonomyService membuat instance baru dari TaxonomyService
+func NewTaxonomyService(taxonomyRepo repositories.TaxonomyRepositoryInterface) *TaxonomyService {
+	return &TaxonomyService{
+		taxonomyRepo: taxonomyRepo,
+		validate:     validator.New(),
+	}
+}
+
+// ListCategories mengambil semua kategori dalam bentuk daftar datar
+func (s *TaxonomyService) ListCategories(ctx context.Context) ([]models.Category, error) {
+	return s.taxonomyRepo.ListCategories(ctx)
+}
+
+// CategoryTree mengambil semua kategori dalam bentuk pohon
+func (s *TaxonomyService) CategoryTree(ctx context.Context) ([]*models.CategoryNode, error) {
+	categories, err := s.taxonomyRepo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return models.BuildTree(categories), nil
+}
+
+// GetCategory mengambil kategori berdasarkan ID
+func (s *TaxonomyService) GetCategory(ctx context.Context, categoryID string) (*models.Category, error) {
+	return s.findCategory(ctx, categoryID, ErrCategoryNotFound)
+}
+
+// CreateCategory membuat kategori baru di bawah induk yang dipilih
+func (s *TaxonomyService) CreateCategory(ctx context.Context, req *dto.CreateCategoryRequestDTO) (*models.Category, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	categorySlug := makeSlug(req.Slug, req.Name)
+	if categorySlug == "" {
+		return nil, ErrInvalidSlug
+	}
+
+	category := &models.Category{
+		ParentID:    normalizeID(req.ParentID),
+		Name:        strings.TrimSpace(req.Name),
+		Slug:        categorySlug,
+		Path:        categorySlug,
+		Description: req.Description,
+	}
+	if category.ParentID != nil {
+		parent, err := s.findCategory(ctx, *category.ParentID, ErrParentNotFound)
+		if err != nil {
+			return nil, err
+		}
+		category.Path = parent.Path + "/" + categorySlug
+	}
+
+	if err := s.taxonomyRepo.CreateCategory(ctx, category); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	log.Printf("Kategori %s dibuat", category.Path)
+	return category, nil
+}
+
+// UpdateCategory mengubah nama, slug atau deskripsi kategori
+func (s *TaxonomyService) UpdateCategory(ctx context.Context, categoryID string, req *dto.UpdateCategoryRequestDTO) (*models.Category, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	category, err := s.findCategory(ctx, categoryID, ErrCategoryNotFound)
+	if err != nil {
+		return nil, err
+	}
+	oldPath := category.Path
+
+	if req.Name != nil {
+		category.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		category.Description = req.Description
+	}
+	if req.Slug != nil {
+		categorySlug := slug.Make(*req.Slug)
+		if categorySlug == "" {
+			return nil, ErrInvalidSlug
+		}
+		category.Slug = categorySlug
+		category.Path = replaceLastSegment(category.Path, categorySlug)
+	}
+
+	if err := s.taxonomyRepo.UpdateCategory(ctx, category, oldPath); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	return category, nil
+}
+
+// MoveCategory memindahkan kategori beserta turunannya ke induk lain
+func (s *TaxonomyService) MoveCategory(ctx context.Context, categoryID string, req *dto.MoveCategoryRequestDTO) (*models.Category, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	category, err := s.findCategory(ctx, categoryID, ErrCategoryNotFound)
+	if err != nil {
+		return nil, err
+	}
+	oldParentID := category.ParentID
+	oldPath := category.Path
+
+	newParentID := normalizeID(req.ParentID)
+	newPath := category.Slug
+	if newParentID != nil {
+		parent, err := s.findCategory(ctx, *newParentID, ErrParentNotFound)
+		if err != nil {
+			return nil, err
+		}
+		// Induk baru tidak boleh kategori itu sendiri atau turunannya
+		if isSameOrDescendant(parent.Path, category.Path) {
+			return nil, ErrInvalidCategoryMove
+		}
+		newPath = parent.Path + "/" + category.Slug
+	}
+
+	category.ParentID = newParentID
+	category.Path = newPath
+	if err := s.taxonomyRepo.MoveCategory(ctx, category, oldParentID, oldPath, req.Position); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	log.Printf("Kategori %s dipindahkan ke %s", oldPath, category.Path)
+	return category, nil
+}
+
+// ReorderCategories mengurutkan ulang anak-anak dari satu induk
+func (s *TaxonomyService) ReorderCategories(ctx context.Context, req *dto.ReorderCategoriesRequestDTO) ([]models.Category, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	parentID := normalizeID(req.ParentID)
+	if parentID != nil {
+		if _, err := s.findCategory(ctx, *parentID, ErrParentNotFound); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.taxonomyRepo.ReorderCategories(ctx, parentID, req.CategoryIDs); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	return s.taxonomyRepo.ListCategories(ctx)
+}
+
+// DeleteCategory menghapus kategori. Artikel dan anak kategori dipindahkan ke reassignTo
+// jika diisi. Tanpa reassignTo, kategori yang masih memiliki anak tidak dapat dihapus;
+// pemeriksaannya dilakukan repository di dalam transaksi penghapusan.
+func (s *TaxonomyService) DeleteCategory(ctx context.Context, categoryID string, reassignTo string) error {
+	category, err := s.findCategory(ctx, categoryID, ErrCategoryNotFound)
+	if err != nil {
+		return err
+	}
+
+	var target *models.Category
+	if reassignTo != "" {
+		target, err = s.findCategory(ctx, reassignTo, ErrReassignTargetNotFound)
+		if err != nil {
+			return err
+		}
+		if isSameOrDescendant(target.Path, category.Path) {
+			return ErrInvalidReassignment
+		}
+	}
+
+	if err := s.taxonomyRepo.DeleteCategory(ctx, category, target); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	log.Printf("Kategori %s dihapus", category.Path)
+	return nil
+}
+
+// ListTags mengambil semua tag
+func (s *TaxonomyService) ListTags(ctx context.Context) ([]models.Tag, error) {
+	return s.taxonomyRepo.ListTags(ctx)
+}
+
+// CreateTag membuat tag baru
+func (s *TaxonomyService) CreateTag(ctx context.Context, req *dto.CreateTagRequestDTO) (*models.Tag, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	tagSlug := makeSlug(req.Slug, req.Name)
+	if tagSlug == "" {
+		return nil, ErrInvalidSlug
+	}
+
+	tag := &models.Tag{Name: strings.TrimSpace(req.Name), Slug: tagSlug}
+	if err := s.taxonomyRepo.CreateTag(ctx, tag); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	return tag, nil
+}
+
+// UpdateTag mengubah nama atau slug tag
+func (s *TaxonomyService) UpdateTag(ctx context.Context, tagID string, req *dto.UpdateTagRequestDTO) (*models.Tag, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	tag, err := s.findTag(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		tag.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Slug != nil {
+		tag.Slug = slug.Make(*req.Slug)
+		if tag.Slug == "" {
+			return nil, ErrInvalidSlug
+		}
+	}
+
+	if err := s.taxonomyRepo.UpdateTag(ctx, tag); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	return tag, nil
+}
+
+// DeleteTag menghapus tag dan melepasnya dari semua artikel
+func (s *TaxonomyService) DeleteTag(ctx context.Context, tagID string) error {
+	if _, err := s.findTag(ctx, tagID); err != nil {
+		return err
+	}
+	return s.taxonomyRepo.DeleteTag(ctx, tagID)
+}
+
+// MergeTags menggabungkan tag sumber ke tag tujuan. Artikel yang memakai tag sumber
+// akan memakai tag tujuan dan tag sumber dihapus.
+func (s *TaxonomyService) MergeTags(ctx context.Context, sourceID string, req *dto.MergeTagsRequestDTO) (*models.Tag, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+	if sourceID == req.TargetTagID {
+		return nil, ErrMergeSameTag
+	}
+
+	source, err := s.findTag(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.findTag(ctx, req.TargetTagID); err != nil {
+		return nil, err
+	}
+
+	if err := s.taxonomyRepo.MergeTags(ctx, source.ID, req.TargetTagID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Tag %s digabungkan ke tag %s", source.Slug, req.TargetTagID)
+	return s.findTag(ctx, req.TargetTagID)
+}
+
+func (s *TaxonomyService) findCategory(ctx context.Context, categoryID string, notFound error) (*models.Category, error) {
+	category, err := s.taxonomyRepo.FindCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, notFound
+	}
+	return category, nil
+}
+
+func (s *TaxonomyService) findTag(ctx context.Context, tagID string) (*models.Tag, error) {
+	tag, err := s.taxonomyRepo.FindTagByID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+	return tag, nil
+}
+
+// mapRepositoryError menerjemahkan error repository ke error service
+func mapRepositoryError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrDuplicateCategory):
+		return ErrCategoryExists
+	case errors.Is(err, repositories.ErrDuplicateTag):
+		return ErrTagExists
+	case errors.Is(err, repositories.ErrSiblingsChanged):
+		return ErrInvalidCategoryOrder
+	case errors.Is(err, repositories.ErrInvalidMove):
+		return ErrInvalidCategoryMove
+	case errors.Is(err, repositories.ErrTreeChanged):
+		return ErrCategoryTreeChanged
+	case errors.Is(err, repositories.ErrInvalidReassignment):
+		return ErrInvalidReassignment
+	case errors.Is(err, repositories.ErrCategoryHasChildren):
+		return ErrCategoryHasChildren
+	default:
+		return err
+	}
+}
+
+// makeSlug membuat slug dari input atau, jika kosong, dari nama
+func makeSlug(requested, name string) string {
+	if strings.TrimSpace(requested) != "" {
+		return slug.Make(requested)
+	}
+	return slug.Make(name)
+}
+
+// normalizeID mengubah string kosong menjadi nil agar dianggap root
+func normalizeID(id *string) *string {
+	if id == nil || strings.TrimSpace(*id) == "" {
+		return nil
+	}
+	return id
+}
+
+// isSameOrDescendant memeriksa apakah path sama dengan ancestorPath atau berada di bawahnya
+func isSameOrDescendant(path, ancestorPath string) bool {
+	return path == ancestorPath || strings.HasPrefix(path, ancestorPath+"/")
+}
+
+func replaceLastSegment(path, segment string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[:i+1] + segment
+	}
+	return segment
+}
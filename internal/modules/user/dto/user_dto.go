@@ -0,0 +1,27 @@
+package dto
+
+import (
+	profile_models "github.com/jokosaputro95/cms-go/internal/modules/profile/models"
+	role_models "github.com/jokosaputro95/cms-go/internal/modules/role/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/user/models"
+)
+
+// UpdateUserStatusRequestDTO digunakan admin untuk mengubah status akun. Alasan wajib diisi
+// dan disimpan di kolom issued_reason.
+type UpdateUserStatusRequestDTO struct {
+	Status string `json:"status" validate:"required,oneof=active suspend banned locked inactive"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+// UnlockUserRequestDTO digunakan admin untuk membuka kunci akun secara manual
+type UnlockUserRequestDTO struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+// UserDetailResponseDTO berisi akun pengguna beserta profil dan role-nya
+type UserDetailResponseDTO struct {
+	User models.User `json:"user"`
+	// Profile bernilai null jika pengguna belum memiliki profil
+	Profile *profile_models.UserProfile `json:"profile"`
+	Roles   []role_models.Role          `json:"roles"`
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/security/middleware"
+	"github.com/jokosaputro95/cms-go/internal/modules/user/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/user/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/user/services"
+	"github.com/jokosaputro95/cms-go/internal/pkg/api"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserHandler menangani permintaan HTTP untuk manajemen pengguna oleh admin
+type UserHandler struct {
+	userService services.UserServiceInterface
+}
+
+// NewUserHandler membuat instance baru dari UserHandler
+func NewUserHandler(userService services.UserServiceInterface) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+// Users menangani pencarian daftar pengguna. Query parameter q mencari username atau email,
+// status dan role memfilter hasil, dan locked=true hanya mengambil akun yang sedang terkunci.
+func (h *UserHandler) Users(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	page, perPage := api.ParsePagination(r)
+	locked, _ := strconv.ParseBool(r.URL.Query().Get("locked"))
+	filter := models.UserFilter{
+		Search:  r.URL.Query().Get("q"),
+		Status:  r.URL.Query().Get("status"),
+		Role:    r.URL.Query().Get("role"),
+		Locked:  locked,
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	users, total, err := h.userService.ListUsers(r.Context(), filter)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to list users")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Users fetched successfully", users, api.NewPaginationMeta(page, perPage, total))
+}
+
+// User menangani detail satu pengguna beserta profil dan role-nya
+func (h *UserHandler) User(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	user, err := h.userService.GetUser(r.Context(), r.PathValue("userID"))
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to get user")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "User fetched successfully", user, nil)
+}
+
+// UserStatus menangani perubahan status akun pengguna dengan alasan yang wajib diisi
+func (h *UserHandler) UserStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	actorID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	var req dto.UpdateUserStatusRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.userService.UpdateUserStatus(r.Context(), actorID, r.PathValue("userID"), &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to update user status")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "User status updated successfully", user, nil)
+}
+
+// UnlockUser menangani pembukaan kunci akun pengguna secara manual
+func (h *UserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	actorID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	var req dto.UnlockUserRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.userService.UnlockUser(r.Context(), actorID, r.PathValue("userID"), &req)
+	if err != nil {
+		h.sendServiceError(w, err, "Failed to unlock user")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "User unlocked successfully", user, nil)
+}
+
+// ForcePasswordReset menangani permintaan admin agar pengguna wajib membuat password baru
+func (h *UserHandler) ForcePasswordReset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.SendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	actorID, ok := r.Context().Value(middleware.UserIDContextKey).(string)
+	if !ok {
+		api.SendError(w, http.StatusInternalServerError, "User ID not found in context")
+		return
+	}
+
+	if err := h.userService.ForcePasswordReset(r.Context(), actorID, r.PathValue("userID")); err != nil {
+		h.sendServiceError(w, err, "Failed to force password reset")
+		return
+	}
+	api.SendSuccess(w, http.StatusOK, "Password reset forced. A reset link has been sent to the user.", nil, nil)
+}
+
+// sendServiceError memetakan error dari UserService ke respons HTTP
+func (h *UserHandler) sendServiceError(w http.ResponseWriter, err error, fallbackMessage string) {
+	log.Printf("%s: %v", fallbackMessage, err)
+
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		api.SendDetailedError(w, http.StatusBadRequest, "Invalid request", "validation_error", nil)
+	case errors.Is(err, services.ErrInvalidStatus):
+		api.SendDetailedError(w, http.StatusBadRequest, err.Error(), "validation_error", nil)
+	case errors.Is(err, services.ErrUserNotFound):
+		api.SendDetailedError(w, http.StatusNotFound, err.Error(), "not_found", nil)
+	case errors.Is(err, services.ErrCannotChangeOwnStatus):
+		api.SendDetailedError(w, http.StatusForbidden, err.Error(), "forbidden", nil)
+	default:
+		api.SendError(w, http.StatusInternalServerError, fallbackMessage)
+	}
+}
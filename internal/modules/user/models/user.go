@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+// Status akun pengguna yang disimpan di kolom users.status
+const (
+	StatusPending   = "pending"
+	StatusActive    = "active"
+	StatusSuspended = "suspend"
+	StatusBanned    = "banned"
+	StatusLocked    = "locked"
+	StatusInactive  = "inactive"
+)
+
+// ManageableStatuses adalah status yang dapat diberikan admin. Status pending hanya dipakai
+// sampai pengguna memverifikasi email.
+var ManageableStatuses = []string{StatusActive, StatusSuspended, StatusBanned, StatusLocked, StatusInactive}
+
+// User merepresentasikan akun di tabel 'users' beserta nama role-nya untuk keperluan admin
+type User struct {
+	ID                  string     `json:"id"`
+	Username            string     `json:"username"`
+	Email               string     `json:"email"`
+	HasPassword         bool       `json:"has_password"`
+	RegistrationMethod  string     `json:"registration_method"`
+	OAuthProvider       *string    `json:"oauth_provider"`
+	Status              string     `json:"status"`
+	EmailVerified       bool       `json:"email_verified"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
+	LastActionBy        *string    `json:"last_action_by"`
+	IssuedReason        *string    `json:"issued_reason"`
+	IssuedAt            *time.Time `json:"issued_at"`
+	CurrentLoginAt      *time.Time `json:"current_login_at"`
+	CurrentLoginIP      *string    `json:"current_login_ip"`
+	FailedLoginAttempts int        `json:"failed_login_attempts"`
+	LockedUntil         *time.Time `json:"locked_until"`
+	Roles               []string   `json:"roles"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// UserFilter berisi kriteria pencarian daftar pengguna
+type UserFilter struct {
+	// Search mencocokkan sebagian username atau email
+	Search string
+	Status string
+	// Role adalah nama role yang dimiliki pengguna
+	Role string
+	// Locked hanya mengambil pengguna yang sedang terkunci karena gagal login
+	Locked  bool
+	Page    int
+	PerPage int
+}
@@ -0,0 +1,222 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	auth_models "github.com/jokosaputro95/cms-go/internal/modules/auth/models"
+	auth_repositories "github.com/jokosaputro95/cms-go/internal/modules/auth/repositories"
+	"github.com/jokosaputro95/cms-go/internal/modules/user/models"
+	"github.com/jokosaputro95/cms-go/internal/pkg/sqlutil"
+
+	"github.com/lib/pq"
+)
+
+const userColumns = `
+	u.id, u.username, u.email, u.password_hash IS NOT NULL, u.registration_method, u.oauth_provider,
+	u.status, u.email_verified, u.email_verified_at, u.last_action_by, u.issued_reason, u.issued_at,
+	u.current_login_at, u.current_login_ip, COALESCE(u.failed_login_attempts, 0), u.locked_until,
+	ARRAY(
+		SELECT r.name FROM user_roles ur
+		JOIN roles r ON r.id = ur.role_id
+		WHERE ur.user_id = u.id
+		ORDER BY r.name
+	),
+	u.created_at, u.updated_at
+`
+
+// UserRepositoryInterface mendefinisikan kontrak untuk interaksi database manajemen pengguna
+type UserRepositoryInterface interface {
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, int, error)
+	FindUserByID(ctx context.Context, userID string) (*models.User, error)
+	UpdateUserStatus(ctx context.Context, userID, status, actorID, reason string, revokeBefore time.Time) (bool, error)
+	UnlockUser(ctx context.Context, userID, actorID, reason string) (bool, error)
+}
+
+// UserRepository adalah implementasi dari UserRepositoryInterface
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository membuat instance baru dari UserRepository
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row rowScanner) (*models.User, error) {
+	user := &models.User{}
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.HasPassword,
+		&user.RegistrationMethod,
+		&user.OAuthProvider,
+		&user.Status,
+		&user.EmailVerified,
+		&user.EmailVerifiedAt,
+		&user.LastActionBy,
+		&user.IssuedReason,
+		&user.IssuedAt,
+		&user.CurrentLoginAt,
+		&user.CurrentLoginIP,
+		&user.FailedLoginAttempts,
+		&user.LockedUntil,
+		pq.Array(&user.Roles),
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListUsers mengambil daftar pengguna sesuai filter beserta jumlah totalnya
+func (r *UserRepository) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Search != "" {
+		args = append(args, sqlutil.ContainsPattern(filter.Search))
+		conditions = append(conditions, fmt.Sprintf(`(u.username ILIKE $%[1]d ESCAPE '\' OR u.email ILIKE $%[1]d ESCAPE '\')`, len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("u.status = $%d", len(args)))
+	}
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM user_roles ur
+			JOIN roles r ON r.id = ur.role_id
+			WHERE ur.user_id = u.id AND r.name = $%d
+		)`, len(args)))
+	}
+	if filter.Locked {
+		conditions = append(conditions, "u.locked_until > NOW()")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM users u ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal menghitung pengguna: %w", err)
+	}
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	query := fmt.Sprintf(`SELECT %s FROM users u %s ORDER BY u.created_at DESC, u.id LIMIT $%d OFFSET $%d`,
+		userColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mengambil daftar pengguna: %w", err)
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("gagal membaca data pengguna: %w", err)
+		}
+		users = append(users, *user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("gagal membaca daftar pengguna: %w", err)
+	}
+	return users, total, nil
+}
+
+// FindUserByID mengambil pengguna berdasarkan ID, nil jika tidak ada
+func (r *UserRepository) FindUserByID(ctx context.Context, userID string) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users u WHERE u.id = $1`
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal mengambil pengguna: %w", err)
+	}
+	return user, nil
+}
+
+// UpdateUserStatus mengubah status akun dan mencatat admin, alasan serta waktu perubahannya.
+// Untuk status selain active, epoch token pengguna dimajukan ke revokeBefore, semua sesi aktif
+// dan token OAuth pengguna dicabut dalam transaksi yang sama, sehingga akun tidak pernah berstatus nonaktif
+// sementara token lamanya masih berlaku. Hasil false berarti pengguna tidak ditemukan.
+func (r *UserRepository) UpdateUserStatus(ctx context.Context, userID, status, actorID, reason string, revokeBefore time.Time) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users
+		SET status = $2, last_action_by = $3, issued_reason = $4, issued_at = NOW()
+		WHERE id = $1
+	`
+	res, err := tx.ExecContext(ctx, query, userID, status, actorID, reason)
+	if err != nil {
+		return false, fmt.Errorf("gagal memperbarui status pengguna: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	if status != models.StatusActive {
+		if _, err := auth_repositories.RevokeUserAccessTx(ctx, tx, userID, revokeBefore, auth_models.SessionRevokedByAdmin); err != nil {
+			return false, err
+		}
+		if err := auth_repositories.RevokeUserOAuthTokensTx(ctx, tx, userID); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return true, nil
+}
+
+// UnlockUser mereset penghitung gagal login dan penguncian sementara. Akun berstatus locked
+// diaktifkan kembali dan perubahannya dicatat; status lain tidak diubah. Hasil false berarti
+// pengguna tidak ditemukan.
+func (r *UserRepository) UnlockUser(ctx context.Context, userID, actorID, reason string) (bool, error) {
+	query := `
+		UPDATE users
+		SET failed_login_attempts = 0,
+			locked_until = NULL,
+			last_action_by = CASE WHEN status = $2 THEN $4 ELSE last_action_by END,
+			issued_reason = CASE WHEN status = $2 THEN $5 ELSE issued_reason END,
+			issued_at = CASE WHEN status = $2 THEN NOW() ELSE issued_at END,
+			status = CASE WHEN status = $2 THEN $3 ELSE status END
+		WHERE id = $1
+	`
+	res, err := r.db.ExecContext(ctx, query, userID, models.StatusLocked, models.StatusActive, actorID, reason)
+	if err != nil {
+		return false, fmt.Errorf("gagal membuka kunci pengguna: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan jumlah baris yang terpengaruh: %w", err)
+	}
+	return rows > 0, nil
+}
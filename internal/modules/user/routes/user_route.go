@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jokosaputro95/cms-go/internal/modules/user/handlers"
+)
+
+// UserRoutes mengelola pendaftaran rute untuk modul manajemen pengguna
+type UserRoutes struct {
+	userHandler *handlers.UserHandler
+}
+
+// NewUserRoutes membuat instance baru dari UserRoutes
+func NewUserRoutes(userHandler *handlers.UserHandler) *UserRoutes {
+	return &UserRoutes{userHandler: userHandler}
+}
+
+// RegisterRoutes mendaftarkan rute admin pengguna ke router.
+// Semua rute dibungkus dengan middleware guard yang memeriksa otentikasi dan permission.
+func (r *UserRoutes) RegisterRoutes(router *http.ServeMux, guard func(http.Handler) http.Handler) {
+	router.Handle("/admin/users", guard(http.HandlerFunc(r.userHandler.Users)))
+	router.Handle("/admin/users/{userID}", guard(http.HandlerFunc(r.userHandler.User)))
+	router.Handle("/admin/users/{userID}/status", guard(http.HandlerFunc(r.userHandler.UserStatus)))
+	router.Handle("/admin/users/{userID}/unlock", guard(http.HandlerFunc(r.userHandler.UnlockUser)))
+	router.Handle("/admin/users/{userID}/password-reset", guard(http.HandlerFunc(r.userHandler.ForcePasswordReset)))
+}
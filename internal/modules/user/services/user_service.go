@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	profile_models "github.com/jokosaputro95/cms-go/internal/modules/profile/models"
+	role_models "github.com/jokosaputro95/cms-go/internal/modules/role/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/user/dto"
+	"github.com/jokosaputro95/cms-go/internal/modules/user/models"
+	"github.com/jokosaputro95/cms-go/internal/modules/user/repositories"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserServiceError adalah tipe error kustom untuk service manajemen pengguna
+type UserServiceError string
+
+func (e UserServiceError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrUserNotFound          = UserServiceError("pengguna tidak ditemukan")
+	ErrInvalidStatus         = UserServiceError("status pengguna tidak valid")
+	ErrCannotChangeOwnStatus = UserServiceError("admin tidak dapat mengubah status akunnya sendiri")
+)
+
+// ProfileReader mengambil profil pengguna; nil jika pengguna belum memiliki profil
+type ProfileReader interface {
+	FindProfileByUserID(ctx context.Context, userID string) (*profile_models.UserProfile, error)
+}
+
+// RoleReader mengambil role yang dimiliki pengguna
+type RoleReader interface {
+	GetUserRoles(ctx context.Context, userID string) ([]role_models.Role, error)
+}
+
+// AccountAccess mengelola akses login pengguna. Diimplementasikan oleh AuthService karena
+// token, sesi dan email reset password dikelola di modul auth.
+type AccountAccess interface {
+	SetUserTokenEpoch(userID string, validAfter time.Time)
+	ForcePasswordReset(ctx context.Context, userID string) error
+}
+
+// UserServiceInterface mendefinisikan kontrak untuk service manajemen pengguna
+type UserServiceInterface interface {
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, int, error)
+	GetUser(ctx context.Context, userID string) (*dto.UserDetailResponseDTO, error)
+	UpdateUserStatus(ctx context.Context, actorID, userID string, req *dto.UpdateUserStatusRequestDTO) (*models.User, error)
+	UnlockUser(ctx context.Context, actorID, userID string, req *dto.UnlockUserRequestDTO) (*models.User, error)
+	ForcePasswordReset(ctx context.Context, actorID, userID string) error
+}
+
+// UserService adalah implementasi dari UserServiceInterface
+type UserService struct {
+	userRepo repositories.UserRepositoryInterface
+	profiles ProfileReader
+	roles    RoleReader
+	access   AccountAccess
+	validate *validator.Validate
+}
+
+// NewUserService membuat instance baru dari UserService
+func NewUserService(userRepo repositories.UserRepositoryInterface, profiles ProfileReader, roles RoleReader, access AccountAccess) *UserService {
+	return &UserService{
+		userRepo: userRepo,
+		profiles: profiles,
+		roles:    roles,
+		access:   access,
+		validate: validator.New(),
+	}
+}
+
+// ListUsers mencari pengguna berdasarkan username/email, status dan role
+func (s *UserService) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, int, error) {
+	filter.Search = strings.TrimSpace(filter.Search)
+	if filter.Status != "" && filter.Status != models.StatusPending && !isManageableStatus(filter.Status) {
+		return nil, 0, ErrInvalidStatus
+	}
+	return s.userRepo.ListUsers(ctx, filter)
+}
+
+// GetUser mengambil akun pengguna beserta profil dan role-nya
+func (s *UserService) GetUser(ctx context.Context, userID string) (*dto.UserDetailResponseDTO, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.profiles.FindProfileByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.roles.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UserDetailResponseDTO{User: *user, Profile: profile, Roles: roles}, nil
+}
+
+// UpdateUserStatus mengubah status akun dengan alasan yang wajib diisi. Selain status active,
+// semua token dan sesi login pengguna dicabut di transaksi yang sama dengan perubahan status.
+func (s *UserService) UpdateUserStatus(ctx context.Context, actorID, userID string, req *dto.UpdateUserStatusRequestDTO) (*models.User, error) {
+	req.Reason = strings.TrimSpace(req.Reason)
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+	if actorID == userID {
+		return nil, ErrCannotChangeOwnStatus
+	}
+
+	now := time.Now()
+	updated, err := s.userRepo.UpdateUserStatus(ctx, userID, req.Status, actorID, req.Reason, now)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrUserNotFound
+	}
+
+	if req.Status != models.StatusActive {
+		// Token sudah dicabut di database; cache epoch di instance ini diperbarui agar
+		// penolakan langsung berlaku tanpa menunggu sinkronisasi cache
+		s.access.SetUserTokenEpoch(userID, now)
+	}
+
+	log.Printf("SECURITY: Status pengguna %s diubah menjadi %s oleh %s: %s", userID, req.Status, actorID, req.Reason)
+	return s.findUser(ctx, userID)
+}
+
+// UnlockUser membuka kunci akun yang terkunci karena gagal login atau berstatus locked
+func (s *UserService) UnlockUser(ctx context.Context, actorID, userID string, req *dto.UnlockUserRequestDTO) (*models.User, error) {
+	req.Reason = strings.TrimSpace(req.Reason)
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validasi input gagal: %w", err)
+	}
+
+	unlocked, err := s.userRepo.UnlockUser(ctx, userID, actorID, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if !unlocked {
+		return nil, ErrUserNotFound
+	}
+
+	log.Printf("SECURITY: Kunci akun pengguna %s dibuka oleh %s: %s", userID, actorID, req.Reason)
+	return s.findUser(ctx, userID)
+}
+
+// ForcePasswordReset menghapus password pengguna, mencabut semua sesinya dan mengirim
+// tautan reset password ke email pengguna
+func (s *UserService) ForcePasswordReset(ctx context.Context, actorID, userID string) error {
+	if _, err := s.findUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.access.ForcePasswordReset(ctx, userID); err != nil {
+		return err
+	}
+
+	log.Printf("SECURITY: Reset password pengguna %s dipaksa oleh %s", userID, actorID)
+	return nil
+}
+
+// findUser mengambil pengguna dan mengubah hasil kosong menjadi ErrUserNotFound
+func (s *UserService) findUser(ctx context.Context, userID string) (*models.User, error) {
+	user, err := s.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func isManageableStatus(status string) bool {
+	for _, manageable := range models.ManageableStatuses {
+		if status == manageable {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 )
 
 // Response adalah struct standar untuk format respons API
@@ -67,4 +68,46 @@ func SendDetailedError(w http.ResponseWriter, statusCode int, message, errorType
 		},
 	}
 	SendJSON(w, statusCode, response)
-}
\ No newline at end of file
+}
+// PaginationMeta adalah format meta standar untuk respons berhalaman.
+type PaginationMeta struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+// ParsePagination membaca query parameter page dan per_page dengan nilai default yang aman.
+func ParsePagination(r *http.Request) (page, perPage int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err = strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	return page, perPage
+}
+
+// NewPaginationMeta membuat PaginationMeta dari halaman, ukuran halaman, dan total data.
+func NewPaginationMeta(page, perPage, total int) PaginationMeta {
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = (total + perPage - 1) / perPage
+	}
+	return PaginationMeta{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
@@ -12,6 +12,8 @@ import (
 type EmailService interface {
 	SendVerificationEmail(to, token, username string) error
 	SendWelcomeEmail(to, username string) error
+	SendPasswordResetEmail(to, token, username string) error
+	SendMagicLinkEmail(to, token, username string) error
 }
 
 type emailService struct {
@@ -24,8 +26,6 @@ func NewEmailService(cfg *config.Config) *emailService {
 
 // SendVerificationEmail mengirimkan email verifikasi
 func (s *emailService) SendVerificationEmail(to, token, username string) error {
-	var body bytes.Buffer
-
 	// Data yang akan dimasukkan ke template
 	data := EmailData{
 		AppName:         s.cfg.Server.AppName, 
@@ -36,8 +36,42 @@ func (s *emailService) SendVerificationEmail(to, token, username string) error {
 		ExpiresIn:       "30 minutes",
 	}
 
+	return s.sendHTML(to, "Verifikasi Email Anda", "verification_html", data)
+}
+
+// SendPasswordResetEmail mengirimkan tautan untuk mengatur ulang password
+func (s *emailService) SendPasswordResetEmail(to, token, username string) error {
+	data := EmailData{
+		AppName:    s.cfg.Server.AppName,
+		FirstName:  username,
+		ResetURL:   fmt.Sprintf("http://localhost:%s/auth/reset-password?token=%s", s.cfg.Server.ServerPort, token),
+		AppURL:     fmt.Sprintf("http://localhost:%s", s.cfg.Server.ServerPort),
+		SupportURL: "http://localhost/support",
+		ExpiresIn:  "15 minutes",
+	}
+
+	return s.sendHTML(to, "Atur Ulang Password Anda", "password_reset_html", data)
+}
+
+// SendMagicLinkEmail mengirimkan tautan login sekali pakai tanpa password
+func (s *emailService) SendMagicLinkEmail(to, token, username string) error {
+	data := EmailData{
+		AppName:    s.cfg.Server.AppName,
+		FirstName:  username,
+		LoginURL:   fmt.Sprintf("http://localhost:%s/auth/magic-link/verify?token=%s", s.cfg.Server.ServerPort, token),
+		AppURL:     fmt.Sprintf("http://localhost:%s", s.cfg.Server.ServerPort),
+		SupportURL: "http://localhost/support",
+		ExpiresIn:  "15 minutes",
+	}
+
+	return s.sendHTML(to, "Tautan Login Anda", "magic_link_html", data)
+}
+
+// sendHTML merender template HTML dan mengirimkannya melalui SMTP
+func (s *emailService) sendHTML(to, subject, templateName string, data EmailData) error {
+	var body bytes.Buffer
+
 	// Persiapkan pesan email dengan header
-	subject := "Verifikasi Email Anda"
 	headers := map[string]string{
 		"From":         s.cfg.Email.EmailSMTPUsername,
 		"To":           to,
@@ -53,7 +87,7 @@ func (s *emailService) SendVerificationEmail(to, token, username string) error {
 	body.WriteString("\r\n")
 
 	// Eksekusi template HTML
-	err := emailTemplates["verification_html"].Execute(&body, data)
+	err := emailTemplates[templateName].Execute(&body, data)
 	if err != nil {
 		return fmt.Errorf("gagal mengeksekusi template email: %w", err)
 	}
@@ -157,12 +157,174 @@ var emailTemplates = map[string]*template.Template{
 	</body>
 	</html>`)),
 	"welcome_text": template.Must(template.New("welcome_text").Parse(`Welcome to {{.AppName}}! 🎉Hi {{.FirstName}}!Your email has been verified successfully! You can now login to your account and start using {{.AppName}}.Login here: {{.AppURL}}If you have any questions, feel free to contact our support team.Best regards,The {{.AppName}} TeamNeed help? Contact Support: {{.SupportURL}}{{.AppName}} - {{.AppURL}}`)),
+	"password_reset_html": template.Must(template.New("password_reset_html").Parse(`
+	<!DOCTYPE html>
+	<html lang="en">
+	<head>
+		<meta charset="UTF-8">
+		<meta name="viewport" content="width=device-width, initial-scale=1.0">
+		<title>Reset Your Password</title>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				line-height: 1.6;
+				color: #333;
+			}
+
+			.container {
+				max-width: 600px;
+				margin: 0 auto;
+				padding: 20px;
+			}
+
+			.header {
+				background: #dc3545;
+				color: white;
+				padding: 20px;
+				text-align: center;
+				border-radius: 5px 5px 0 0;
+			}
+
+			.content {
+				background: #f9f9f9;
+				padding: 30px;
+				border-radius: 0 0 5px 5px;
+			}
+
+			.button {
+				display: inline-block;
+				background: #007bff;
+				color: white;
+				padding: 12px 24px;
+				text-decoration: none;
+				border-radius: 5px;
+				margin: 20px 0;
+			}
+
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				font-size: 12px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<div class="header">
+				<h1>{{.AppName}}</h1>
+			</div>
+			<div class="content">
+				<h2>Hi {{.FirstName}}!</h2>
+				<p>We received a request to reset the password for your {{.AppName}} account. Click the button below to choose a new password:</p>
+
+				<a href="{{.ResetURL}}" class="button">Reset My Password</a>
+
+				<p>Or copy and paste this link in your browser:</p>
+				<p style="word-break: break-all; background: #eee; padding: 10px; border-radius: 3px;">{{.ResetURL}}
+				</p>
+
+				<p><strong>This link expires in {{.ExpiresIn}} and can only be used once.</strong></p>
+
+				<p>If you didn't request a password reset, please ignore this email. Your password will not change.</p>
+
+				<p>Best regards,<br>The {{.AppName}} Team</p>
+			</div>
+			<div class="footer">
+				<p>Need help? <a href="{{.SupportURL}}">Contact Support</a></p>
+				<p>{{.AppName}} - {{.AppURL}}</p>
+			</div>
+		</div>
+	</body>
+	</html>`)),
+	"magic_link_html": template.Must(template.New("magic_link_html").Parse(`
+	<!DOCTYPE html>
+	<html lang="en">
+	<head>
+		<meta charset="UTF-8">
+		<meta name="viewport" content="width=device-width, initial-scale=1.0">
+		<title>Your Login Link</title>
+		<style>
+			body {
+				font-family: Arial, sans-serif;
+				line-height: 1.6;
+				color: #333;
+			}
+
+			.container {
+				max-width: 600px;
+				margin: 0 auto;
+				padding: 20px;
+			}
+
+			.header {
+				background: #007bff;
+				color: white;
+				padding: 20px;
+				text-align: center;
+				border-radius: 5px 5px 0 0;
+			}
+
+			.content {
+				background: #f9f9f9;
+				padding: 30px;
+				border-radius: 0 0 5px 5px;
+			}
+
+			.button {
+				display: inline-block;
+				background: #007bff;
+				color: white;
+				padding: 12px 24px;
+				text-decoration: none;
+				border-radius: 5px;
+				margin: 20px 0;
+			}
+
+			.footer {
+				text-align: center;
+				margin-top: 20px;
+				font-size: 12px;
+				color: #666;
+			}
+		</style>
+	</head>
+	<body>
+		<div class="container">
+			<div class="header">
+				<h1>{{.AppName}}</h1>
+			</div>
+			<div class="content">
+				<h2>Hi {{.FirstName}}!</h2>
+				<p>We received a request to log in to your {{.AppName}} account without a password. Click the button below to log in:</p>
+
+				<a href="{{.LoginURL}}" class="button">Log Me In</a>
+
+				<p>Or copy and paste this link in your browser:</p>
+				<p style="word-break: break-all; background: #eee; padding: 10px; border-radius: 3px;">{{.LoginURL}}
+				</p>
+
+				<p><strong>This link expires in {{.ExpiresIn}}, can only be used once and only works in the browser where you requested it.</strong></p>
+
+				<p>If you didn't request this link, please ignore this email. Nobody can log in with it without access to your browser.</p>
+
+				<p>Best regards,<br>The {{.AppName}} Team</p>
+			</div>
+			<div class="footer">
+				<p>Need help? <a href="{{.SupportURL}}">Contact Support</a></p>
+				<p>{{.AppName}} - {{.AppURL}}</p>
+			</div>
+		</div>
+	</body>
+	</html>`)),
 }
 
 type EmailData struct {
 	AppName         string
 	FirstName       string
 	VerificationURL string
+	ResetURL        string
+	LoginURL        string
 	AppURL          string
 	SupportURL      string
 	ExpiresIn       string
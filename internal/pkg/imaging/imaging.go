@@ -0,0 +1,96 @@
+// Package imaging menyediakan operasi gambar sederhana (resize dan crop) yang hanya
+// memakai standard library.
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Mode menentukan cara gambar disesuaikan ke ukuran tujuan
+type Mode string
+
+const (
+	// ModeFit memperkecil gambar agar muat di dalam kotak tujuan dengan rasio tetap
+	ModeFit Mode = "fit"
+	// ModeFill memotong bagian tengah gambar lalu mengubah ukurannya tepat ke kotak tujuan
+	ModeFill Mode = "fill"
+)
+
+// Resize menyesuaikan src ke kotak width x height sesuai mode. Pada ModeFit gambar
+// yang sudah lebih kecil dari kotak tujuan tidak diperbesar.
+func Resize(src image.Image, width, height int, mode Mode) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW == 0 || srcH == 0 || width <= 0 || height <= 0 {
+		return src
+	}
+
+	if mode == ModeFill {
+		crop := centerCrop(bounds, width, height)
+		return scale(src, crop, width, height)
+	}
+
+	if srcW <= width && srcH <= height {
+		return src
+	}
+	dstW, dstH := width, srcH*width/srcW
+	if dstH > height {
+		dstW, dstH = srcW*height/srcH, height
+	}
+	return scale(src, bounds, max(dstW, 1), max(dstH, 1))
+}
+
+// centerCrop menghitung area tengah src dengan rasio yang sama seperti width x height
+func centerCrop(bounds image.Rectangle, width, height int) image.Rectangle {
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	cropW, cropH := srcW, srcW*height/width
+	if cropH > srcH {
+		cropW, cropH = srcH*width/height, srcH
+	}
+	x0 := bounds.Min.X + (srcW-cropW)/2
+	y0 := bounds.Min.Y + (srcH-cropH)/2
+	return image.Rect(x0, y0, x0+cropW, y0+cropH)
+}
+
+// scale mengubah ukuran area region dari src menjadi width x height. Saat memperkecil,
+// setiap piksel tujuan adalah rata-rata semua piksel sumber yang tercakup (box filter)
+// sehingga hasilnya tidak bergerigi.
+func scale(src image.Image, region image.Rectangle, width, height int) *image.RGBA {
+	// Salin ke RGBA terlebih dahulu agar pembacaan piksel tidak melalui interface
+	rgba := image.NewRGBA(image.Rect(0, 0, region.Dx(), region.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, region.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcW, srcH := region.Dx(), region.Dy()
+
+	for y := 0; y < height; y++ {
+		sy0 := y * srcH / height
+		sy1 := max((y+1)*srcH/height, sy0+1)
+		for x := 0; x < width; x++ {
+			sx0 := x * srcW / width
+			sx1 := max((x+1)*srcW/width, sx0+1)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				offset := sy*rgba.Stride + sx0*4
+				for sx := sx0; sx < sx1; sx++ {
+					r += uint64(rgba.Pix[offset])
+					g += uint64(rgba.Pix[offset+1])
+					b += uint64(rgba.Pix[offset+2])
+					a += uint64(rgba.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n),
+				G: uint8(g / n),
+				B: uint8(b / n),
+				A: uint8(a / n),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,304 @@
+// Package jwk memuat kunci penandatanganan JWT asimetris (RSA dan Ed25519) dari file PEM
+// dan menerbitkan kunci publiknya dalam format JSON Web Key Set (RFC 7517), serta membaca
+// JWK milik pihak lain untuk verifikasi.
+package jwk
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+const (
+	// AlgRS256 adalah algoritma JWS untuk kunci RSA
+	AlgRS256 = "RS256"
+	// AlgEdDSA adalah algoritma JWS untuk kunci Ed25519
+	AlgEdDSA = "EdDSA"
+	// minRSABits adalah ukuran minimum modulus RSA yang diterima
+	minRSABits = 2048
+)
+
+var b64 = base64.RawURLEncoding
+
+// Key adalah satu kunci JWT beserta kid dan algoritmanya. Private hanya terisi jika
+// kunci dimuat dari private key sehingga dapat dipakai untuk menandatangani.
+type Key struct {
+	ID        string
+	Algorithm string
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+}
+
+// CanSign menunjukkan apakah kunci memiliki private key
+func (k *Key) CanSign() bool {
+	return k.Private != nil
+}
+
+// JSONWebKey adalah representasi publik satu kunci dalam JWKS
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP (Ed25519) dan EC
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet adalah dokumen yang disajikan di /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// LoadFile memuat kunci dari file PEM. File boleh berisi private key (PKCS#8 atau PKCS#1)
+// maupun public key (PKIX atau PKCS#1). kid kosong diganti thumbprint RFC 7638.
+func LoadFile(path, kid string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file kunci %s: %w", path, err)
+	}
+	key, err := Parse(data, kid)
+	if err != nil {
+		return nil, fmt.Errorf("file kunci %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// LoadFiles memuat daftar kunci dari spesifikasi dipisah koma. Setiap entri berupa path
+// atau kid=path; format kedua dipakai agar kid kunci lama tetap sama setelah rotasi.
+func LoadFiles(spec string) ([]*Key, error) {
+	var keys []*Key
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, path := "", entry
+		if before, after, found := strings.Cut(entry, "="); found {
+			kid, path = strings.TrimSpace(before), strings.TrimSpace(after)
+		}
+		key, err := LoadFile(path, kid)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Parse membaca blok PEM pertama dari data
+func Parse(data []byte, kid string) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("blok PEM tidak ditemukan")
+	}
+
+	var (
+		private crypto.Signer
+		public  crypto.PublicKey
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("private key PKCS#8 tidak valid: %w", err)
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("jenis private key tidak didukung: %T", parsed)
+		}
+		private, public = signer, signer.Public()
+	case "RSA PRIVATE KEY":
+		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("private key RSA tidak valid: %w", err)
+		}
+		private, public = parsed, parsed.Public()
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("public key tidak valid: %w", err)
+		}
+		public = parsed
+	case "RSA PUBLIC KEY":
+		parsed, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("public key RSA tidak valid: %w", err)
+		}
+		public = parsed
+	default:
+		return nil, fmt.Errorf("jenis blok PEM tidak didukung: %s", block.Type)
+	}
+
+	key := &Key{ID: kid, Private: private}
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("kunci RSA minimal %d bit", minRSABits)
+		}
+		key.Algorithm, key.Public = AlgRS256, pub
+	case ed25519.PublicKey:
+		key.Algorithm, key.Public = AlgEdDSA, pub
+	default:
+		return nil, fmt.Errorf("jenis kunci tidak didukung: %T (gunakan RSA atau Ed25519)", public)
+	}
+
+	if key.ID == "" {
+		key.ID = Thumbprint(key.Public)
+	}
+	return key, nil
+}
+
+// Thumbprint menghitung thumbprint SHA-256 RFC 7638 dari public key, dipakai sebagai kid bawaan
+func Thumbprint(public crypto.PublicKey) string {
+	// Anggota wajib dalam urutan leksikografis sesuai RFC 7638 bagian 3.2
+	var members any
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{E: encodeExponent(pub.E), Kty: "RSA", N: b64.EncodeToString(pub.N.Bytes())}
+	case ed25519.PublicKey:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{Crv: "Ed25519", Kty: "OKP", X: b64.EncodeToString(pub)}
+	default:
+		return ""
+	}
+
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return b64.EncodeToString(sum[:])
+}
+
+// JSONWebKey mengembalikan representasi publik kunci
+func (k *Key) JSONWebKey() JSONWebKey {
+	jwk := JSONWebKey{KeyID: k.ID, Use: "sig", Algorithm: k.Algorithm}
+	switch pub := k.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = b64.EncodeToString(pub.N.Bytes())
+		jwk.E = encodeExponent(pub.E)
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = b64.EncodeToString(pub)
+	}
+	return jwk
+}
+
+// PublicKey mengubah JWK menjadi public key. Dipakai untuk memverifikasi token yang
+// ditandatangani pihak lain, misalnya ID token dari penyedia OpenID Connect; selain RSA dan
+// Ed25519, kunci EC P-256/P-384 juga diterima.
+func (k JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := b64.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("modulus RSA tidak valid: %w", err)
+		}
+		e, err := b64.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("eksponen RSA tidak valid")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		x, err := b64.DecodeString(k.X)
+		if err != nil || k.Curve != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("kunci Ed25519 tidak valid")
+		}
+		return ed25519.PublicKey(x), nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("kurva EC tidak didukung: %s", k.Curve)
+		}
+		x, errX := b64.DecodeString(k.X)
+		y, errY := b64.DecodeString(k.Y)
+		if errX != nil || errY != nil {
+			return nil, errors.New("koordinat EC tidak valid")
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("titik EC tidak berada pada kurva")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("jenis kunci tidak didukung: %s", k.KeyType)
+	}
+}
+
+func encodeExponent(e int) string {
+	return b64.EncodeToString(big.NewInt(int64(e)).Bytes())
+}
+
+// Set berisi satu kunci penandatanganan aktif dan kunci-kunci verifikasi tambahan yang
+// masih diterima selama masa rotasi. Set tidak diubah setelah dibuat sehingga aman
+// dipakai bersamaan oleh banyak goroutine.
+type Set struct {
+	signing *Key
+	keys    map[string]*Key
+	order   []*Key
+}
+
+// NewSet membuat Set dari kunci penandatanganan dan kunci verifikasi. Kunci penandatanganan
+// wajib memiliki private key; kid harus unik di seluruh set.
+func NewSet(signing *Key, verification ...*Key) (*Set, error) {
+	if signing == nil || !signing.CanSign() {
+		return nil, errors.New("kunci penandatanganan harus berupa private key")
+	}
+
+	set := &Set{signing: signing, keys: map[string]*Key{}}
+	for _, key := range append([]*Key{signing}, verification...) {
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("kid duplikat: %s", key.ID)
+		}
+		set.keys[key.ID] = key
+		set.order = append(set.order, key)
+	}
+	return set, nil
+}
+
+// SigningKey mengembalikan kunci yang dipakai untuk menandatangani token baru
+func (s *Set) SigningKey() *Key {
+	return s.signing
+}
+
+// Find mengembalikan kunci dengan kid tertentu, atau nil jika tidak dikenal
+func (s *Set) Find(kid string) *Key {
+	return s.keys[kid]
+}
+
+// JWKS mengembalikan seluruh kunci publik, diawali kunci penandatanganan aktif
+func (s *Set) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(s.order))}
+	for _, key := range s.order {
+		set.Keys = append(set.Keys, key.JSONWebKey())
+	}
+	return set
+}
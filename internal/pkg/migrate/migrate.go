@@ -0,0 +1,363 @@
+// Package migrate menjalankan migrasi SQL berformat golang-migrate terhadap PostgreSQL.
+// Versi yang terpasang dicatat di tabel schema_migrations (kompatibel dengan golang-migrate)
+// dan setiap perintah dijalankan di bawah advisory lock agar beberapa instance yang
+// dijalankan bersamaan tidak saling balapan.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// advisoryLockID adalah kunci pg_advisory_lock untuk migrasi; nilainya bebas selama konsisten
+const advisoryLockID int64 = 7_245_901_331
+
+var (
+	// ErrNoChange dikembalikan ketika tidak ada migrasi yang perlu dijalankan
+	ErrNoChange = errors.New("tidak ada perubahan, database sudah pada versi tujuan")
+	// ErrUnknownVersion dikembalikan ketika versi tujuan tidak ada di daftar migrasi
+	ErrUnknownVersion = errors.New("versi migrasi tidak dikenal")
+)
+
+// DirtyError dikembalikan ketika migrasi sebelumnya berhenti di tengah jalan. Database perlu
+// diperiksa secara manual lalu ditandai bersih dengan perintah force.
+type DirtyError struct {
+	Version uint
+}
+
+func (e *DirtyError) Error() string {
+	return fmt.Sprintf("database dalam keadaan dirty pada versi %d, perbaiki secara manual lalu jalankan 'migrate force <versi>'", e.Version)
+}
+
+var filenamePattern = regexp.MustCompile(`^(\d+)_([a-zA-Z0-9_\-]+)\.(up|down)\.sql$`)
+
+// Migration adalah satu pasang file migrasi up dan down
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus adalah status satu migrasi terhadap database
+type MigrationStatus struct {
+	Migration
+	Applied bool
+}
+
+// Status adalah ringkasan kondisi migrasi database
+type Status struct {
+	Version    uint
+	Dirty      bool
+	Migrations []MigrationStatus
+}
+
+// Migrator menjalankan migrasi terhadap database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New membaca semua migrasi dari fsys dan membuat instance baru dari Migrator
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load membaca file migrasi dari root fsys dan mengurutkannya berdasarkan versi.
+// Setiap versi wajib memiliki file up dan down.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca direktori migrasi: %w", err)
+	}
+
+	byVersion := map[uint]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := filenamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("versi migrasi tidak valid pada file %s", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca file migrasi %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("versi migrasi %d dipakai oleh lebih dari satu nama", version)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migrasi %d_%s harus memiliki file up dan down", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up menjalankan semua migrasi yang belum terpasang
+func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return ErrNoChange
+	}
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down membatalkan sejumlah steps migrasi terakhir
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		index := m.indexOf(current)
+		if index < 0 || steps <= 0 {
+			return ErrNoChange
+		}
+		targetIndex := index - steps
+		if targetIndex < -1 {
+			targetIndex = -1
+		}
+		return m.migrateDown(ctx, conn, index, targetIndex)
+	})
+}
+
+// To memindahkan database ke versi tertentu, naik atau turun. Versi 0 berarti
+// membatalkan semua migrasi.
+func (m *Migrator) To(ctx context.Context, version uint) error {
+	targetIndex := -1
+	if version != 0 {
+		targetIndex = m.indexOf(version)
+		if targetIndex < 0 {
+			return ErrUnknownVersion
+		}
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		index := m.indexOf(current)
+		if current != 0 && index < 0 {
+			return fmt.Errorf("versi database %d tidak ada di daftar migrasi: %w", current, ErrUnknownVersion)
+		}
+
+		switch {
+		case targetIndex > index:
+			for i := index + 1; i <= targetIndex; i++ {
+				if err := m.apply(ctx, conn, m.migrations[i], m.migrations[i].Up, m.migrations[i].Version, current); err != nil {
+					return err
+				}
+				current = m.migrations[i].Version
+			}
+			return nil
+		case targetIndex < index:
+			return m.migrateDown(ctx, conn, index, targetIndex)
+		default:
+			return ErrNoChange
+		}
+	})
+}
+
+// Force mencatat versi database tanpa menjalankan SQL apa pun dan menghapus tanda dirty.
+// Dipakai setelah memperbaiki migrasi yang gagal secara manual atau untuk menandai database
+// lama yang skemanya dipasang tanpa runner ini.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if version != 0 && m.indexOf(version) < 0 {
+		return ErrUnknownVersion
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return setVersion(ctx, conn, version, false)
+	})
+}
+
+// Status mengembalikan versi database saat ini beserta daftar migrasi dan status terpasangnya
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	status := &Status{}
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		status.Version, status.Dirty, err = readVersion(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migration := range m.migrations {
+		applied := migration.Version <= status.Version
+		// Migrasi pada versi dirty belum tentu terpasang sepenuhnya
+		if status.Dirty && migration.Version == status.Version {
+			applied = false
+		}
+		status.Migrations = append(status.Migrations, MigrationStatus{Migration: migration, Applied: applied})
+	}
+	return status, nil
+}
+
+// migrateDown menjalankan migrasi down dari index hingga (tidak termasuk) targetIndex
+func (m *Migrator) migrateDown(ctx context.Context, conn *sql.Conn, index, targetIndex int) error {
+	for i := index; i > targetIndex; i-- {
+		var previous uint
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+		if err := m.apply(ctx, conn, m.migrations[i], m.migrations[i].Down, previous, m.migrations[i].Version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply menjalankan satu file migrasi. Versi ditandai dirty sebelum SQL dijalankan, lalu
+// SQL dan versi baru disimpan dalam satu transaksi. Jika transaksi gagal, seluruh perubahan
+// di-rollback sehingga versi sebelumnya dapat dipulihkan; jika proses mati di tengah jalan,
+// tanda dirty tetap tersimpan dan harus diselesaikan dengan Force.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, statements string, newVersion, previousVersion uint) error {
+	direction := "up"
+	if newVersion < migration.Version {
+		direction = "down"
+	}
+	log.Printf("Menjalankan migrasi %d_%s (%s)", migration.Version, migration.Name, direction)
+
+	if err := setVersion(ctx, conn, migration.Version, true); err != nil {
+		return err
+	}
+
+	err := func() error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("gagal memulai transaksi: %w", err)
+		}
+		defer tx.Rollback()
+
+		if _, err := tx.ExecContext(ctx, statements); err != nil {
+			return err
+		}
+		if err := setVersion(ctx, tx, newVersion, false); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}()
+	if err != nil {
+		if restoreErr := setVersion(context.Background(), conn, previousVersion, false); restoreErr != nil {
+			log.Printf("Gagal memulihkan versi migrasi %d: %v", previousVersion, restoreErr)
+		}
+		return fmt.Errorf("migrasi %d_%s (%s) gagal: %w", migration.Version, migration.Name, direction, err)
+	}
+	return nil
+}
+
+// withLock menjalankan fn pada satu koneksi yang memegang advisory lock migrasi.
+// Advisory lock bersifat per sesi sehingga lock, query dan unlock harus memakai koneksi yang sama.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil koneksi database: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockID); err != nil {
+		return fmt.Errorf("gagal mengambil advisory lock migrasi: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockID); err != nil {
+			log.Printf("Gagal melepas advisory lock migrasi: %v", err)
+		}
+	}()
+
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("gagal membuat tabel schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+// cleanVersion membaca versi database dan menolak melanjutkan jika database dirty
+func (m *Migrator) cleanVersion(ctx context.Context, conn *sql.Conn) (uint, error) {
+	version, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, &DirtyError{Version: version}
+	}
+	return version, nil
+}
+
+func (m *Migrator) indexOf(version uint) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+type execQueryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// readVersion membaca versi terpasang; tabel kosong berarti versi 0
+func readVersion(ctx context.Context, q execQueryer) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := q.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("gagal membaca versi migrasi: %w", err)
+	}
+	return uint(version), dirty, nil
+}
+
+// setVersion menyimpan versi database. Seperti golang-migrate, tabel hanya berisi satu baris.
+func setVersion(ctx context.Context, q execQueryer, version uint, dirty bool) error {
+	if _, err := q.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return fmt.Errorf("gagal menyimpan versi migrasi: %w", err)
+	}
+	if version == 0 && !dirty {
+		return nil
+	}
+	query := `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`
+	if _, err := q.ExecContext(ctx, query, int64(version), dirty); err != nil {
+		return fmt.Errorf("gagal menyimpan versi migrasi: %w", err)
+	}
+	return nil
+}
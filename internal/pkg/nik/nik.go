@@ -0,0 +1,154 @@
+// Package nik mengurai dan memvalidasi Nomor Induk Kependudukan (NIK) Indonesia.
+//
+// NIK terdiri dari 16 digit dengan format PPKKCC-DDMMYY-SSSS:
+// PP kode provinsi, KK kode kabupaten/kota, CC kode kecamatan, DDMMYY tanggal lahir
+// (tanggal ditambah 40 untuk perempuan) dan SSSS nomor urut.
+package nik
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Length adalah jumlah digit NIK
+const Length = 16
+
+// Gender adalah jenis kelamin yang dikodekan di NIK. Nilainya sama dengan nilai
+// kolom gender di user_profiles.
+type Gender string
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+)
+
+var (
+	// ErrInvalidLength dikembalikan ketika NIK tidak terdiri dari 16 karakter
+	ErrInvalidLength = errors.New("NIK harus terdiri dari 16 digit")
+	// ErrInvalidCharacter dikembalikan ketika NIK mengandung karakter selain angka
+	ErrInvalidCharacter = errors.New("NIK hanya boleh berisi angka")
+	// ErrInvalidRegion dikembalikan ketika kode provinsi, kabupaten/kota atau kecamatan tidak valid
+	ErrInvalidRegion = errors.New("kode wilayah pada NIK tidak valid")
+	// ErrInvalidBirthDate dikembalikan ketika tanggal lahir pada NIK bukan tanggal yang valid
+	ErrInvalidBirthDate = errors.New("tanggal lahir pada NIK tidak valid")
+	// ErrInvalidSerial dikembalikan ketika nomor urut NIK bernilai 0000
+	ErrInvalidSerial = errors.New("nomor urut pada NIK tidak valid")
+)
+
+// provinceCodes adalah kode provinsi Kemendagri yang berlaku
+var provinceCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"21": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "36": true,
+	"51": true, "52": true, "53": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true,
+	"71": true, "72": true, "73": true, "74": true, "75": true, "76": true,
+	"81": true, "82": true,
+	"91": true, "92": true, "93": true, "94": true, "95": true, "96": true,
+}
+
+// NIK adalah hasil penguraian Nomor Induk Kependudukan
+type NIK struct {
+	Number       string
+	ProvinceCode string // 2 digit, contoh "32"
+	RegencyCode  string // 4 digit termasuk kode provinsi, contoh "3273"
+	DistrictCode string // 6 digit termasuk kode kabupaten/kota, contoh "327301"
+	BirthDate    time.Time
+	Gender       Gender
+	Serial       string
+}
+
+// Parse mengurai dan memvalidasi NIK. Tahun dua digit diartikan sebagai tahun terakhir
+// yang tidak melewati hari ini, misalnya "05" menjadi 2005 dan "85" menjadi 1985.
+func Parse(value string) (*NIK, error) {
+	return ParseAt(value, time.Now().UTC())
+}
+
+// ParseAt sama dengan Parse tetapi memakai now sebagai acuan untuk menentukan abad tahun lahir
+func ParseAt(value string, now time.Time) (*NIK, error) {
+	if len(value) != Length {
+		return nil, ErrInvalidLength
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return nil, ErrInvalidCharacter
+		}
+	}
+
+	result := &NIK{
+		Number:       value,
+		ProvinceCode: value[0:2],
+		RegencyCode:  value[0:4],
+		DistrictCode: value[0:6],
+		Serial:       value[12:16],
+		Gender:       GenderMale,
+	}
+	if !provinceCodes[result.ProvinceCode] || value[2:4] == "00" || value[4:6] == "00" {
+		return nil, ErrInvalidRegion
+	}
+	if result.Serial == "0000" {
+		return nil, ErrInvalidSerial
+	}
+
+	day := digits(value[6:8])
+	month := digits(value[8:10])
+	year := digits(value[10:12])
+	if day > 40 {
+		day -= 40
+		result.Gender = GenderFemale
+	}
+
+	birthDate, err := birthDate(day, month, year, now)
+	if err != nil {
+		return nil, err
+	}
+	result.BirthDate = birthDate
+	return result, nil
+}
+
+// Valid melaporkan apakah value adalah NIK yang valid
+func Valid(value string) bool {
+	_, err := Parse(value)
+	return err == nil
+}
+
+// MatchesBirthDate melaporkan apakah tanggal lahir sama dengan yang dikodekan di NIK
+func (n *NIK) MatchesBirthDate(date time.Time) bool {
+	y1, m1, d1 := n.BirthDate.Date()
+	y2, m2, d2 := date.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// String mengembalikan NIK dengan pemisah, contoh 327301-150885-0001
+func (n *NIK) String() string {
+	return fmt.Sprintf("%s-%s-%s", n.Number[0:6], n.Number[6:12], n.Number[12:16])
+}
+
+func birthDate(day, month, year int, now time.Time) (time.Time, error) {
+	if month < 1 || month > 12 || day < 1 {
+		return time.Time{}, ErrInvalidBirthDate
+	}
+
+	// Pilih abad terbaru yang tidak membuat tanggal lahir berada di masa depan. Perbandingan
+	// memakai tanggal lengkap agar lahir di bulan berjalan setelah hari ini masuk abad sebelumnya.
+	fullYear := now.Year()/100*100 + year
+	if time.Date(fullYear, time.Month(month), day, 0, 0, 0, 0, time.UTC).After(now) {
+		fullYear -= 100
+	}
+
+	date := time.Date(fullYear, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	// time.Date menormalkan tanggal yang melewati akhir bulan, misalnya 31 Februari
+	if date.Day() != day || int(date.Month()) != month {
+		return time.Time{}, ErrInvalidBirthDate
+	}
+	return date, nil
+}
+
+func digits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n
+}
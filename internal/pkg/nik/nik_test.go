@@ -0,0 +1,136 @@
+package nik
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseAt(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		value      string
+		wantErr    error
+		wantBirth  time.Time
+		wantGender Gender
+	}{
+		{
+			name:       "laki-laki",
+			value:      "3273011508850001",
+			wantBirth:  time.Date(1985, 8, 15, 0, 0, 0, 0, time.UTC),
+			wantGender: GenderMale,
+		},
+		{
+			name:       "perempuan tanggal ditambah 40",
+			value:      "3273015508850001",
+			wantBirth:  time.Date(1985, 8, 15, 0, 0, 0, 0, time.UTC),
+			wantGender: GenderFemale,
+		},
+		{
+			name:       "perempuan tanggal 31",
+			value:      "3273017101900001",
+			wantBirth:  time.Date(1990, 1, 31, 0, 0, 0, 0, time.UTC),
+			wantGender: GenderFemale,
+		},
+		{name: "tanggal 40 bukan tanggal valid", value: "3273014001900001", wantErr: ErrInvalidBirthDate},
+		{name: "perempuan tanggal 32", value: "3273017201900001", wantErr: ErrInvalidBirthDate},
+		{name: "tanggal 00", value: "3273010001900001", wantErr: ErrInvalidBirthDate},
+		{name: "bulan 13", value: "3273011513900001", wantErr: ErrInvalidBirthDate},
+		{
+			name:       "lahir hari ini masuk abad berjalan",
+			value:      "3273011810260001",
+			wantBirth:  time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			wantGender: GenderMale,
+		},
+		{
+			name:       "lahir akhir bulan berjalan masuk abad sebelumnya",
+			value:      "3273011910260001",
+			wantBirth:  time.Date(1926, 10, 19, 0, 0, 0, 0, time.UTC),
+			wantGender: GenderMale,
+		},
+		{
+			name:       "bulan depan masuk abad sebelumnya",
+			value:      "3273010111260001",
+			wantBirth:  time.Date(1926, 11, 1, 0, 0, 0, 0, time.UTC),
+			wantGender: GenderMale,
+		},
+		{
+			name:       "29 Februari tahun kabisat",
+			value:      "3273012902040001",
+			wantBirth:  time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC),
+			wantGender: GenderMale,
+		},
+		{
+			name:       "29 Februari tahun 2000",
+			value:      "3273016902000001",
+			wantBirth:  time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+			wantGender: GenderFemale,
+		},
+		{name: "29 Februari bukan tahun kabisat", value: "3273012902050001", wantErr: ErrInvalidBirthDate},
+		{name: "31 April", value: "3273013104900001", wantErr: ErrInvalidBirthDate},
+		{name: "kode provinsi tidak dikenal", value: "1073011508850001", wantErr: ErrInvalidRegion},
+		{name: "kode kabupaten 00", value: "3200011508850001", wantErr: ErrInvalidRegion},
+		{name: "kode kecamatan 00", value: "3273001508850001", wantErr: ErrInvalidRegion},
+		{name: "nomor urut 0000", value: "3273011508850000", wantErr: ErrInvalidSerial},
+		{name: "huruf", value: "32730115088500A1", wantErr: ErrInvalidCharacter},
+		{name: "spasi", value: "327301 150885001", wantErr: ErrInvalidCharacter},
+		{name: "tanda minus", value: "-273011508850001", wantErr: ErrInvalidCharacter},
+		{name: "digit non-ASCII", value: "32730115088500١", wantErr: ErrInvalidCharacter},
+		{name: "terlalu pendek", value: "327301150885001", wantErr: ErrInvalidLength},
+		{name: "terlalu panjang", value: "32730115088500011", wantErr: ErrInvalidLength},
+		{name: "kosong", value: "", wantErr: ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAt(tt.value, now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseAt(%q) error = %v, seharusnya %v", tt.value, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAt(%q) error: %v", tt.value, err)
+			}
+			if !got.BirthDate.Equal(tt.wantBirth) {
+				t.Errorf("BirthDate = %v, seharusnya %v", got.BirthDate, tt.wantBirth)
+			}
+			if got.Gender != tt.wantGender {
+				t.Errorf("Gender = %q, seharusnya %q", got.Gender, tt.wantGender)
+			}
+		})
+	}
+}
+
+func TestParseAtLeapDayPreviousCentury(t *testing.T) {
+	// Sebelum tahun 2000, "00" diartikan 1900 yang bukan tahun kabisat
+	now := time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := ParseAt("3273012902000001", now); !errors.Is(err, ErrInvalidBirthDate) {
+		t.Fatalf("error = %v, seharusnya %v", err, ErrInvalidBirthDate)
+	}
+	got, err := ParseAt("3273012902960001", now)
+	if err != nil {
+		t.Fatalf("ParseAt error: %v", err)
+	}
+	if want := time.Date(1996, 2, 29, 0, 0, 0, 0, time.UTC); !got.BirthDate.Equal(want) {
+		t.Errorf("BirthDate = %v, seharusnya %v", got.BirthDate, want)
+	}
+}
+
+func TestParseAtRegionCodes(t *testing.T) {
+	got, err := ParseAt("3273015508850012", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ParseAt error: %v", err)
+	}
+	if got.ProvinceCode != "32" || got.RegencyCode != "3273" || got.DistrictCode != "327301" || got.Serial != "0012" {
+		t.Errorf("kode = %s/%s/%s/%s", got.ProvinceCode, got.RegencyCode, got.DistrictCode, got.Serial)
+	}
+	if got.String() != "327301-550885-0012" {
+		t.Errorf("String() = %q", got.String())
+	}
+	if !got.MatchesBirthDate(time.Date(1985, 8, 15, 23, 0, 0, 0, time.UTC)) {
+		t.Error("MatchesBirthDate seharusnya true untuk tanggal yang sama")
+	}
+}
@@ -0,0 +1,573 @@
+// Package oidc mengimplementasikan sisi klien login OAuth 2.0 authorization code + PKCE
+// (RFC 7636) untuk penyedia OpenID Connect (Google atau penyedia OIDC generik) dan GitHub
+// yang hanya mendukung OAuth 2.0 dengan API pengguna.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/pkg/jwk"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// TypeOIDC adalah penyedia OpenID Connect dengan discovery dan ID token
+	TypeOIDC = "oidc"
+	// TypeGitHub adalah GitHub OAuth App; identitas diambil dari API pengguna GitHub
+	TypeGitHub = "github"
+
+	googleIssuer   = "https://accounts.google.com"
+	githubAuthURL  = "https://github.com/login/oauth/authorize"
+	githubTokenURL = "https://github.com/login/oauth/access_token"
+	githubUserURL  = "https://api.github.com/user"
+
+	// keysRefreshInterval membatasi pengambilan ulang JWKS ketika kid tidak dikenal
+	keysRefreshInterval = time.Minute
+	// idTokenLeeway adalah toleransi selisih jam dengan penyedia
+	idTokenLeeway = time.Minute
+	// maxResponseSize membatasi ukuran respons dari penyedia
+	maxResponseSize = 1 << 20
+)
+
+// ErrInvalidIDToken dikembalikan ketika ID token gagal diverifikasi
+var ErrInvalidIDToken = errors.New("ID token tidak valid")
+
+// idTokenAlgorithms adalah algoritma tanda tangan ID token yang diterima. HMAC sengaja
+// tidak diterima karena secret klien bukan kunci verifikasi.
+var idTokenAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "EdDSA"}
+
+// Config adalah konfigurasi satu penyedia. AuthURL, TokenURL dan UserInfoURL boleh kosong
+// untuk penyedia OIDC (diisi dari discovery) dan GitHub (memakai endpoint github.com).
+type Config struct {
+	Name         string
+	Type         string
+	ClientID     string
+	ClientSecret string
+	Issuer       string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	Scopes       []string
+	RedirectURL  string
+}
+
+// Token adalah respons token endpoint
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+}
+
+// Identity adalah identitas pengguna yang sudah diverifikasi dari penyedia
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Username      string
+}
+
+// Provider adalah satu penyedia identitas yang sudah dikonfigurasi. Dokumen discovery dan
+// JWKS diambil saat pertama kali dibutuhkan lalu disimpan di memori.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu            sync.Mutex
+	discovered    bool
+	jwksURL       string
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// NewProvider membuat Provider dan melengkapi nilai bawaan untuk Google dan GitHub
+func NewProvider(cfg Config, client *http.Client) (*Provider, error) {
+	if cfg.Type == "" {
+		cfg.Type = TypeOIDC
+		if cfg.Name == "github" {
+			cfg.Type = TypeGitHub
+		}
+	}
+
+	switch cfg.Type {
+	case TypeOIDC:
+		if cfg.Issuer == "" && cfg.Name == "google" {
+			cfg.Issuer = googleIssuer
+		}
+		if cfg.Issuer == "" {
+			return nil, fmt.Errorf("penyedia %s: issuer wajib diisi", cfg.Name)
+		}
+		if len(cfg.Scopes) == 0 {
+			cfg.Scopes = []string{"openid", "email", "profile"}
+		} else if !containsString(cfg.Scopes, "openid") {
+			cfg.Scopes = append([]string{"openid"}, cfg.Scopes...)
+		}
+	case TypeGitHub:
+		if cfg.AuthURL == "" {
+			cfg.AuthURL = githubAuthURL
+		}
+		if cfg.TokenURL == "" {
+			cfg.TokenURL = githubTokenURL
+		}
+		if cfg.UserInfoURL == "" {
+			cfg.UserInfoURL = githubUserURL
+		}
+		if len(cfg.Scopes) == 0 {
+			cfg.Scopes = []string{"read:user", "user:email"}
+		}
+	default:
+		return nil, fmt.Errorf("penyedia %s: jenis tidak didukung: %s", cfg.Name, cfg.Type)
+	}
+
+	if cfg.Name == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, fmt.Errorf("penyedia %s: nama, client ID dan redirect URL wajib diisi", cfg.Name)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Provider{cfg: cfg, client: client}, nil
+}
+
+// Name mengembalikan nama penyedia seperti yang dipakai di URL
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// Type mengembalikan jenis penyedia (TypeOIDC atau TypeGitHub)
+func (p *Provider) Type() string {
+	return p.cfg.Type
+}
+
+// AuthCodeURL membuat URL otorisasi dengan state, nonce dan code challenge PKCE S256.
+// nonce diabaikan untuk penyedia non-OIDC.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	if p.cfg.Type == TypeOIDC {
+		params.Set("nonce", nonce)
+	}
+
+	separator := "?"
+	if strings.Contains(p.cfg.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.cfg.AuthURL + separator + params.Encode(), nil
+}
+
+// Exchange menukar authorization code dengan token menggunakan code verifier PKCE
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	// GitHub hanya menerima kredensial klien di body; penyedia OIDC wajib mendukung
+	// client_secret_basic (RFC 6749 bagian 2.3.1)
+	if p.cfg.Type == TypeGitHub {
+		form.Set("client_id", p.cfg.ClientID)
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.Type != TypeGitHub {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	var body struct {
+		Token
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.do(req, &body)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menukar authorization code: %w", err)
+	}
+	if body.Error != "" || status != http.StatusOK || body.AccessToken == "" {
+		return nil, fmt.Errorf("penyedia %s menolak authorization code: status %d %s %s", p.cfg.Name, status, body.Error, body.ErrorDescription)
+	}
+	return &body.Token, nil
+}
+
+// Identity mengambil identitas pengguna dari token. Untuk penyedia OIDC, ID token
+// diverifikasi (tanda tangan, issuer, audience, masa berlaku dan nonce).
+func (p *Provider) Identity(ctx context.Context, token *Token, nonce string) (*Identity, error) {
+	if p.cfg.Type == TypeGitHub {
+		return p.githubIdentity(ctx, token.AccessToken)
+	}
+
+	claims, err := p.verifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+	identity := &Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+		Username:      claims.PreferredUsername,
+	}
+
+	// Sebagian penyedia hanya mengirim email lewat userinfo endpoint
+	if identity.Email == "" && p.cfg.UserInfoURL != "" {
+		var info userInfo
+		if err := p.getJSON(ctx, p.cfg.UserInfoURL, token.AccessToken, &info); err != nil {
+			return nil, fmt.Errorf("gagal mengambil userinfo: %w", err)
+		}
+		if info.Subject != identity.Subject {
+			return nil, errors.New("sub userinfo tidak cocok dengan ID token")
+		}
+		identity.Email, identity.EmailVerified = info.Email, bool(info.EmailVerified)
+		if identity.Name == "" {
+			identity.Name = info.Name
+		}
+		if identity.Username == "" {
+			identity.Username = info.PreferredUsername
+		}
+	}
+	return identity, nil
+}
+
+// userInfo adalah klaim identitas yang dipakai, baik dari ID token maupun userinfo endpoint
+type userInfo struct {
+	Subject           string       `json:"sub"`
+	Email             string       `json:"email"`
+	EmailVerified     flexibleBool `json:"email_verified"`
+	Name              string       `json:"name"`
+	PreferredUsername string       `json:"preferred_username"`
+}
+
+type idTokenClaims struct {
+	Nonce             string       `json:"nonce"`
+	AuthorizedParty   string       `json:"azp"`
+	Email             string       `json:"email"`
+	EmailVerified     flexibleBool `json:"email_verified"`
+	Name              string       `json:"name"`
+	PreferredUsername string       `json:"preferred_username"`
+	jwt.RegisteredClaims
+}
+
+func (p *Provider) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (*idTokenClaims, error) {
+	if rawIDToken == "" {
+		return nil, fmt.Errorf("%w: respons token tidak berisi id_token", ErrInvalidIDToken)
+	}
+
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.key(ctx, kid)
+		},
+		jwt.WithValidMethods(idTokenAlgorithms),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(idTokenLeeway),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	// Nonce mengikat ID token ke permintaan login ini sehingga token lama tidak dapat diputar ulang
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce tidak cocok", ErrInvalidIDToken)
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.cfg.ClientID {
+		return nil, fmt.Errorf("%w: azp tidak cocok", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: sub kosong", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// key mengembalikan kunci verifikasi ID token berdasarkan kid. JWKS diambil ulang jika kid
+// tidak dikenal, paling sering sekali per keysRefreshInterval, agar rotasi kunci penyedia
+// tetap terbaca tanpa membanjiri penyedia.
+func (p *Provider) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := p.findKeyLocked(kid); key != nil {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < keysRefreshInterval {
+		return nil, fmt.Errorf("kid tidak dikenal: %q", kid)
+	}
+
+	var set jwk.JSONWebKeySet
+	if err := p.getJSON(ctx, p.jwksURL, "", &set); err != nil {
+		return nil, fmt.Errorf("gagal mengambil JWKS penyedia: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, item := range set.Keys {
+		if item.Use != "" && item.Use != "sig" {
+			continue
+		}
+		if public, err := item.PublicKey(); err == nil {
+			keys[item.KeyID] = public
+		}
+	}
+	p.keys, p.keysFetchedAt = keys, time.Now()
+
+	if key := p.findKeyLocked(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("kid tidak dikenal: %q", kid)
+}
+
+// findKeyLocked mencari kunci berdasarkan kid. Token tanpa kid hanya diterima jika
+// penyedia memiliki tepat satu kunci.
+func (p *Provider) findKeyLocked(kid string) crypto.PublicKey {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return p.keys[kid]
+}
+
+// discover mengambil dokumen discovery OIDC sekali lalu melengkapi endpoint yang belum
+// dikonfigurasi. Kegagalan tidak disimpan sehingga dicoba lagi pada permintaan berikutnya.
+func (p *Provider) discover(ctx context.Context) error {
+	if p.cfg.Type != TypeOIDC {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovered {
+		return nil
+	}
+
+	var doc struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserInfoEndpoint      string `json:"userinfo_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+	discoveryURL := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, discoveryURL, "", &doc); err != nil {
+		return fmt.Errorf("gagal mengambil discovery penyedia %s: %w", p.cfg.Name, err)
+	}
+	if doc.Issuer != p.cfg.Issuer {
+		return fmt.Errorf("issuer discovery %q tidak cocok dengan konfigurasi %q", doc.Issuer, p.cfg.Issuer)
+	}
+	if doc.JWKSURI == "" || doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" {
+		return fmt.Errorf("dokumen discovery penyedia %s tidak lengkap", p.cfg.Name)
+	}
+
+	if p.cfg.AuthURL == "" {
+		p.cfg.AuthURL = doc.AuthorizationEndpoint
+	}
+	if p.cfg.TokenURL == "" {
+		p.cfg.TokenURL = doc.TokenEndpoint
+	}
+	if p.cfg.UserInfoURL == "" {
+		p.cfg.UserInfoURL = doc.UserInfoEndpoint
+	}
+	p.jwksURL = doc.JWKSURI
+	p.discovered = true
+	return nil
+}
+
+// githubIdentity mengambil profil dan email utama yang sudah diverifikasi dari API GitHub
+func (p *Provider) githubIdentity(ctx context.Context, accessToken string) (*Identity, error) {
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := p.getJSON(ctx, p.cfg.UserInfoURL, accessToken, &user); err != nil {
+		return nil, fmt.Errorf("gagal mengambil profil GitHub: %w", err)
+	}
+	if user.ID == 0 {
+		return nil, errors.New("profil GitHub tidak berisi id")
+	}
+
+	// Email di profil bisa kosong atau belum diverifikasi, jadi yang dipakai adalah
+	// email utama terverifikasi dari daftar email pengguna
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.cfg.UserInfoURL, "/")+"/emails", accessToken, &emails); err != nil {
+		return nil, fmt.Errorf("gagal mengambil email GitHub: %w", err)
+	}
+
+	identity := &Identity{
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Name:     user.Name,
+		Username: user.Login,
+	}
+	for _, email := range emails {
+		if email.Primary && email.Verified {
+			identity.Email, identity.EmailVerified = email.Email, true
+			break
+		}
+	}
+	return identity, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, endpoint, bearer string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	status, err := p.do(req, out)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("status %d dari %s", status, endpoint)
+	}
+	return nil
+}
+
+func (p *Provider) do(req *http.Request, out interface{}) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(out); err != nil && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("respons tidak valid dari %s: %w", req.URL.Host, err)
+	}
+	return resp.StatusCode, nil
+}
+
+// flexibleBool menerima boolean JSON maupun string "true"/"false" karena sebagian
+// penyedia mengirim email_verified sebagai string
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case bool:
+		*b = flexibleBool(v)
+	case string:
+		*b = flexibleBool(strings.EqualFold(v, "true"))
+	default:
+		*b = false
+	}
+	return nil
+}
+
+// Registry berisi penyedia yang dikonfigurasi, diakses berdasarkan nama
+type Registry struct {
+	providers map[string]*Provider
+}
+
+// NewRegistry membuat Registry dari daftar konfigurasi penyedia
+func NewRegistry(configs []Config, client *http.Client) (*Registry, error) {
+	registry := &Registry{providers: map[string]*Provider{}}
+	for _, cfg := range configs {
+		if _, exists := registry.providers[cfg.Name]; exists {
+			return nil, fmt.Errorf("penyedia duplikat: %s", cfg.Name)
+		}
+		provider, err := NewProvider(cfg, client)
+		if err != nil {
+			return nil, err
+		}
+		registry.providers[cfg.Name] = provider
+	}
+	return registry, nil
+}
+
+// Provider mengembalikan penyedia berdasarkan nama, atau nil jika tidak dikonfigurasi
+func (r *Registry) Provider(name string) *Provider {
+	if r == nil {
+		return nil
+	}
+	return r.providers[name]
+}
+
+// Names mengembalikan nama semua penyedia secara berurutan
+func (r *Registry) Names() []string {
+	if r == nil {
+		return []string{}
+	}
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RandomString membuat nilai acak 256 bit berformat base64url, dipakai untuk state,
+// nonce dan code verifier PKCE
+func RandomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("gagal membuat nilai acak: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// CodeChallenge menghitung code challenge PKCE S256 dari code verifier
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func containsString(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
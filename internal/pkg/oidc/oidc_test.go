@@ -0,0 +1,336 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/internal/pkg/jwk"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID     = "cms-client"
+	testClientSecret = "rahasia"
+	testRedirectURL  = "https://cms.example/auth/oauth/stub/callback"
+	testCode         = "code-123"
+	testKeyID        = "stub-key"
+)
+
+// stubIdP adalah penyedia OpenID Connect palsu berbasis httptest. idToken dipanggil saat
+// token endpoint menerima code yang benar sehingga setiap test dapat mengatur klaimnya.
+type stubIdP struct {
+	server       *httptest.Server
+	key          ed25519.PrivateKey
+	codeVerifier string
+	idToken      func(issuer string) jwt.MapClaims
+	discoveryHit atomic.Int32
+	jwksHit      atomic.Int32
+	tokenHit     atomic.Int32
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &stubIdP{key: private, codeVerifier: "verifier-abc"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		idp.discoveryHit.Add(1)
+		writeJSON(w, http.StatusOK, map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"userinfo_endpoint":      idp.server.URL + "/userinfo",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.jwksHit.Add(1)
+		key := &jwk.Key{ID: testKeyID, Algorithm: jwk.AlgEdDSA, Public: private.Public()}
+		writeJSON(w, http.StatusOK, jwk.JSONWebKeySet{Keys: []jwk.JSONWebKey{key.JSONWebKey()}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		idp.tokenHit.Add(1)
+		clientID, secret, _ := r.BasicAuth()
+		if err := r.ParseForm(); err != nil ||
+			clientID != testClientID || secret != testClientSecret ||
+			r.PostForm.Get("grant_type") != "authorization_code" ||
+			r.PostForm.Get("redirect_uri") != testRedirectURL {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+			return
+		}
+		if r.PostForm.Get("code") != testCode || r.PostForm.Get("code_verifier") != idp.codeVerifier {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{
+			"access_token": "access-xyz",
+			"token_type":   "Bearer",
+			"id_token":     idp.sign(t, idp.idToken(idp.server.URL)),
+		})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *stubIdP) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(idp.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func (idp *stubIdP) provider(t *testing.T) *Provider {
+	t.Helper()
+	provider, err := NewProvider(Config{
+		Name:         "stub",
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		Issuer:       idp.server.URL,
+		RedirectURL:  testRedirectURL,
+	}, idp.server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func validClaims(issuer, nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            issuer,
+		"sub":            "subject-1",
+		"aud":            testClientID,
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          nonce,
+		"email":          "budi@example.com",
+		"email_verified": "true",
+		"name":           "Budi Santoso",
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestAuthCodeURLUsesDiscoveredEndpoint(t *testing.T) {
+	idp := newStubIdP(t)
+	provider := idp.provider(t)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", "nonce-1", CodeChallenge(idp.codeVerifier))
+	if err != nil {
+		t.Fatalf("AuthCodeURL error: %v", err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed.Scheme + "://" + parsed.Host + parsed.Path; got != idp.server.URL+"/authorize" {
+		t.Errorf("endpoint otorisasi = %s, seharusnya dari discovery", got)
+	}
+
+	query := parsed.Query()
+	expected := map[string]string{
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"redirect_uri":          testRedirectURL,
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        CodeChallenge(idp.codeVerifier),
+		"code_challenge_method": "S256",
+	}
+	for key, value := range expected {
+		if query.Get(key) != value {
+			t.Errorf("parameter %s = %q, seharusnya %q", key, query.Get(key), value)
+		}
+	}
+	if !strings.Contains(query.Get("scope"), "openid") {
+		t.Errorf("scope %q seharusnya berisi openid", query.Get("scope"))
+	}
+
+	// Discovery hanya diambil sekali
+	if _, err := provider.AuthCodeURL(context.Background(), "state-2", "nonce-2", "challenge"); err != nil {
+		t.Fatal(err)
+	}
+	if hits := idp.discoveryHit.Load(); hits != 1 {
+		t.Errorf("discovery diambil %d kali, seharusnya 1", hits)
+	}
+}
+
+func TestDiscoveryRejectsIssuerMismatch(t *testing.T) {
+	idp := newStubIdP(t)
+	provider, err := NewProvider(Config{
+		Name:        "stub",
+		ClientID:    testClientID,
+		Issuer:      idp.server.URL + "/",
+		RedirectURL: testRedirectURL,
+	}, idp.server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge"); err == nil {
+		t.Fatal("issuer discovery yang berbeda dari konfigurasi seharusnya ditolak")
+	}
+}
+
+func TestExchangeAndIdentity(t *testing.T) {
+	idp := newStubIdP(t)
+	idp.idToken = func(issuer string) jwt.MapClaims { return validClaims(issuer, "nonce-1") }
+	provider := idp.provider(t)
+
+	token, err := provider.Exchange(context.Background(), testCode, idp.codeVerifier)
+	if err != nil {
+		t.Fatalf("Exchange error: %v", err)
+	}
+	identity, err := provider.Identity(context.Background(), token, "nonce-1")
+	if err != nil {
+		t.Fatalf("Identity error: %v", err)
+	}
+	if identity.Subject != "subject-1" || identity.Email != "budi@example.com" || !identity.EmailVerified || identity.Name != "Budi Santoso" {
+		t.Errorf("identitas tidak sesuai: %+v", identity)
+	}
+	if hits := idp.jwksHit.Load(); hits != 1 {
+		t.Errorf("JWKS diambil %d kali, seharusnya 1", hits)
+	}
+}
+
+func TestExchangeRejectsWrongCodeVerifier(t *testing.T) {
+	idp := newStubIdP(t)
+	idp.idToken = func(issuer string) jwt.MapClaims { return validClaims(issuer, "nonce-1") }
+	provider := idp.provider(t)
+
+	if _, err := provider.Exchange(context.Background(), testCode, "verifier-lain"); err == nil {
+		t.Fatal("code verifier yang salah seharusnya ditolak")
+	}
+	if _, err := provider.Exchange(context.Background(), "code-lain", idp.codeVerifier); err == nil {
+		t.Fatal("authorization code yang salah seharusnya ditolak")
+	}
+}
+
+func TestIdentityRejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims func(issuer string) jwt.MapClaims
+		nonce  string
+	}{
+		{
+			name:   "nonce berbeda",
+			claims: func(issuer string) jwt.MapClaims { return validClaims(issuer, "nonce-penyerang") },
+			nonce:  "nonce-1",
+		},
+		{
+			name:   "nonce login kosong",
+			claims: func(issuer string) jwt.MapClaims { return validClaims(issuer, "") },
+			nonce:  "",
+		},
+		{
+			name:   "issuer berbeda",
+			claims: func(issuer string) jwt.MapClaims { return validClaims("https://idp-lain.example", "nonce-1") },
+			nonce:  "nonce-1",
+		},
+		{
+			name: "audience berbeda",
+			claims: func(issuer string) jwt.MapClaims {
+				claims := validClaims(issuer, "nonce-1")
+				claims["aud"] = "klien-lain"
+				return claims
+			},
+			nonce: "nonce-1",
+		},
+		{
+			name: "azp berbeda pada multi audience",
+			claims: func(issuer string) jwt.MapClaims {
+				claims := validClaims(issuer, "nonce-1")
+				claims["aud"] = []string{testClientID, "klien-lain"}
+				claims["azp"] = "klien-lain"
+				return claims
+			},
+			nonce: "nonce-1",
+		},
+		{
+			name: "kedaluwarsa",
+			claims: func(issuer string) jwt.MapClaims {
+				claims := validClaims(issuer, "nonce-1")
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return claims
+			},
+			nonce: "nonce-1",
+		},
+		{
+			name: "tanpa sub",
+			claims: func(issuer string) jwt.MapClaims {
+				claims := validClaims(issuer, "nonce-1")
+				delete(claims, "sub")
+				return claims
+			},
+			nonce: "nonce-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newStubIdP(t)
+			idp.idToken = tt.claims
+			provider := idp.provider(t)
+
+			token, err := provider.Exchange(context.Background(), testCode, idp.codeVerifier)
+			if err != nil {
+				t.Fatalf("Exchange error: %v", err)
+			}
+			if _, err := provider.Identity(context.Background(), token, tt.nonce); !errors.Is(err, ErrInvalidIDToken) {
+				t.Fatalf("err = %v, seharusnya ErrInvalidIDToken", err)
+			}
+		})
+	}
+}
+
+func TestIdentityRejectsForeignSignature(t *testing.T) {
+	idp := newStubIdP(t)
+	provider := idp.provider(t)
+
+	// Token ditandatangani kunci lain dengan kid yang sama
+	_, foreign, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, validClaims(idp.server.URL, "nonce-1"))
+	token.Header["kid"] = testKeyID
+	forged, err := token.SignedString(foreign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.Identity(context.Background(), &Token{IDToken: forged}, "nonce-1"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Fatalf("err = %v, seharusnya ErrInvalidIDToken", err)
+	}
+
+	// HS256 dengan client secret bukan tanda tangan yang sah untuk ID token
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims(idp.server.URL, "nonce-1"))
+	signed, err := hmacToken.SignedString([]byte(testClientSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.Identity(context.Background(), &Token{IDToken: signed}, "nonce-1"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Fatalf("err = %v, seharusnya ErrInvalidIDToken", err)
+	}
+}
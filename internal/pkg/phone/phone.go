@@ -0,0 +1,72 @@
+// Package phone menormalkan nomor telepon ke format E.164 dengan asumsi nomor Indonesia
+// ketika kode negara tidak ditulis.
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultCountryCode adalah kode negara yang dipakai untuk nomor tanpa kode negara
+const DefaultCountryCode = "62"
+
+// ErrInvalidPhone dikembalikan ketika nomor telepon tidak dapat dinormalkan
+var ErrInvalidPhone = errors.New("nomor telepon tidak valid")
+
+// Normalize mengubah nomor telepon menjadi format E.164, contoh "0812-3456-7890",
+// "62 812 3456 7890" dan "+6281234567890" semuanya menjadi "+6281234567890".
+// Spasi, tanda hubung, titik dan tanda kurung diabaikan.
+func Normalize(raw string) (string, error) {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(raw) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+	number := b.String()
+
+	var digits string
+	switch {
+	case strings.HasPrefix(number, "+"):
+		digits = number[1:]
+	case strings.HasPrefix(number, "00"):
+		// Awalan panggilan internasional, contoh 006281234567890
+		digits = number[2:]
+	case strings.HasPrefix(number, "0"):
+		digits = DefaultCountryCode + number[1:]
+	case strings.HasPrefix(number, DefaultCountryCode):
+		digits = number
+	case strings.HasPrefix(number, "8"):
+		// Nomor seluler Indonesia yang ditulis tanpa awalan 0
+		digits = DefaultCountryCode + number
+	default:
+		return "", ErrInvalidPhone
+	}
+
+	// E.164 membatasi nomor hingga 15 digit dan kode negara tidak diawali 0
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+	if strings.HasPrefix(digits, DefaultCountryCode) {
+		national := digits[len(DefaultCountryCode):]
+		// Nomor nasional Indonesia terdiri dari 8-12 digit dan tidak diawali 0
+		if len(national) < 8 || len(national) > 12 || national[0] == '0' {
+			return "", ErrInvalidPhone
+		}
+	}
+	return "+" + digits, nil
+}
+
+// Mask menyembunyikan bagian tengah nomor, contoh "+6281234567890" menjadi "+62812*****890"
+func Mask(number string) string {
+	if len(number) <= 8 {
+		return number
+	}
+	return number[:6] + strings.Repeat("*", len(number)-9) + number[len(number)-3:]
+}
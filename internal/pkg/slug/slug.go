@@ -0,0 +1,32 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Make mengubah teks bebas menjadi slug URL, contoh "Berita Terkini!" menjadi "berita-terkini".
+// Huruf beraksen diubah ke huruf dasar dan karakter selain huruf/angka diganti tanda hubung.
+func Make(text string) string {
+	var b strings.Builder
+	lastHyphen := true
+
+	for _, r := range norm.NFD.String(strings.ToLower(text)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Lewati tanda diakritik hasil dekomposisi NFD
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			lastHyphen = false
+		default:
+			if !lastHyphen {
+				b.WriteByte('-')
+				lastHyphen = true
+			}
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
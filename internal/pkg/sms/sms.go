@@ -0,0 +1,110 @@
+// Package sms menyediakan pengirim SMS yang dapat diganti. Driver log dan file hanya untuk
+// pengembangan lokal: log menulis pesan ke log aplikasi dan file menambahkannya ke sebuah file,
+// sehingga keduanya ditolak di production. Driver disabled menolak setiap pengiriman.
+// Integrasi penyedia SMS produksi cukup mengimplementasikan SMSSender.
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/cms-go/config"
+	"github.com/jokosaputro95/cms-go/internal/pkg/phone"
+)
+
+// ErrSMSDisabled dikembalikan oleh DisabledSender untuk setiap pengiriman
+var ErrSMSDisabled = errors.New("pengiriman SMS tidak diaktifkan")
+
+// SMSSender mengirim pesan SMS ke nomor berformat E.164
+type SMSSender interface {
+	Send(ctx context.Context, to, message string) error
+}
+
+// NewSMSSender membuat SMSSender sesuai SMS_DRIVER. Di production driver wajib diisi dan
+// driver pengembangan (log, file) ditolak agar kode OTP tidak tertulis ke log atau disk.
+func NewSMSSender(cfg *config.Config, isProd bool) (SMSSender, error) {
+	driver := cfg.SMS.SMSDriver
+	if isProd && (driver == "" || driver == "log" || driver == "file") {
+		return nil, fmt.Errorf("SMS_DRIVER %q is for development only; configure an SMS provider or set SMS_DRIVER=disabled", driver)
+	}
+
+	switch driver {
+	case "", "log":
+		return NewLogSender(), nil
+	case "file":
+		return NewFileSender(cfg.SMS.SMSFilePath)
+	case "disabled":
+		return DisabledSender{}, nil
+	default:
+		return nil, fmt.Errorf("driver SMS tidak dikenal: %s", cfg.SMS.SMSDriver)
+	}
+}
+
+// otpPattern mencocokkan deretan angka seperti kode OTP di dalam pesan
+var otpPattern = regexp.MustCompile(`\d{4,}`)
+
+// LogSender menulis SMS ke log aplikasi dengan nomor dan kode di dalam pesan disamarkan
+type LogSender struct{}
+
+// NewLogSender membuat instance baru dari LogSender
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+// Send menulis pesan ke log. Kode di dalam pesan disamarkan karena log aplikasi dapat dibaca
+// lebih banyak orang daripada pemilik nomor.
+func (s *LogSender) Send(ctx context.Context, to, message string) error {
+	masked := otpPattern.ReplaceAllStringFunc(message, func(code string) string {
+		return strings.Repeat("*", len(code))
+	})
+	log.Printf("SMS to %s: %s", phone.Mask(to), masked)
+	return nil
+}
+
+// DisabledSender menolak setiap pengiriman SMS, dipakai jika belum ada penyedia SMS
+type DisabledSender struct{}
+
+// Send selalu mengembalikan ErrSMSDisabled
+func (DisabledSender) Send(ctx context.Context, to, message string) error {
+	return ErrSMSDisabled
+}
+
+// FileSender menambahkan setiap SMS sebagai satu baris ke sebuah file
+type FileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSender membuat instance baru dari FileSender dan memastikan direktorinya ada
+func NewFileSender(path string) (*FileSender, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("gagal membuat direktori file SMS: %w", err)
+	}
+	return &FileSender{path: path}, nil
+}
+
+// Send menambahkan pesan ke file
+func (s *FileSender) Send(ctx context.Context, to, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("gagal membuka file SMS: %w", err)
+	}
+	defer file.Close()
+
+	line := fmt.Sprintf("%s\t%s\t%s\n", time.Now().UTC().Format(time.RFC3339), to, message)
+	if _, err := file.WriteString(line); err != nil {
+		return fmt.Errorf("gagal menulis file SMS: %w", err)
+	}
+	return nil
+}
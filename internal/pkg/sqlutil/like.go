@@ -0,0 +1,14 @@
+// Package sqlutil berisi bantuan kecil untuk menyusun query SQL
+package sqlutil
+
+import "strings"
+
+// likeEscaper meloloskan backslash dan karakter wildcard LIKE sehingga "%" dan "_" dicocokkan
+// apa adanya
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ContainsPattern membuat pola LIKE/ILIKE yang mencocokkan text di posisi mana pun. Pola harus
+// dipakai bersama ESCAPE '\' agar karakter yang diloloskan tidak dianggap wildcard.
+func ContainsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage menyimpan object sebagai file di bawah direktori root
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage membuat instance baru dari LocalStorage dan memastikan direktori root ada
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca direktori storage: %w", err)
+	}
+	if err := os.MkdirAll(absRoot, 0o755); err != nil {
+		return nil, fmt.Errorf("gagal membuat direktori storage: %w", err)
+	}
+	return &LocalStorage{root: absRoot}, nil
+}
+
+// Put menulis object ke file. File ditulis ke file sementara lalu di-rename agar
+// pembaca tidak pernah melihat file yang setengah jadi.
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	fullPath, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return fmt.Errorf("gagal membuat direktori object: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("gagal membuat file sementara: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("gagal menulis object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("gagal menutup object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		return fmt.Errorf("gagal menyimpan object: %w", err)
+	}
+	return nil
+}
+
+// Open membuka object untuk dibaca
+func (s *LocalStorage) Open(ctx context.Context, key string) (Object, *ObjectInfo, error) {
+	fullPath, err := s.resolve(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, ErrObjectNotFound
+		}
+		return nil, nil, fmt.Errorf("gagal membuka object: %w", err)
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("gagal membaca info object: %w", err)
+	}
+	return file, &ObjectInfo{Key: key, Size: stat.Size(), ModTime: stat.ModTime()}, nil
+}
+
+// Delete menghapus object. Menghapus object yang tidak ada tidak dianggap error.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	fullPath, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("gagal menghapus object: %w", err)
+	}
+	return nil
+}
+
+// resolve mengubah key menjadi path file dan menolak key yang keluar dari direktori root
+func (s *LocalStorage) resolve(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned == "/" || strings.Contains(key, "\\") || cleaned != "/"+key {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
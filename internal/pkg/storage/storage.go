@@ -0,0 +1,37 @@
+// Package storage menyediakan abstraksi penyimpanan file. Implementasi pertama memakai
+// filesystem lokal; implementasi lain (misalnya S3-compatible) cukup memenuhi interface Storage.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrObjectNotFound dikembalikan ketika object dengan key tersebut tidak ada
+var ErrObjectNotFound = errors.New("object tidak ditemukan")
+
+// ErrInvalidKey dikembalikan ketika key mengandung path yang tidak diizinkan
+var ErrInvalidKey = errors.New("key object tidak valid")
+
+// Object adalah isi file yang dibaca dari storage. Object mendukung Seek agar
+// dapat dilayani dengan http.ServeContent (termasuk Range request).
+type Object interface {
+	io.ReadSeekCloser
+}
+
+// ObjectInfo berisi metadata object yang tersimpan
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Storage mendefinisikan kontrak penyimpanan file berbasis key.
+// Key memakai pemisah "/" tanpa awalan "/", contoh "2025/01/abc/original.jpg".
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Open(ctx context.Context, key string) (Object, *ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+}
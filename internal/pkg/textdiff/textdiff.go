@@ -0,0 +1,136 @@
+package textdiff
+
+import "strings"
+
+// Jenis operasi pada hasil diff
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// maxEdits membatasi jumlah edit yang dicari algoritma Myers. Jika dua teks berbeda
+// lebih jauh dari batas ini, diff dianggap sebagai penggantian seluruh teks.
+const maxEdits = 2000
+
+// Line adalah satu baris hasil diff
+type Line struct {
+	Op      string `json:"op"`
+	Text    string `json:"text"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+}
+
+// Lines menghitung diff per baris antara teks lama dan teks baru menggunakan algoritma Myers
+func Lines(oldText, newText string) []Line {
+	return diff(splitLines(oldText), splitLines(newText))
+}
+
+// HasChanges memeriksa apakah hasil diff mengandung penambahan atau penghapusan
+func HasChanges(lines []Line) bool {
+	for _, line := range lines {
+		if line.Op != OpEqual {
+			return true
+		}
+	}
+	return false
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
+
+func diff(a, b []string) []Line {
+	n, m := len(a), len(b)
+	// offset menyisakan satu diagonal di kedua sisi agar v[offset+k±1] selalu valid
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+	for d := 0; d <= n+m; d++ {
+		if d > maxEdits {
+			return replaceAll(a, b)
+		}
+		// Hanya diagonal -d-1..d+1 yang dibaca saat backtrack pada langkah d, sehingga
+		// memori jejak tumbuh O(D²) alih-alih O(D·(N+M))
+		snapshot := make([]int, 2*d+3)
+		copy(snapshot, v[offset-d-1:offset+d+2])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+	return replaceAll(a, b)
+}
+
+// backtrack menelusuri jejak algoritma Myers dari akhir ke awal untuk menyusun hasil diff
+// Jejak langkah d menyimpan diagonal -d-1..d+1 sehingga diagonal k berada di indeks k+d+1.
+func backtrack(trace [][]int, a, b []string) []Line {
+	x, y := len(a), len(b)
+	var reversed []Line
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		base := d + 1
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[base+k-1] < v[base+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[base+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, Line{Op: OpEqual, Text: a[x-1], OldLine: x, NewLine: y})
+			x--
+			y--
+		}
+
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, Line{Op: OpInsert, Text: b[y-1], NewLine: y})
+			} else {
+				reversed = append(reversed, Line{Op: OpDelete, Text: a[x-1], OldLine: x})
+			}
+			x, y = prevX, prevY
+		}
+	}
+
+	lines := make([]Line, len(reversed))
+	for i, line := range reversed {
+		lines[len(reversed)-1-i] = line
+	}
+	return lines
+}
+
+func replaceAll(a, b []string) []Line {
+	lines := make([]Line, 0, len(a)+len(b))
+	for i, text := range a {
+		lines = append(lines, Line{Op: OpDelete, Text: text, OldLine: i + 1})
+	}
+	for i, text := range b {
+		lines = append(lines, Line{Op: OpInsert, Text: text, NewLine: i + 1})
+	}
+	return lines
+}
@@ -0,0 +1,138 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// rfcSecret adalah secret SHA1 dari lampiran B RFC 6238 ("12345678901234567890")
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// Vektor uji lampiran B RFC 6238 untuk SHA1. RFC memakai 8 digit; kode 6 digit adalah
+// 6 digit terakhirnya karena keduanya diambil dari nilai truncation yang sama.
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCodeAtRFC6238Vectors(t *testing.T) {
+	for _, v := range rfcVectors {
+		code, err := CodeAt(rfcSecret, Step(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatalf("CodeAt(%d) error: %v", v.unix, err)
+		}
+		if code != v.code {
+			t.Errorf("CodeAt(%d) = %s, seharusnya %s", v.unix, code, v.code)
+		}
+	}
+}
+
+func TestValidateRFC6238Vectors(t *testing.T) {
+	for _, v := range rfcVectors {
+		at := time.Unix(v.unix, 0)
+		step, ok := Validate(rfcSecret, v.code, at, 0)
+		if !ok {
+			t.Errorf("Validate(%d) menolak kode yang benar", v.unix)
+			continue
+		}
+		if step != Step(at) {
+			t.Errorf("Validate(%d) step = %d, seharusnya %d", v.unix, step, Step(at))
+		}
+	}
+}
+
+func TestValidateSkewWindow(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+	current := Step(at)
+	period := int64(Period / time.Second)
+
+	tests := []struct {
+		name     string
+		codeStep int64
+		valid    bool
+	}{
+		{"periode sebelumnya", current - 1, true},
+		{"periode saat ini", current, true},
+		{"periode berikutnya", current + 1, true},
+		{"dua periode sebelumnya", current - 2, false},
+		{"dua periode berikutnya", current + 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := CodeAt(rfcSecret, tt.codeStep)
+			if err != nil {
+				t.Fatal(err)
+			}
+			step, ok := Validate(rfcSecret, code, at, 1)
+			if ok != tt.valid {
+				t.Fatalf("Validate = %v, seharusnya %v", ok, tt.valid)
+			}
+			if ok && step != tt.codeStep {
+				t.Fatalf("step = %d, seharusnya %d", step, tt.codeStep)
+			}
+		})
+	}
+
+	// Batas periode: detik terakhir periode sebelumnya masih diterima dengan skew 1
+	code, _ := CodeAt(rfcSecret, current)
+	if _, ok := Validate(rfcSecret, code, time.Unix((current+1)*period+period-1, 0), 1); !ok {
+		t.Error("kode periode sebelumnya seharusnya diterima di akhir periode berikutnya")
+	}
+}
+
+func TestValidateRejectsMalformedInput(t *testing.T) {
+	at := time.Unix(59, 0)
+	for _, code := range []string{"", "28708", "2870820", "abcdef"} {
+		if _, ok := Validate(rfcSecret, code, at, 1); ok {
+			t.Errorf("Validate(%q) seharusnya ditolak", code)
+		}
+	}
+	if _, ok := Validate("bukan-base32!", "287082", at, 1); ok {
+		t.Error("secret tidak valid seharusnya ditolak")
+	}
+}
+
+// TestValidateStepEnablesReplayRejection memastikan Validate mengembalikan periode milik kode,
+// bukan periode saat validasi. Pemanggil menolak pemakaian ulang dengan hanya menerima periode
+// yang lebih besar dari periode terakhir yang dipakai (lihat ConsumeTOTPStep di modul auth).
+func TestValidateStepEnablesReplayRejection(t *testing.T) {
+	at := time.Unix(1234567890, 0)
+	var lastUsedStep int64
+	consume := func(code string, now time.Time) bool {
+		step, ok := Validate(rfcSecret, code, now, 1)
+		if !ok || step <= lastUsedStep {
+			return false
+		}
+		lastUsedStep = step
+		return true
+	}
+
+	code, _ := CodeAt(rfcSecret, Step(at))
+	if !consume(code, at) {
+		t.Fatal("kode pertama seharusnya diterima")
+	}
+	if consume(code, at) {
+		t.Error("kode yang sama dalam periode yang sama seharusnya ditolak")
+	}
+	if consume(code, at.Add(Period)) {
+		t.Error("kode yang sama pada periode berikutnya (masih dalam skew) seharusnya ditolak")
+	}
+
+	previous, _ := CodeAt(rfcSecret, Step(at)-1)
+	if consume(previous, at) {
+		t.Error("kode periode sebelumnya seharusnya ditolak setelah periode yang lebih baru dipakai")
+	}
+
+	next, _ := CodeAt(rfcSecret, Step(at)+1)
+	if !consume(next, at.Add(Period)) {
+		t.Error("kode periode berikutnya seharusnya diterima")
+	}
+}
@@ -0,0 +1,214 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxCBORDepth membatasi kedalaman struktur CBOR dari klien
+const maxCBORDepth = 16
+
+var errCBOR = errors.New("CBOR tidak valid")
+
+// cborDecoder mendekode subset CBOR (RFC 8949) yang dipakai WebAuthn: integer, byte string,
+// text string, array, map, tag, boolean, null dan float. Panjang tak tentu (indefinite length)
+// tidak didukung karena authenticator wajib memakai encoding CTAP2 canonical.
+// Integer didekode sebagai int64, map sebagai map[interface{}]interface{} dengan kunci int64
+// atau string.
+type cborDecoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// decodeCBOR mendekode satu nilai dan mengembalikan jumlah byte yang dipakai
+func decodeCBOR(data []byte) (interface{}, int, error) {
+	d := &cborDecoder{data: data}
+	value, err := d.value()
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, d.pos, nil
+}
+
+func (d *cborDecoder) value() (interface{}, error) {
+	if d.depth > maxCBORDepth {
+		return nil, fmt.Errorf("%w: struktur terlalu dalam", errCBOR)
+	}
+	if d.pos >= len(d.data) {
+		return nil, fmt.Errorf("%w: data terpotong", errCBOR)
+	}
+
+	initial := d.data[d.pos]
+	d.pos++
+	major, info := initial>>5, initial&0x1f
+
+	if major == 7 {
+		return d.simple(info)
+	}
+	arg, err := d.argument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: integer terlalu besar", errCBOR)
+		}
+		return int64(arg), nil
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: integer terlalu besar", errCBOR)
+		}
+		return -1 - int64(arg), nil
+	case 2, 3:
+		raw, err := d.bytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		if major == 3 {
+			return string(raw), nil
+		}
+		return raw, nil
+	case 4:
+		// Setiap elemen minimal satu byte sehingga panjang tidak boleh melebihi sisa data
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, fmt.Errorf("%w: panjang array tidak valid", errCBOR)
+		}
+		d.depth++
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		d.depth--
+		return items, nil
+	case 5:
+		if arg > uint64(len(d.data)-d.pos)/2 {
+			return nil, fmt.Errorf("%w: panjang map tidak valid", errCBOR)
+		}
+		d.depth++
+		entries := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, fmt.Errorf("%w: kunci map harus integer atau string", errCBOR)
+			}
+			if _, exists := entries[key]; exists {
+				return nil, fmt.Errorf("%w: kunci map ganda", errCBOR)
+			}
+			entry, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = entry
+		}
+		d.depth--
+		return entries, nil
+	default:
+		// Tag (major 6) diabaikan dan nilai di dalamnya dikembalikan apa adanya
+		d.depth++
+		value, err := d.value()
+		d.depth--
+		return value, err
+	}
+}
+
+// argument membaca argumen head CBOR sesuai additional information
+func (d *cborDecoder) argument(info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info <= 27:
+		size := 1 << (info - 24)
+		raw, err := d.bytes(uint64(size))
+		if err != nil {
+			return 0, err
+		}
+		switch size {
+		case 1:
+			return uint64(raw[0]), nil
+		case 2:
+			return uint64(binary.BigEndian.Uint16(raw)), nil
+		case 4:
+			return uint64(binary.BigEndian.Uint32(raw)), nil
+		default:
+			return binary.BigEndian.Uint64(raw), nil
+		}
+	default:
+		return 0, fmt.Errorf("%w: panjang tak tentu tidak didukung", errCBOR)
+	}
+}
+
+// simple membaca nilai major type 7: boolean, null, undefined dan float
+func (d *cborDecoder) simple(info byte) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		raw, err := d.bytes(2)
+		if err != nil {
+			return nil, err
+		}
+		return float64(halfToFloat32(binary.BigEndian.Uint16(raw))), nil
+	case 26:
+		raw, err := d.bytes(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(raw))), nil
+	case 27:
+		raw, err := d.bytes(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(raw)), nil
+	default:
+		return nil, fmt.Errorf("%w: nilai simple %d tidak didukung", errCBOR, info)
+	}
+}
+
+func (d *cborDecoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, fmt.Errorf("%w: data terpotong", errCBOR)
+	}
+	raw := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return raw, nil
+}
+
+// halfToFloat32 mengubah float IEEE 754 half precision menjadi float32
+func halfToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		// Subnormal: nilai = frac * 2^-24
+		value := float32(frac) / (1 << 24)
+		if sign != 0 {
+			value = -value
+		}
+		return value
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | frac<<13)
+	default:
+		return math.Float32frombits(sign | (exp+112)<<23 | frac<<13)
+	}
+}
@@ -0,0 +1,122 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// Algoritma COSE yang didukung (IANA COSE Algorithms)
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// Algorithms adalah algoritma yang ditawarkan ke authenticator, urut dari yang paling disukai
+var Algorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// Parameter COSE_Key (RFC 9052 bagian 7 dan RFC 9053)
+const (
+	coseKeyType   int64 = 1
+	coseAlgorithm int64 = 3
+	coseCurve     int64 = -1 // crv untuk EC2/OKP, n untuk RSA
+	coseX         int64 = -2 // x untuk EC2/OKP, e untuk RSA
+	coseY         int64 = -3
+
+	coseKeyTypeOKP int64 = 1
+	coseKeyTypeEC2 int64 = 2
+	coseKeyTypeRSA int64 = 3
+
+	coseCurveP256    int64 = 1
+	coseCurveEd25519 int64 = 6
+
+	// minRSAKeyBits menolak kunci RSA yang terlalu lemah
+	minRSAKeyBits = 2048
+)
+
+// publicKey adalah kunci publik kredensial yang sudah di-parsing dari COSE_Key
+type publicKey struct {
+	algorithm int64
+	key       crypto.PublicKey
+}
+
+// parsePublicKey mem-parsing COSE_Key ES256 (P-256), EdDSA (Ed25519) atau RS256
+func parsePublicKey(raw []byte) (*publicKey, error) {
+	decoded, used, err := decodeCBOR(raw)
+	if err != nil || used != len(raw) {
+		return nil, fmt.Errorf("%w: kunci publik COSE tidak valid", ErrInvalidResponse)
+	}
+	params, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: kunci publik COSE bukan map", ErrInvalidResponse)
+	}
+	keyType, _ := params[coseKeyType].(int64)
+	alg, _ := params[coseAlgorithm].(int64)
+
+	switch {
+	case alg == AlgES256 && keyType == coseKeyTypeEC2:
+		curve, _ := params[coseCurve].(int64)
+		x, _ := params[coseX].([]byte)
+		y, _ := params[coseY].([]byte)
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("%w: kunci ES256 tidak valid", ErrInvalidResponse)
+		}
+		// Validasi titik ada di kurva sebelum dipakai
+		uncompressed := append(append([]byte{0x04}, x...), y...)
+		if _, err := ecdh.P256().NewPublicKey(uncompressed); err != nil {
+			return nil, fmt.Errorf("%w: kunci ES256 tidak berada di kurva", ErrInvalidResponse)
+		}
+		return &publicKey{algorithm: alg, key: &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}}, nil
+
+	case alg == AlgEdDSA && keyType == coseKeyTypeOKP:
+		curve, _ := params[coseCurve].(int64)
+		x, _ := params[coseX].([]byte)
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: kunci EdDSA tidak valid", ErrInvalidResponse)
+		}
+		return &publicKey{algorithm: alg, key: ed25519.PublicKey(x)}, nil
+
+	case alg == AlgRS256 && keyType == coseKeyTypeRSA:
+		n, _ := params[coseCurve].([]byte)
+		e, _ := params[coseX].([]byte)
+		modulus := new(big.Int).SetBytes(n)
+		exponent := new(big.Int).SetBytes(e)
+		if modulus.BitLen() < minRSAKeyBits || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("%w: kunci RS256 tidak valid", ErrInvalidResponse)
+		}
+		return &publicKey{algorithm: alg, key: &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: algoritma COSE %d dengan tipe kunci %d tidak didukung", ErrInvalidResponse, alg, keyType)
+	}
+}
+
+// verify memeriksa tanda tangan atas message sesuai algoritma kunci
+func (k *publicKey) verify(message, signature []byte) error {
+	valid := false
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		valid = ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, message, signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	}
+	if !valid {
+		return fmt.Errorf("%w: tanda tangan tidak valid", ErrInvalidResponse)
+	}
+	return nil
+}
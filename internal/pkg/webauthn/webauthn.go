@@ -0,0 +1,370 @@
+// Package webauthn mengimplementasikan sisi relying party Web Authentication Level 2
+// (https://www.w3.org/TR/webauthn-2/) untuk registrasi dan login dengan passkey atau security
+// key: verifikasi clientDataJSON, authenticator data, attestation "none" (serta self
+// attestation "packed" yang tidak mengidentifikasi perangkat) dan tanda tangan assertion
+// dengan kunci COSE ES256, EdDSA atau RS256.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// CeremonyCreate dan CeremonyGet adalah nilai clientDataJSON.type
+	CeremonyCreate = "webauthn.create"
+	CeremonyGet    = "webauthn.get"
+
+	// challengeSize adalah panjang challenge acak dalam byte
+	challengeSize = 32
+	// maxCredentialIDLength adalah batas panjang credential ID dari spesifikasi
+	maxCredentialIDLength = 1023
+)
+
+// Flag authenticator data (bagian 6.1)
+const (
+	flagUserPresent      = 0x01
+	flagUserVerified     = 0x04
+	flagBackupEligible   = 0x08
+	flagBackedUp         = 0x10
+	flagAttestedCredData = 0x40
+	flagExtensionData    = 0x80
+)
+
+var (
+	// ErrInvalidResponse dikembalikan ketika respons authenticator gagal diverifikasi
+	ErrInvalidResponse = errors.New("respons WebAuthn tidak valid")
+	// ErrUnsupportedAttestation dikembalikan untuk format attestation selain none dan self attestation
+	ErrUnsupportedAttestation = errors.New("format attestation tidak didukung")
+	// ErrSignCountRegression dikembalikan ketika penghitung tanda tangan tidak naik, tanda
+	// bahwa authenticator mungkin diklon
+	ErrSignCountRegression = errors.New("penghitung tanda tangan authenticator tidak naik")
+)
+
+// RelyingParty adalah konfigurasi relying party: RP ID (domain), nama yang ditampilkan
+// authenticator dan origin frontend yang boleh menjalankan ceremony
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// NewRelyingParty memvalidasi konfigurasi relying party. Setiap origin harus berada di
+// domain RP ID atau subdomainnya sesuai aturan browser.
+func NewRelyingParty(id, name string, origins []string) (*RelyingParty, error) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" {
+		return nil, errors.New("RP ID WebAuthn wajib diisi")
+	}
+	rp := &RelyingParty{ID: id, Name: name}
+	for _, origin := range origins {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin == "" {
+			continue
+		}
+		parsed, err := url.Parse(origin)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+			return nil, fmt.Errorf("origin WebAuthn tidak valid: %s", origin)
+		}
+		host := parsed.Hostname()
+		if host != id && !strings.HasSuffix(host, "."+id) {
+			return nil, fmt.Errorf("origin WebAuthn %s tidak berada di domain RP ID %s", origin, id)
+		}
+		rp.Origins = append(rp.Origins, origin)
+	}
+	if len(rp.Origins) == 0 {
+		return nil, errors.New("minimal satu origin WebAuthn wajib diisi")
+	}
+	return rp, nil
+}
+
+// RegistrationResponse adalah AuthenticatorAttestationResponse dari navigator.credentials.create
+type RegistrationResponse struct {
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// AssertionResponse adalah AuthenticatorAssertionResponse dari navigator.credentials.get
+type AssertionResponse struct {
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserHandle        []byte
+}
+
+// Credential adalah kredensial yang berhasil didaftarkan
+type Credential struct {
+	ID []byte
+	// PublicKey adalah kunci publik dalam encoding COSE_Key apa adanya
+	PublicKey      []byte
+	Algorithm      int64
+	SignCount      uint32
+	AAGUID         []byte
+	UserVerified   bool
+	BackupEligible bool
+	BackedUp       bool
+}
+
+// Assertion adalah hasil verifikasi login dengan kredensial
+type Assertion struct {
+	SignCount    uint32
+	UserVerified bool
+	BackedUp     bool
+}
+
+// authenticatorData adalah hasil parsing authenticator data (bagian 6.1)
+type authenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+// clientData adalah isi clientDataJSON yang diperiksa (bagian 5.8.1)
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// NewChallenge membuat challenge acak berformat base64url tanpa padding
+func NewChallenge() (string, error) {
+	challenge := make([]byte, challengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", fmt.Errorf("gagal membuat challenge WebAuthn: %w", err)
+	}
+	return EncodeBase64URL(challenge), nil
+}
+
+// ChallengeFromClientData mengambil challenge dari clientDataJSON agar relying party dapat
+// mencari challenge yang diterbitkan sebelum memverifikasi respons
+func ChallengeFromClientData(clientDataJSON []byte) (string, error) {
+	var data clientData
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil || data.Challenge == "" {
+		return "", fmt.Errorf("%w: clientDataJSON tidak dapat dibaca", ErrInvalidResponse)
+	}
+	return data.Challenge, nil
+}
+
+// EncodeBase64URL mengubah byte menjadi base64url tanpa padding sesuai format JSON WebAuthn
+func EncodeBase64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeBase64URL menerima base64url dengan atau tanpa padding
+func DecodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+// VerifyRegistration memverifikasi respons registrasi (bagian 7.1) terhadap challenge yang
+// diterbitkan dan mengembalikan kredensial yang harus disimpan
+func (rp *RelyingParty) VerifyRegistration(resp *RegistrationResponse, challenge string, requireUserVerification bool) (*Credential, error) {
+	if err := rp.verifyClientData(resp.ClientDataJSON, CeremonyCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := decodeCBOR(resp.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: attestationObject: %v", ErrInvalidResponse, err)
+	}
+	attestation, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: attestationObject bukan map", ErrInvalidResponse)
+	}
+	format, _ := attestation["fmt"].(string)
+	statement, _ := attestation["attStmt"].(map[interface{}]interface{})
+	rawAuthData, _ := attestation["authData"].([]byte)
+	if statement == nil || rawAuthData == nil {
+		return nil, fmt.Errorf("%w: attestationObject tidak lengkap", ErrInvalidResponse)
+	}
+
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := rp.verifyAuthenticatorData(authData, requireUserVerification); err != nil {
+		return nil, err
+	}
+	if authData.flags&flagAttestedCredData == 0 {
+		return nil, fmt.Errorf("%w: authenticator data tidak memuat kredensial", ErrInvalidResponse)
+	}
+
+	key, err := parsePublicKey(authData.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "none":
+		if len(statement) != 0 {
+			return nil, fmt.Errorf("%w: attStmt none harus kosong", ErrInvalidResponse)
+		}
+	case "packed":
+		// Hanya self attestation (tanpa sertifikat) yang diterima: tanda tangan dibuat dengan
+		// kunci kredensial itu sendiri sehingga tidak mengidentifikasi model perangkat
+		if _, hasCertificate := statement["x5c"]; hasCertificate {
+			return nil, ErrUnsupportedAttestation
+		}
+		alg, _ := statement["alg"].(int64)
+		sig, _ := statement["sig"].([]byte)
+		if alg != key.algorithm || sig == nil {
+			return nil, fmt.Errorf("%w: self attestation tidak valid", ErrInvalidResponse)
+		}
+		clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+		if err := key.verify(append(append([]byte{}, rawAuthData...), clientDataHash[:]...), sig); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAttestation, format)
+	}
+
+	return &Credential{
+		ID:             authData.credentialID,
+		PublicKey:      authData.publicKey,
+		Algorithm:      key.algorithm,
+		SignCount:      authData.signCount,
+		AAGUID:         authData.aaguid,
+		UserVerified:   authData.flags&flagUserVerified != 0,
+		BackupEligible: authData.flags&flagBackupEligible != 0,
+		BackedUp:       authData.flags&flagBackedUp != 0,
+	}, nil
+}
+
+// VerifyAssertion memverifikasi respons login (bagian 7.2) dengan kunci publik COSE dan
+// penghitung tanda tangan yang tersimpan. Penghitung yang tidak naik ditolak dengan
+// ErrSignCountRegression, kecuali authenticator tidak memakai penghitung (selalu 0).
+func (rp *RelyingParty) VerifyAssertion(resp *AssertionResponse, challenge string, publicKey []byte, storedSignCount uint32, requireUserVerification bool) (*Assertion, error) {
+	if err := rp.verifyClientData(resp.ClientDataJSON, CeremonyGet, challenge); err != nil {
+		return nil, err
+	}
+
+	authData, err := parseAuthenticatorData(resp.AuthenticatorData)
+	if err != nil {
+		return nil, err
+	}
+	if err := rp.verifyAuthenticatorData(authData, requireUserVerification); err != nil {
+		return nil, err
+	}
+
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+	signed := append(append([]byte{}, resp.AuthenticatorData...), clientDataHash[:]...)
+	if err := key.verify(signed, resp.Signature); err != nil {
+		return nil, err
+	}
+
+	if (authData.signCount != 0 || storedSignCount != 0) && authData.signCount <= storedSignCount {
+		return nil, ErrSignCountRegression
+	}
+
+	return &Assertion{
+		SignCount:    authData.signCount,
+		UserVerified: authData.flags&flagUserVerified != 0,
+		BackedUp:     authData.flags&flagBackedUp != 0,
+	}, nil
+}
+
+// verifyClientData memeriksa type, challenge dan origin pada clientDataJSON
+func (rp *RelyingParty) verifyClientData(raw []byte, ceremony, challenge string) error {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("%w: clientDataJSON tidak dapat dibaca", ErrInvalidResponse)
+	}
+	if data.Type != ceremony {
+		return fmt.Errorf("%w: type %q bukan %q", ErrInvalidResponse, data.Type, ceremony)
+	}
+	if challenge == "" || data.Challenge != challenge {
+		return fmt.Errorf("%w: challenge tidak cocok", ErrInvalidResponse)
+	}
+	if data.CrossOrigin {
+		return fmt.Errorf("%w: ceremony dari iframe lintas origin tidak diterima", ErrInvalidResponse)
+	}
+	for _, origin := range rp.Origins {
+		if data.Origin == origin {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: origin %q tidak diizinkan", ErrInvalidResponse, data.Origin)
+}
+
+// verifyAuthenticatorData memeriksa hash RP ID dan flag kehadiran/verifikasi pengguna
+func (rp *RelyingParty) verifyAuthenticatorData(authData *authenticatorData, requireUserVerification bool) error {
+	expected := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(authData.rpIDHash, expected[:]) {
+		return fmt.Errorf("%w: RP ID tidak cocok", ErrInvalidResponse)
+	}
+	if authData.flags&flagUserPresent == 0 {
+		return fmt.Errorf("%w: pengguna tidak hadir", ErrInvalidResponse)
+	}
+	if requireUserVerification && authData.flags&flagUserVerified == 0 {
+		return fmt.Errorf("%w: verifikasi pengguna dibutuhkan", ErrInvalidResponse)
+	}
+	if authData.flags&flagBackedUp != 0 && authData.flags&flagBackupEligible == 0 {
+		return fmt.Errorf("%w: flag backup tidak konsisten", ErrInvalidResponse)
+	}
+	return nil
+}
+
+// parseAuthenticatorData mem-parsing authenticator data dan memastikan seluruh byte terpakai
+func parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, fmt.Errorf("%w: authenticator data terlalu pendek", ErrInvalidResponse)
+	}
+	authData := &authenticatorData{
+		rpIDHash:  raw[:32],
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	rest := raw[37:]
+
+	if authData.flags&flagAttestedCredData != 0 {
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("%w: attested credential data terpotong", ErrInvalidResponse)
+		}
+		authData.aaguid = rest[:16]
+		idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLength == 0 || idLength > maxCredentialIDLength || len(rest) < idLength {
+			return nil, fmt.Errorf("%w: panjang credential ID tidak valid", ErrInvalidResponse)
+		}
+		authData.credentialID = rest[:idLength]
+		rest = rest[idLength:]
+
+		_, used, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: kunci publik: %v", ErrInvalidResponse, err)
+		}
+		authData.publicKey = rest[:used]
+		rest = rest[used:]
+	}
+
+	if authData.flags&flagExtensionData != 0 {
+		extensions, used, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: extension: %v", ErrInvalidResponse, err)
+		}
+		if _, ok := extensions.(map[interface{}]interface{}); !ok {
+			return nil, fmt.Errorf("%w: extension bukan map", ErrInvalidResponse)
+		}
+		rest = rest[used:]
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%w: authenticator data memuat byte sisa", ErrInvalidResponse)
+	}
+	return authData, nil
+}
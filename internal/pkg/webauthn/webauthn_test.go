@@ -0,0 +1,489 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const (
+	testRPID   = "example.com"
+	testOrigin = "https://example.com"
+)
+
+// cborPair dan cborMap membentuk map CBOR dengan urutan kunci yang ditentukan test
+type cborPair struct {
+	key   interface{}
+	value interface{}
+}
+
+type cborMap []cborPair
+
+// encodeCBOR adalah encoder CBOR minimal untuk membangun respons authenticator di test
+func encodeCBOR(value interface{}) []byte {
+	switch v := value.(type) {
+	case int:
+		return encodeCBOR(int64(v))
+	case int64:
+		if v >= 0 {
+			return cborHead(0, uint64(v))
+		}
+		return cborHead(1, uint64(-1-v))
+	case []byte:
+		return append(cborHead(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHead(3, uint64(len(v))), v...)
+	case []interface{}:
+		out := cborHead(4, uint64(len(v)))
+		for _, item := range v {
+			out = append(out, encodeCBOR(item)...)
+		}
+		return out
+	case cborMap:
+		out := cborHead(5, uint64(len(v)))
+		for _, pair := range v {
+			out = append(out, encodeCBOR(pair.key)...)
+			out = append(out, encodeCBOR(pair.value)...)
+		}
+		return out
+	}
+	panic("tipe CBOR tidak didukung di test")
+}
+
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return []byte{major<<5 | 25, byte(n >> 8), byte(n)}
+	default:
+		out := []byte{major<<5 | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(out[1:], uint32(n))
+		return out
+	}
+}
+
+// softAuthenticator adalah authenticator perangkat lunak dengan kunci ES256 (P-256) atau
+// EdDSA (Ed25519) yang menghasilkan respons registrasi dan assertion seperti browser
+type softAuthenticator struct {
+	t            *testing.T
+	algorithm    int64
+	ecdsaKey     *ecdsa.PrivateKey
+	ed25519Key   ed25519.PrivateKey
+	credentialID []byte
+	rpID         string
+	signCount    uint32
+}
+
+func newSoftAuthenticator(t *testing.T, algorithm int64) *softAuthenticator {
+	t.Helper()
+	a := &softAuthenticator{t: t, algorithm: algorithm, rpID: testRPID, credentialID: make([]byte, 16)}
+	if _, err := rand.Read(a.credentialID); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	switch algorithm {
+	case AlgES256:
+		a.ecdsaKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgEdDSA:
+		_, a.ed25519Key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		t.Fatalf("algoritma %d tidak didukung authenticator test", algorithm)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func (a *softAuthenticator) coseKey() []byte {
+	if a.algorithm == AlgEdDSA {
+		return encodeCBOR(cborMap{
+			{coseKeyType, coseKeyTypeOKP},
+			{coseAlgorithm, AlgEdDSA},
+			{coseCurve, coseCurveEd25519},
+			{coseX, []byte(a.ed25519Key.Public().(ed25519.PublicKey))},
+		})
+	}
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	a.ecdsaKey.X.FillBytes(x)
+	a.ecdsaKey.Y.FillBytes(y)
+	return encodeCBOR(cborMap{
+		{coseKeyType, coseKeyTypeEC2},
+		{coseAlgorithm, AlgES256},
+		{coseCurve, coseCurveP256},
+		{coseX, x},
+		{coseY, y},
+	})
+}
+
+func (a *softAuthenticator) sign(message []byte) []byte {
+	if a.algorithm == AlgEdDSA {
+		return ed25519.Sign(a.ed25519Key, message)
+	}
+	digest := sha256.Sum256(message)
+	signature, err := ecdsa.SignASN1(rand.Reader, a.ecdsaKey, digest[:])
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	return signature
+}
+
+func (a *softAuthenticator) authenticatorData(flags byte, withCredential bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if withCredential {
+		data = append(data, make([]byte, 16)...) // AAGUID kosong seperti authenticator tanpa attestation
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.coseKey()...)
+	}
+	return data
+}
+
+func clientDataJSON(t *testing.T, ceremony, challenge, origin string) []byte {
+	t.Helper()
+	raw, err := json.Marshal(map[string]interface{}{
+		"type":        ceremony,
+		"challenge":   challenge,
+		"origin":      origin,
+		"crossOrigin": false,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+// register membuat respons navigator.credentials.create dengan format "none" atau "packed"
+// (self attestation)
+func (a *softAuthenticator) register(format, challenge, origin string) *RegistrationResponse {
+	clientData := clientDataJSON(a.t, CeremonyCreate, challenge, origin)
+	authData := a.authenticatorData(flagUserPresent|flagUserVerified|flagAttestedCredData, true)
+
+	statement := cborMap{}
+	if format == "packed" {
+		clientDataHash := sha256.Sum256(clientData)
+		signature := a.sign(append(append([]byte{}, authData...), clientDataHash[:]...))
+		statement = cborMap{{"alg", a.algorithm}, {"sig", signature}}
+	}
+	return &RegistrationResponse{
+		ClientDataJSON: clientData,
+		AttestationObject: encodeCBOR(cborMap{
+			{"fmt", format},
+			{"attStmt", statement},
+			{"authData", authData},
+		}),
+	}
+}
+
+// assert menaikkan penghitung lalu membuat respons navigator.credentials.get
+func (a *softAuthenticator) assert(challenge, origin string, flags byte) *AssertionResponse {
+	a.signCount++
+	clientData := clientDataJSON(a.t, CeremonyGet, challenge, origin)
+	authData := a.authenticatorData(flags, false)
+	clientDataHash := sha256.Sum256(clientData)
+	return &AssertionResponse{
+		ClientDataJSON:    clientData,
+		AuthenticatorData: authData,
+		Signature:         a.sign(append(append([]byte{}, authData...), clientDataHash[:]...)),
+	}
+}
+
+func newTestRelyingParty(t *testing.T) *RelyingParty {
+	t.Helper()
+	rp, err := NewRelyingParty(testRPID, "CMS", []string{testOrigin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rp
+}
+
+func newTestChallenge(t *testing.T) string {
+	t.Helper()
+	challenge, err := NewChallenge()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return challenge
+}
+
+var testAlgorithms = []struct {
+	name      string
+	algorithm int64
+}{
+	{"ES256", AlgES256},
+	{"EdDSA", AlgEdDSA},
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	rp := newTestRelyingParty(t)
+	for _, alg := range testAlgorithms {
+		for _, format := range []string{"none", "packed"} {
+			t.Run(alg.name+"/"+format, func(t *testing.T) {
+				authenticator := newSoftAuthenticator(t, alg.algorithm)
+				challenge := newTestChallenge(t)
+
+				credential, err := rp.VerifyRegistration(authenticator.register(format, challenge, testOrigin), challenge, true)
+				if err != nil {
+					t.Fatalf("VerifyRegistration error: %v", err)
+				}
+				if string(credential.ID) != string(authenticator.credentialID) {
+					t.Error("credential ID tidak sesuai")
+				}
+				if credential.Algorithm != alg.algorithm {
+					t.Errorf("algoritma = %d, seharusnya %d", credential.Algorithm, alg.algorithm)
+				}
+				if string(credential.PublicKey) != string(authenticator.coseKey()) {
+					t.Error("kunci publik COSE tidak sesuai")
+				}
+				if !credential.UserVerified || credential.BackupEligible || credential.BackedUp {
+					t.Errorf("flag kredensial tidak sesuai: %+v", credential)
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyRegistrationRejects(t *testing.T) {
+	rp := newTestRelyingParty(t)
+
+	t.Run("challenge tidak cocok", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t, AlgES256)
+		resp := authenticator.register("none", newTestChallenge(t), testOrigin)
+		if _, err := rp.VerifyRegistration(resp, newTestChallenge(t), false); !errors.Is(err, ErrInvalidResponse) {
+			t.Fatalf("err = %v, seharusnya ErrInvalidResponse", err)
+		}
+	})
+
+	t.Run("origin lain", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t, AlgES256)
+		challenge := newTestChallenge(t)
+		resp := authenticator.register("none", challenge, "https://evil.example")
+		if _, err := rp.VerifyRegistration(resp, challenge, false); !errors.Is(err, ErrInvalidResponse) {
+			t.Fatalf("err = %v, seharusnya ErrInvalidResponse", err)
+		}
+	})
+
+	t.Run("RP ID lain", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t, AlgEdDSA)
+		authenticator.rpID = "evil.example"
+		challenge := newTestChallenge(t)
+		resp := authenticator.register("none", challenge, testOrigin)
+		if _, err := rp.VerifyRegistration(resp, challenge, false); !errors.Is(err, ErrInvalidResponse) {
+			t.Fatalf("err = %v, seharusnya ErrInvalidResponse", err)
+		}
+	})
+
+	t.Run("self attestation dengan tanda tangan kunci lain", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t, AlgES256)
+		other := newSoftAuthenticator(t, AlgES256)
+		challenge := newTestChallenge(t)
+		resp := authenticator.register("packed", challenge, testOrigin)
+
+		decoded, _, err := decodeCBOR(resp.AttestationObject)
+		if err != nil {
+			t.Fatal(err)
+		}
+		authData := decoded.(map[interface{}]interface{})["authData"].([]byte)
+		clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+		forged := other.sign(append(append([]byte{}, authData...), clientDataHash[:]...))
+		resp.AttestationObject = encodeCBOR(cborMap{
+			{"fmt", "packed"},
+			{"attStmt", cborMap{{"alg", AlgES256}, {"sig", forged}}},
+			{"authData", authData},
+		})
+		if _, err := rp.VerifyRegistration(resp, challenge, false); !errors.Is(err, ErrInvalidResponse) {
+			t.Fatalf("err = %v, seharusnya ErrInvalidResponse", err)
+		}
+	})
+
+	t.Run("attestation dengan sertifikat", func(t *testing.T) {
+		authenticator := newSoftAuthenticator(t, AlgES256)
+		challenge := newTestChallenge(t)
+		resp := authenticator.register("none", challenge, testOrigin)
+		decoded, _, _ := decodeCBOR(resp.AttestationObject)
+		authData := decoded.(map[interface{}]interface{})["authData"].([]byte)
+		resp.AttestationObject = encodeCBOR(cborMap{
+			{"fmt", "packed"},
+			{"attStmt", cborMap{{"alg", AlgES256}, {"sig", []byte{1}}, {"x5c", []interface{}{[]byte{1, 2, 3}}}}},
+			{"authData", authData},
+		})
+		if _, err := rp.VerifyRegistration(resp, challenge, false); !errors.Is(err, ErrUnsupportedAttestation) {
+			t.Fatalf("err = %v, seharusnya ErrUnsupportedAttestation", err)
+		}
+	})
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	rp := newTestRelyingParty(t)
+	for _, alg := range testAlgorithms {
+		t.Run(alg.name, func(t *testing.T) {
+			authenticator := newSoftAuthenticator(t, alg.algorithm)
+			challenge := newTestChallenge(t)
+			credential, err := rp.VerifyRegistration(authenticator.register("none", challenge, testOrigin), challenge, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			challenge = newTestChallenge(t)
+			resp := authenticator.assert(challenge, testOrigin, flagUserPresent|flagUserVerified)
+			assertion, err := rp.VerifyAssertion(resp, challenge, credential.PublicKey, credential.SignCount, true)
+			if err != nil {
+				t.Fatalf("VerifyAssertion error: %v", err)
+			}
+			if assertion.SignCount != authenticator.signCount || !assertion.UserVerified {
+				t.Errorf("assertion tidak sesuai: %+v", assertion)
+			}
+		})
+	}
+}
+
+func TestVerifyAssertionRejects(t *testing.T) {
+	rp := newTestRelyingParty(t)
+	const present = flagUserPresent | flagUserVerified
+
+	tests := []struct {
+		name string
+		// prepare mengembalikan respons, challenge yang diharapkan relying party dan
+		// penghitung tersimpan
+		prepare func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32)
+		uv      bool
+		target  error
+	}{
+		{
+			name: "origin lain",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				challenge := newTestChallenge(t)
+				return a.assert(challenge, "https://evil.example", present), challenge, 0
+			},
+			target: ErrInvalidResponse,
+		},
+		{
+			name: "subdomain yang tidak dikonfigurasi",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				challenge := newTestChallenge(t)
+				return a.assert(challenge, "https://login.example.com", present), challenge, 0
+			},
+			target: ErrInvalidResponse,
+		},
+		{
+			name: "rpIdHash lain",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				a.rpID = "evil.example"
+				challenge := newTestChallenge(t)
+				return a.assert(challenge, testOrigin, present), challenge, 0
+			},
+			target: ErrInvalidResponse,
+		},
+		{
+			name: "challenge tidak cocok",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				return a.assert(newTestChallenge(t), testOrigin, present), newTestChallenge(t), 0
+			},
+			target: ErrInvalidResponse,
+		},
+		{
+			name: "penghitung tidak naik",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				a.signCount = 4
+				challenge := newTestChallenge(t)
+				return a.assert(challenge, testOrigin, present), challenge, 5
+			},
+			target: ErrSignCountRegression,
+		},
+		{
+			name: "penghitung sama dengan yang tersimpan",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				a.signCount = 6
+				challenge := newTestChallenge(t)
+				return a.assert(challenge, testOrigin, present), challenge, 7
+			},
+			target: ErrSignCountRegression,
+		},
+		{
+			name: "tanpa flag UP",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				challenge := newTestChallenge(t)
+				return a.assert(challenge, testOrigin, flagUserVerified), challenge, 0
+			},
+			target: ErrInvalidResponse,
+		},
+		{
+			name: "tanpa flag UV saat verifikasi pengguna diwajibkan",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				challenge := newTestChallenge(t)
+				return a.assert(challenge, testOrigin, flagUserPresent), challenge, 0
+			},
+			uv:     true,
+			target: ErrInvalidResponse,
+		},
+		{
+			name: "clientDataJSON dari ceremony registrasi",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				challenge := newTestChallenge(t)
+				resp := a.assert(challenge, testOrigin, present)
+				resp.ClientDataJSON = clientDataJSON(t, CeremonyCreate, challenge, testOrigin)
+				return resp, challenge, 0
+			},
+			target: ErrInvalidResponse,
+		},
+		{
+			name: "tanda tangan diubah",
+			prepare: func(t *testing.T, a *softAuthenticator) (*AssertionResponse, string, uint32) {
+				challenge := newTestChallenge(t)
+				resp := a.assert(challenge, testOrigin, present)
+				resp.Signature[len(resp.Signature)-1] ^= 0xff
+				return resp, challenge, 0
+			},
+			target: ErrInvalidResponse,
+		},
+	}
+
+	for _, alg := range testAlgorithms {
+		for _, tt := range tests {
+			t.Run(alg.name+"/"+tt.name, func(t *testing.T) {
+				authenticator := newSoftAuthenticator(t, alg.algorithm)
+				publicKey := authenticator.coseKey()
+				resp, challenge, storedSignCount := tt.prepare(t, authenticator)
+
+				_, err := rp.VerifyAssertion(resp, challenge, publicKey, storedSignCount, tt.uv)
+				if !errors.Is(err, tt.target) {
+					t.Fatalf("err = %v, seharusnya %v", err, tt.target)
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyAssertionAllowsZeroSignCount(t *testing.T) {
+	// Authenticator tanpa penghitung (misalnya passkey yang disinkronkan) selalu mengirim 0
+	rp := newTestRelyingParty(t)
+	authenticator := newSoftAuthenticator(t, AlgEdDSA)
+	for i := 0; i < 2; i++ {
+		authenticator.signCount = 0
+		challenge := newTestChallenge(t)
+		resp := authenticator.assert(challenge, testOrigin, flagUserPresent)
+		// assert menaikkan penghitung, jadi authenticator data dibuat ulang dengan nilai 0
+		authenticator.signCount = 0
+		clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+		resp.AuthenticatorData = authenticator.authenticatorData(flagUserPresent, false)
+		resp.Signature = authenticator.sign(append(append([]byte{}, resp.AuthenticatorData...), clientDataHash[:]...))
+
+		if _, err := rp.VerifyAssertion(resp, challenge, authenticator.coseKey(), 0, false); err != nil {
+			t.Fatalf("percobaan %d: penghitung 0 seharusnya diterima, err = %v", i+1, err)
+		}
+	}
+}
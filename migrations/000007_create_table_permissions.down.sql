@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_permissions_updated_at ON permissions;
+
+DROP INDEX IF EXISTS idx_permissions_name;
+DROP TABLE IF EXISTS permissions;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_role_permissions_permission_id;
+DROP INDEX IF EXISTS idx_role_permissions_role_id;
+
+ALTER TABLE role_permissions DROP CONSTRAINT IF EXISTS fk_role_permissions_permission;
+ALTER TABLE role_permissions DROP CONSTRAINT IF EXISTS fk_role_permissions_role;
+
+DROP TABLE IF EXISTS role_permissions;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS role_permissions (
+    id VARCHAR(255) PRIMARY KEY,
+    role_id VARCHAR(255) NOT NULL,
+    permission_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (role_id, permission_id),
+
+    CONSTRAINT fk_role_permissions_role
+        FOREIGN KEY(role_id)
+            REFERENCES roles(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_role_permissions_permission
+        FOREIGN KEY(permission_id)
+            REFERENCES permissions(id)
+            ON DELETE CASCADE
+);
+
+-- Indexes untuk performance
+CREATE INDEX IF NOT EXISTS idx_role_permissions_role_id ON role_permissions(role_id);
+CREATE INDEX IF NOT EXISTS idx_role_permissions_permission_id ON role_permissions(permission_id);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS bump_authz_version_on_role_permissions ON role_permissions;
+DROP TRIGGER IF EXISTS bump_authz_version_on_user_roles ON user_roles;
+
+DROP FUNCTION IF EXISTS bump_role_authz_version();
+DROP FUNCTION IF EXISTS bump_user_authz_version();
+
+ALTER TABLE users DROP COLUMN IF EXISTS authz_version;
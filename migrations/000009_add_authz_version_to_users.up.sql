@@ -0,0 +1,35 @@
+-- Versi otorisasi pengguna, disalin ke klaim access token agar token dengan
+-- role/permission yang sudah usang dapat dideteksi
+ALTER TABLE users ADD COLUMN IF NOT EXISTS authz_version BIGINT NOT NULL DEFAULT 1;
+
+-- Naikkan versi ketika role pengguna bertambah atau berkurang
+CREATE OR REPLACE FUNCTION bump_user_authz_version()
+RETURNS TRIGGER AS $$
+BEGIN
+   UPDATE users SET authz_version = authz_version + 1
+   WHERE id = COALESCE(NEW.user_id, OLD.user_id);
+   RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER bump_authz_version_on_user_roles
+    AFTER INSERT OR DELETE ON user_roles
+    FOR EACH ROW
+    EXECUTE FUNCTION bump_user_authz_version();
+
+-- Naikkan versi semua pemegang role ketika permission role berubah
+CREATE OR REPLACE FUNCTION bump_role_authz_version()
+RETURNS TRIGGER AS $$
+BEGIN
+   UPDATE users SET authz_version = authz_version + 1
+   WHERE id IN (
+       SELECT user_id FROM user_roles WHERE role_id = COALESCE(NEW.role_id, OLD.role_id)
+   );
+   RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER bump_authz_version_on_role_permissions
+    AFTER INSERT OR DELETE ON role_permissions
+    FOR EACH ROW
+    EXECUTE FUNCTION bump_role_authz_version();
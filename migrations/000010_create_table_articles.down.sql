@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS update_articles_updated_at ON articles;
+
+DROP INDEX IF EXISTS idx_articles_publish_at;
+DROP INDEX IF EXISTS idx_articles_published_at;
+DROP INDEX IF EXISTS idx_articles_status;
+DROP INDEX IF EXISTS idx_articles_author_id;
+DROP INDEX IF EXISTS idx_articles_slug;
+
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS fk_articles_author;
+DROP TABLE IF EXISTS articles;
@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS articles (
+    id VARCHAR(255) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    excerpt TEXT,
+    body TEXT NOT NULL DEFAULT '',
+    cover_image_url VARCHAR(500),
+    author_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft', -- draft, in_review, scheduled, published, archived
+    publish_at TIMESTAMPTZ, -- waktu tayang untuk artikel berstatus scheduled
+    submitted_at TIMESTAMPTZ,
+    submitted_by VARCHAR(255),
+    reviewed_at TIMESTAMPTZ,
+    reviewed_by VARCHAR(255),
+    published_at TIMESTAMPTZ,
+    published_by VARCHAR(255),
+    archived_at TIMESTAMPTZ,
+    archived_by VARCHAR(255),
+    last_edited_by VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_articles_status
+        CHECK (status IN ('draft', 'in_review', 'scheduled', 'published', 'archived')),
+    CONSTRAINT fk_articles_author
+        FOREIGN KEY(author_id)
+            REFERENCES users(id)
+);
+
+-- Indexes untuk performance
+CREATE INDEX IF NOT EXISTS idx_articles_slug ON articles(slug);
+CREATE INDEX IF NOT EXISTS idx_articles_author_id ON articles(author_id);
+CREATE INDEX IF NOT EXISTS idx_articles_status ON articles(status);
+CREATE INDEX IF NOT EXISTS idx_articles_published_at ON articles(published_at DESC);
+CREATE INDEX IF NOT EXISTS idx_articles_publish_at ON articles(publish_at) WHERE status = 'scheduled';
+
+-- Trigger untuk auto-update updated_at
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = NOW();
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_articles_updated_at
+    BEFORE UPDATE ON articles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS prevent_article_revisions_update ON article_revisions;
+DROP FUNCTION IF EXISTS prevent_article_revision_update();
+
+DROP INDEX IF EXISTS idx_article_revisions_article_id;
+
+ALTER TABLE article_revisions DROP CONSTRAINT IF EXISTS fk_article_revisions_article;
+DROP TABLE IF EXISTS article_revisions;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id VARCHAR(255) PRIMARY KEY,
+    article_id VARCHAR(255) NOT NULL,
+    revision_number INT NOT NULL,
+    author_id VARCHAR(255) NOT NULL, -- pengguna yang menyimpan revisi
+    snapshot JSONB NOT NULL,         -- salinan lengkap konten artikel saat disimpan
+    change_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (article_id, revision_number),
+
+    CONSTRAINT fk_article_revisions_article
+        FOREIGN KEY(article_id)
+            REFERENCES articles(id)
+            ON DELETE CASCADE
+);
+
+-- Indexes untuk performance
+CREATE INDEX IF NOT EXISTS idx_article_revisions_article_id ON article_revisions(article_id);
+
+-- Revisi bersifat immutable, tolak semua UPDATE
+CREATE OR REPLACE FUNCTION prevent_article_revision_update()
+RETURNS TRIGGER AS $$
+BEGIN
+   RAISE EXCEPTION 'article_revisions is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER prevent_article_revisions_update
+    BEFORE UPDATE ON article_revisions
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_article_revision_update();
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_article_transitions_article_id;
+
+ALTER TABLE article_transitions DROP CONSTRAINT IF EXISTS fk_article_transitions_article;
+DROP TABLE IF EXISTS article_transitions;
+
+DROP INDEX IF EXISTS idx_articles_unpublish_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS unpublish_at;
@@ -0,0 +1,24 @@
+-- Embargo: artikel terbit otomatis diarsipkan saat unpublish_at tercapai
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_articles_unpublish_at ON articles(unpublish_at) WHERE status = 'published';
+
+CREATE TABLE IF NOT EXISTS article_transitions (
+    id VARCHAR(255) PRIMARY KEY,
+    article_id VARCHAR(255) NOT NULL,
+    action VARCHAR(20) NOT NULL,      -- submit, reject, schedule, publish, archive, ...
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(255),            -- NULL untuk transisi otomatis oleh scheduler
+    automatic BOOLEAN NOT NULL DEFAULT FALSE,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_article_transitions_article
+        FOREIGN KEY(article_id)
+            REFERENCES articles(id)
+            ON DELETE CASCADE
+);
+
+-- Indexes untuk performance
+CREATE INDEX IF NOT EXISTS idx_article_transitions_article_id ON article_transitions(article_id, created_at);
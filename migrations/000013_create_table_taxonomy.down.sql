@@ -0,0 +1,14 @@
+DROP TRIGGER IF EXISTS update_tags_updated_at ON tags;
+DROP TRIGGER IF EXISTS update_categories_updated_at ON categories;
+
+DROP INDEX IF EXISTS idx_articles_category_id;
+DROP INDEX IF EXISTS idx_article_tags_tag_id;
+DROP INDEX IF EXISTS idx_categories_path;
+DROP INDEX IF EXISTS idx_categories_parent_id;
+
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS fk_articles_category;
+ALTER TABLE articles DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS article_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS categories;
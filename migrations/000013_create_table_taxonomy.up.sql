@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id VARCHAR(255) PRIMARY KEY,
+    parent_id VARCHAR(255),
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) NOT NULL,
+    path VARCHAR(1000) NOT NULL UNIQUE, -- gabungan slug dari root, contoh: news/national/politics
+    description TEXT,
+    position INT NOT NULL DEFAULT 0,    -- urutan di antara kategori bersaudara
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_categories_parent
+        FOREIGN KEY(parent_id)
+            REFERENCES categories(id)
+            ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id VARCHAR(255) NOT NULL,
+    tag_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (article_id, tag_id),
+
+    CONSTRAINT fk_article_tags_article
+        FOREIGN KEY(article_id)
+            REFERENCES articles(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_article_tags_tag
+        FOREIGN KEY(tag_id)
+            REFERENCES tags(id)
+            ON DELETE CASCADE
+);
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS category_id VARCHAR(255);
+ALTER TABLE articles ADD CONSTRAINT fk_articles_category
+    FOREIGN KEY(category_id)
+        REFERENCES categories(id)
+        ON DELETE SET NULL;
+
+-- Indexes untuk performance
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id, position);
+CREATE INDEX IF NOT EXISTS idx_categories_path ON categories(path varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_article_tags_tag_id ON article_tags(tag_id);
+CREATE INDEX IF NOT EXISTS idx_articles_category_id ON articles(category_id);
+
+-- Trigger untuk auto-update updated_at
+CREATE TRIGGER update_categories_updated_at
+    BEFORE UPDATE ON categories
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_tags_updated_at
+    BEFORE UPDATE ON tags
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS update_media_assets_updated_at ON media_assets;
+
+DROP INDEX IF EXISTS idx_media_assets_created_at;
+DROP INDEX IF EXISTS idx_media_assets_mime_type;
+DROP INDEX IF EXISTS idx_media_assets_uploader_id;
+
+DROP TABLE IF EXISTS media_renditions;
+DROP TABLE IF EXISTS media_assets;
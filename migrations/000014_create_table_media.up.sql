@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS media_assets (
+    id VARCHAR(255) PRIMARY KEY,
+    uploader_id VARCHAR(255) NOT NULL,
+    filename VARCHAR(255) NOT NULL,        -- nama file asli dari pengunggah
+    storage_key VARCHAR(500) NOT NULL UNIQUE,
+    mime_type VARCHAR(100) NOT NULL,       -- hasil deteksi isi file, bukan dari header request
+    size_bytes BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,         -- SHA-256 isi file, dipakai juga sebagai ETag
+    width INT,
+    height INT,
+    alt_text VARCHAR(500),
+    caption TEXT,
+    credit VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_media_assets_uploader
+        FOREIGN KEY(uploader_id)
+            REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS media_renditions (
+    id VARCHAR(255) PRIMARY KEY,
+    media_id VARCHAR(255) NOT NULL,
+    name VARCHAR(50) NOT NULL,             -- thumbnail, medium, large
+    storage_key VARCHAR(500) NOT NULL UNIQUE,
+    mime_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (media_id, name),
+
+    CONSTRAINT fk_media_renditions_media
+        FOREIGN KEY(media_id)
+            REFERENCES media_assets(id)
+            ON DELETE CASCADE
+);
+
+-- Indexes untuk performance
+CREATE INDEX IF NOT EXISTS idx_media_assets_uploader_id ON media_assets(uploader_id);
+CREATE INDEX IF NOT EXISTS idx_media_assets_mime_type ON media_assets(mime_type);
+CREATE INDEX IF NOT EXISTS idx_media_assets_created_at ON media_assets(created_at DESC);
+
+-- Trigger untuk auto-update updated_at
+CREATE TRIGGER update_media_assets_updated_at
+    BEFORE UPDATE ON media_assets
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_user_profiles_city_code;
+DROP INDEX IF EXISTS idx_user_profiles_province_code;
+
+ALTER TABLE user_profiles
+    DROP COLUMN IF EXISTS district_code,
+    DROP COLUMN IF EXISTS city_code,
+    DROP COLUMN IF EXISTS province_code;
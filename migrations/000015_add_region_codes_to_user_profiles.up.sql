@@ -0,0 +1,8 @@
+-- Kode wilayah Kemendagri untuk profil, dapat diisi otomatis dari NIK
+ALTER TABLE user_profiles
+    ADD COLUMN IF NOT EXISTS province_code VARCHAR(2),
+    ADD COLUMN IF NOT EXISTS city_code VARCHAR(4),
+    ADD COLUMN IF NOT EXISTS district_code VARCHAR(6);
+
+CREATE INDEX IF NOT EXISTS idx_user_profiles_province_code ON user_profiles(province_code);
+CREATE INDEX IF NOT EXISTS idx_user_profiles_city_code ON user_profiles(city_code);
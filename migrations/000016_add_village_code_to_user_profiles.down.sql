@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_user_profiles_district_code;
+
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS village_code;
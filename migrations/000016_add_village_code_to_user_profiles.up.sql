@@ -0,0 +1,4 @@
+-- Kode desa/kelurahan Kemendagri, melengkapi kode wilayah profil
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS village_code VARCHAR(10);
+
+CREATE INDEX IF NOT EXISTS idx_user_profiles_district_code ON user_profiles(district_code);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS phone_verifications;
+
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS phone_verified_at;
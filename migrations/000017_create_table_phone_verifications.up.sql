@@ -0,0 +1,42 @@
+-- Waktu verifikasi nomor telepon; dikosongkan setiap kali nomor diganti
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Normalkan nomor Indonesia yang sudah tersimpan ke format E.164. Baris yang akan
+-- bertabrakan dengan nomor pengguna lain dibiarkan agar migrasi tidak gagal.
+UPDATE user_profiles p
+SET phone = n.normalized
+FROM (
+    SELECT id,
+           CASE
+               WHEN cleaned LIKE '0%' THEN '+62' || substr(cleaned, 2)
+               WHEN cleaned LIKE '62%' THEN '+' || cleaned
+               WHEN cleaned LIKE '8%' THEN '+62' || cleaned
+               ELSE cleaned
+           END AS normalized
+    FROM (
+        SELECT id, regexp_replace(phone, '[\s\-\.\(\)]', '', 'g') AS cleaned
+        FROM user_profiles
+        WHERE phone IS NOT NULL
+    ) c
+) n
+WHERE p.id = n.id
+  AND p.phone IS DISTINCT FROM n.normalized
+  AND NOT EXISTS (SELECT 1 FROM user_profiles o WHERE o.phone = n.normalized AND o.id <> p.id);
+
+CREATE TABLE IF NOT EXISTS phone_verifications (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    consumed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_phone_verifications_user
+        FOREIGN KEY(user_id)
+            REFERENCES users(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_phone_verifications_user_created ON phone_verifications(user_id, created_at DESC);
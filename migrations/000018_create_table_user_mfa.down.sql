@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS mfa_recovery_codes;
+
+DROP TRIGGER IF EXISTS update_user_mfa_updated_at ON user_mfa;
+DROP TABLE IF EXISTS user_mfa;
+
+ALTER TABLE roles DROP COLUMN IF EXISTS require_mfa;
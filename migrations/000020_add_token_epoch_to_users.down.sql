@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_token ON revoked_tokens(token_id);
+
+ALTER TABLE users DROP COLUMN IF EXISTS tokens_valid_after;
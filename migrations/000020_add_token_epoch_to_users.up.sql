@@ -0,0 +1,6 @@
+-- Epoch token per pengguna: semua token yang diterbitkan sebelum atau pada waktu ini
+-- dianggap dicabut, sehingga "logout dari semua perangkat" tidak perlu mencatat setiap jti
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tokens_valid_after TIMESTAMP WITH TIME ZONE;
+
+-- Indeks unik token_id sudah cukup untuk pencarian berdasarkan jti
+DROP INDEX IF EXISTS idx_revoked_tokens_token;
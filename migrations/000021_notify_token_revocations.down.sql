@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_users_tokens_valid_after;
+
+DROP TRIGGER IF EXISTS notify_users_token_epoch ON users;
+DROP TRIGGER IF EXISTS notify_revoked_tokens ON revoked_tokens;
+
+DROP FUNCTION IF EXISTS notify_user_token_epoch();
+DROP FUNCTION IF EXISTS notify_revoked_token();
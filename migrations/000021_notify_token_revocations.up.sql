@@ -0,0 +1,41 @@
+-- Siarkan setiap pencabutan token lewat NOTIFY agar cache pencabutan di semua instance
+-- aplikasi langsung diperbarui tanpa menunggu sinkronisasi berkala
+CREATE OR REPLACE FUNCTION notify_revoked_token()
+RETURNS TRIGGER AS $$
+BEGIN
+   PERFORM pg_notify('token_revocations', json_build_object(
+       'kind', 'token',
+       'token_id', NEW.token_id,
+       'expires_at', NEW.expires_at
+   )::text);
+   RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_revoked_tokens
+    AFTER INSERT OR UPDATE ON revoked_tokens
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_revoked_token();
+
+-- Siarkan perubahan epoch token pengguna (logout semua perangkat, reset password, dll.)
+CREATE OR REPLACE FUNCTION notify_user_token_epoch()
+RETURNS TRIGGER AS $$
+BEGIN
+   PERFORM pg_notify('token_revocations', json_build_object(
+       'kind', 'user',
+       'user_id', NEW.id,
+       'valid_after', NEW.tokens_valid_after
+   )::text);
+   RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_users_token_epoch
+    AFTER UPDATE OF tokens_valid_after ON users
+    FOR EACH ROW
+    WHEN (NEW.tokens_valid_after IS DISTINCT FROM OLD.tokens_valid_after)
+    EXECUTE FUNCTION notify_user_token_epoch();
+
+-- Dipakai oleh sinkronisasi inkremental cache pencabutan
+CREATE INDEX IF NOT EXISTS idx_users_tokens_valid_after ON users(tokens_valid_after)
+    WHERE tokens_valid_after IS NOT NULL;
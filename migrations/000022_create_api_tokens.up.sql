@@ -0,0 +1,24 @@
+-- Personal access token untuk integrasi (aplikasi mobile, skrip ingestion). Token mentah
+-- hanya ditampilkan sekali saat dibuat; yang disimpan hanya hash SHA-256 dan prefiks
+-- yang aman ditampilkan untuk mengenali token. scopes berisi nama permission yang boleh
+-- dipakai token, dibatasi lagi oleh permission pengguna saat token digunakan.
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token_prefix VARCHAR(32) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    last_used_ip VARCHAR(45),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_api_tokens_user
+        FOREIGN KEY(user_id)
+            REFERENCES users(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user_id ON api_tokens(user_id);
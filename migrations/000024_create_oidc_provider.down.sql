@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS oauth_tokens;
+DROP TABLE IF EXISTS oauth_consents;
+DROP TABLE IF EXISTS oauth_authorization_codes;
+DROP TRIGGER IF EXISTS update_oauth_clients_updated_at ON oauth_clients;
+DROP TABLE IF EXISTS oauth_clients;
@@ -0,0 +1,101 @@
+-- Aplikasi lain (client) yang memakai akun CMS lewat OAuth 2.0 / OpenID Connect.
+-- id adalah client_id; secret_hash NULL berarti public client (SPA/mobile) yang hanya
+-- dapat memakai authorization code dengan PKCE.
+CREATE TABLE IF NOT EXISTS oauth_clients (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    secret_hash VARCHAR(64),
+    redirect_uris TEXT[] NOT NULL DEFAULT '{}',
+    grant_types TEXT[] NOT NULL DEFAULT '{}',
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_oauth_clients_created_by
+        FOREIGN KEY(created_by)
+            REFERENCES users(id)
+            ON DELETE SET NULL
+);
+
+CREATE TRIGGER update_oauth_clients_updated_at
+    BEFORE UPDATE ON oauth_clients
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Authorization code yang belum ditukar. Yang disimpan hanya hash code; setiap code
+-- hanya dapat ditukar sekali dan dihapus saat ditukar.
+CREATE TABLE IF NOT EXISTS oauth_authorization_codes (
+    code_hash VARCHAR(64) PRIMARY KEY,
+    client_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    redirect_uri TEXT NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    nonce VARCHAR(255),
+    code_challenge VARCHAR(128) NOT NULL,
+    auth_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT fk_oauth_authorization_codes_client
+        FOREIGN KEY(client_id)
+            REFERENCES oauth_clients(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_oauth_authorization_codes_user
+        FOREIGN KEY(user_id)
+            REFERENCES users(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_authorization_codes_expires_at ON oauth_authorization_codes(expires_at);
+
+-- Scope yang sudah disetujui pengguna untuk setiap client, agar layar consent tidak
+-- ditampilkan lagi untuk scope yang sama
+CREATE TABLE IF NOT EXISTS oauth_consents (
+    user_id VARCHAR(255) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, client_id),
+    CONSTRAINT fk_oauth_consents_user
+        FOREIGN KEY(user_id)
+            REFERENCES users(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_oauth_consents_client
+        FOREIGN KEY(client_id)
+            REFERENCES oauth_clients(id)
+            ON DELETE CASCADE
+);
+
+-- Access token dan refresh token opaque yang diterbitkan untuk client. Token dari satu
+-- otorisasi berbagi grant_id sehingga dapat dicabut bersamaan. user_id NULL untuk token
+-- client credentials.
+CREATE TABLE IF NOT EXISTS oauth_tokens (
+    id VARCHAR(255) PRIMARY KEY,
+    grant_id VARCHAR(255) NOT NULL,
+    token_type VARCHAR(20) NOT NULL, -- access, refresh
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    client_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255),
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    auth_time TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_oauth_tokens_client
+        FOREIGN KEY(client_id)
+            REFERENCES oauth_clients(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_oauth_tokens_user
+        FOREIGN KEY(user_id)
+            REFERENCES users(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_tokens_grant_id ON oauth_tokens(grant_id);
+CREATE INDEX IF NOT EXISTS idx_oauth_tokens_user_client ON oauth_tokens(user_id, client_id);
+CREATE INDEX IF NOT EXISTS idx_oauth_tokens_expires_at ON oauth_tokens(expires_at);
@@ -0,0 +1,3 @@
+DELETE FROM email_verification_tokens WHERE token_type = 'magic_link';
+
+ALTER TABLE email_verification_tokens DROP COLUMN IF EXISTS nonce_hash;
@@ -0,0 +1,4 @@
+-- Tautan login (magic link) terikat ke browser yang memintanya: hash nonce yang diberikan
+-- ke browser tersebut disimpan bersama token dan harus cocok saat tautan dipakai.
+-- Untuk magic link, kolom token berisi hash SHA-256 dari token di tautan, bukan token mentah.
+ALTER TABLE email_verification_tokens ADD COLUMN IF NOT EXISTS nonce_hash VARCHAR(64);
@@ -0,0 +1,39 @@
+-- Kredensial WebAuthn (passkey atau security key) milik pengguna. credential_id adalah ID
+-- kredensial dalam base64url, public_key adalah kunci publik COSE apa adanya, dan sign_count
+-- adalah penghitung tanda tangan terakhir untuk mendeteksi authenticator yang diklon.
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    credential_id VARCHAR(1400) NOT NULL UNIQUE,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    aaguid VARCHAR(36) NOT NULL,
+    transports TEXT[] NOT NULL DEFAULT '{}',
+    backup_eligible BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_webauthn_credentials_user
+        FOREIGN KEY(user_id)
+            REFERENCES users(id)
+            ON DELETE CASCADE,
+    CONSTRAINT uq_webauthn_credentials_user_name UNIQUE (user_id, name)
+);
+
+-- Challenge registrasi dan login yang belum dipakai, hanya disimpan dalam bentuk hash.
+-- user_id kosong berarti login tanpa username (discoverable credential).
+CREATE TABLE IF NOT EXISTS webauthn_challenges (
+    challenge_hash VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(255),
+    ceremony VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT fk_webauthn_challenges_user
+        FOREIGN KEY(user_id)
+            REFERENCES users(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_challenges_expires_at ON webauthn_challenges(expires_at);
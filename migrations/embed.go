@@ -0,0 +1,11 @@
+// Package migrations menyimpan file SQL migrasi database. File di-embed ke binary
+// sehingga migrasi dapat dijalankan tanpa menyalin direktori ini ke server.
+package migrations
+
+import "embed"
+
+// FS berisi semua file migrasi dengan format penamaan golang-migrate,
+// contoh 000001_create_table_users.up.sql
+//
+//go:embed *.sql
+var FS embed.FS